@@ -0,0 +1,228 @@
+// cmd/account/account.go
+package account
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/TheDucker1/mangaupdatescli/internal/apiclient"
+	"github.com/TheDucker1/mangaupdatescli/internal/config"
+	"github.com/TheDucker1/mangaupdatescli/internal/utils"
+	"github.com/TheDucker1/mangaupdatescli/mangaupdates"
+	"golang.org/x/term"
+	"os"
+	"sort"
+	"strings"
+)
+
+// CommandHandler defines the function signature for command handlers
+type CommandHandler func(ctx context.Context, args []string)
+
+// CommandInfo stores the handler and its associated help content
+type CommandInfo struct {
+	Handler CommandHandler
+	Help    utils.HelpContent
+}
+
+// accountCommands maps the CLI command name to its handler and help
+var accountCommands = make(map[string]CommandInfo)
+
+// init populates accountCommands. The help variables live in account_help.go.
+func init() {
+	accountCommands["login"] = CommandInfo{Handler: handleLogin, Help: loginHelpContent}
+	accountCommands["logout"] = CommandInfo{Handler: handleLogout, Help: logoutHelpContent}
+}
+
+// HandleCommand dispatches to the correct account command handler
+func HandleCommand(ctx context.Context, command string, args []string) {
+	cmdInfo, ok := accountCommands[command]
+	if !ok {
+		isJsonHelp, _, _ := utils.CheckHelpFlags(args)
+		fmt.Fprintf(os.Stderr, "Error: Unknown account command: %s\n\n", command)
+		PrintAccountSubprogramHelp(isJsonHelp)
+		os.Exit(1)
+	}
+	cmdInfo.Handler(ctx, args)
+}
+
+// PrintAccountSubprogramHelp prints help for the entire 'account' subprogram
+func PrintAccountSubprogramHelp(jsonFormat bool) {
+	if jsonFormat {
+		type CommandHelpSummary struct {
+			Command     string `json:"command"`
+			Usage       string `json:"usage"`
+			Description string `json:"description"`
+		}
+		var summaries []CommandHelpSummary
+		var commandNames []string
+		for name := range accountCommands {
+			commandNames = append(commandNames, name)
+		}
+		sort.Strings(commandNames)
+
+		for _, name := range commandNames {
+			cmdInfo := accountCommands[name]
+			summaries = append(summaries, CommandHelpSummary{
+				Command:     name,
+				Usage:       cmdInfo.Help.Usage,
+				Description: cmdInfo.Help.Description,
+			})
+		}
+		outputData := map[string]interface{}{
+			"subprogram":  "account",
+			"description": "Commands for logging in and out of a MangaUpdates account.",
+			"commands":    summaries,
+		}
+		jsonData, _ := json.MarshalIndent(outputData, "", "  ")
+		fmt.Println(string(jsonData))
+	} else {
+		fmt.Println("`account` subprogram: Commands for logging in and out of a MangaUpdates account.")
+		fmt.Println("Available commands:")
+		var commandNames []string
+		for name := range accountCommands {
+			commandNames = append(commandNames, name)
+		}
+		sort.Strings(commandNames)
+
+		for _, name := range commandNames {
+			cmdInfo := accountCommands[name]
+			fmt.Printf("  %-30s %s\n", name, cmdInfo.Help.Description)
+		}
+		fmt.Println("\nUse 'mangaupdatescli account <command> -hh' for more detailed help on a specific command.")
+	}
+}
+
+// --- Handler Functions ---
+
+// handleLogin (PUT /account/login)
+func handleLogin(ctx context.Context, args []string) {
+	fs := flag.NewFlagSet("login", flag.ContinueOnError)
+	var reqBody mangaupdates.AccountLoginRequestV1
+	fs.StringVar(&reqBody.Username, "username", "", "Account username (required).")
+	passwordStdin := fs.Bool("password-stdin", false, "Read the password from the first line of stdin.")
+
+	isJsonHelp, isTextHelp, remainingArgs := utils.CheckHelpFlags(args)
+	if err := fs.Parse(remainingArgs); err != nil {
+		utils.PrintErrorAndExit("Failed to parse flags for 'login'", err)
+	}
+
+	if isJsonHelp {
+		utils.PrintJSONHelp(loginHelpContent)
+		return
+	}
+	if isTextHelp {
+		utils.PrintFormattedHelp(loginHelpContent)
+		return
+	}
+
+	if reqBody.Username == "" {
+		fmt.Fprintln(os.Stderr, "Error: --username is required for login.")
+		utils.PrintFormattedHelp(loginHelpContent)
+		os.Exit(1)
+	}
+	if !*passwordStdin {
+		reqBody.Password = os.Getenv("MANGAUPDATES_PASSWORD")
+	}
+	if reqBody.Password == "" {
+		password, err := readPassword(os.Stdin, *passwordStdin)
+		if err != nil {
+			utils.PrintErrorAndExit("Failed to read password", err)
+		}
+		reqBody.Password = password
+	}
+
+	loginResp, err := mangaupdates.Default().Login(ctx, reqBody.Username, reqBody.Password)
+	if err != nil {
+		utils.PrintErrorAndExit("API request failed for /account/login", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		utils.PrintErrorAndExit("Failed to load config", err)
+	}
+	profile := cfg.ActiveProfile()
+	profile.Username = reqBody.Username
+	profile.SessionToken = loginResp.Context.SessionToken
+	profile.SessionAPIURL = apiclient.Default().BaseURL()
+	if err := config.Save(cfg); err != nil {
+		utils.PrintErrorAndExit("Failed to store session token", err)
+	}
+
+	fmt.Printf("Logged in as %s (uid %d) in profile %q.\n", reqBody.Username, loginResp.Context.UID, cfg.ActiveProfileName())
+}
+
+// handleLogout (POST /account/logout)
+func handleLogout(ctx context.Context, args []string) {
+	fs := flag.NewFlagSet("logout", flag.ContinueOnError)
+
+	isJsonHelp, isTextHelp, remainingArgs := utils.CheckHelpFlags(args)
+	if err := fs.Parse(remainingArgs); err != nil {
+		utils.PrintErrorAndExit("Failed to parse flags for 'logout'", err)
+	}
+
+	if isJsonHelp {
+		utils.PrintJSONHelp(logoutHelpContent)
+		return
+	}
+	if isTextHelp {
+		utils.PrintFormattedHelp(logoutHelpContent)
+		return
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		utils.PrintErrorAndExit("Failed to load config", err)
+	}
+	profile := cfg.ActiveProfile()
+	if profile.SessionToken == "" {
+		fmt.Printf("Profile %q is not logged in.\n", cfg.ActiveProfileName())
+		return
+	}
+
+	_, err = mangaupdates.Default().Logout(ctx)
+	// An expired token is as good as logged out; only other failures are fatal.
+	if err != nil && !errors.Is(err, mangaupdates.ErrSessionExpired) {
+		utils.PrintErrorAndExit("API request failed for /account/logout", err)
+	}
+
+	username := profile.Username
+	profile.Username = ""
+	profile.SessionToken = ""
+	profile.SessionAPIURL = ""
+	if err := config.Save(cfg); err != nil {
+		utils.PrintErrorAndExit("Failed to remove stored session token", err)
+	}
+
+	fmt.Printf("Logged out %s from profile %q.\n", username, cfg.ActiveProfileName())
+}
+
+// readPassword reads the password from the first line of in with
+// --password-stdin. Otherwise in must be a terminal, where it prompts on
+// stderr and reads the password without echoing it.
+func readPassword(in *os.File, fromStdin bool) (string, error) {
+	if fromStdin {
+		line, err := bufio.NewReader(in).ReadString('\n')
+		if err != nil && line == "" {
+			return "", err
+		}
+		password := strings.TrimRight(line, "\r\n")
+		if password == "" {
+			return "", errors.New("the first line of stdin is empty")
+		}
+		return password, nil
+	}
+	fd := int(in.Fd())
+	if !term.IsTerminal(fd) {
+		return "", errors.New("stdin is not a terminal; pass the password with --password-stdin or MANGAUPDATES_PASSWORD")
+	}
+	fmt.Fprint(os.Stderr, "Password: ")
+	password, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", err
+	}
+	return string(password), nil
+}
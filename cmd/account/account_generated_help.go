@@ -0,0 +1,45 @@
+// Code generated by tools/helpcodegen/main.go; DO NOT EDIT.
+package account
+
+import "github.com/TheDucker1/mangaupdatescli/internal/utils"
+
+var helpLoginContent = utils.HelpContent{
+	Usage:       "mangaupdatescli account login --username <string> --password <string> [--body <JSON|YAML|@file|->]",
+	Description: "Log in and start a session",
+	Arguments: []utils.ArgHelp{
+		{
+			Name:        "username",
+			Type:        "string",
+			Required:    true,
+			Description: "Account username.",
+			In:          "body",
+		},
+		{
+			Name:        "password",
+			Type:        "string",
+			Required:    true,
+			Description: "Account password.",
+			In:          "body",
+		},
+		{
+			Name:        "body",
+			Type:        "JSON|YAML",
+			Required:    false,
+			Description: "Request body as a JSON or YAML document, @file to read it from a file, or - for stdin. The flags of its fields override it.",
+		},
+	},
+	InputJSON:     utils.SchemaHelp{Label: "Request Body Schema (required)", Schema: &utils.Schema{Ref: "AccountLoginRequestV1"}},
+	OutputJSON:    utils.SchemaHelp{Label: "Schema (on 200)", Schema: &utils.Schema{Ref: "AccountLoginResponseV1"}},
+	ErrorExamples: map[string]string{"400": "Validation or Service Error", "401": "Invalid credentials"},
+	AuthRequired:  false,
+}
+
+var helpLogoutContent = utils.HelpContent{
+	Usage:         "mangaupdatescli account logout [REQUIRES AUTH]",
+	Description:   "End the current session",
+	Arguments:     nil,
+	InputJSON:     "None",
+	OutputJSON:    utils.SchemaHelp{Label: "Schema (on 200)", Schema: &utils.Schema{Ref: "ApiResponseV1"}},
+	ErrorExamples: map[string]string{"401": "Unauthorized"},
+	AuthRequired:  true,
+}
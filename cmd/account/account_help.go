@@ -0,0 +1,40 @@
+// cmd/account/account_help.go
+package account
+
+import "github.com/TheDucker1/mangaupdatescli/internal/utils"
+
+// Help for the account commands is written by hand: they manage the local
+// session as well as calling the API, so the generated text does not fit.
+
+var loginHelpContent = utils.HelpContent{
+	Usage:       "mangaupdatescli account login --username <string> [--password-stdin]",
+	Description: "Log in to MangaUpdates and store the session token in the active profile.",
+	Arguments: []utils.ArgHelp{
+		{
+			Name:        "username",
+			Type:        "string",
+			Required:    true,
+			Description: "Account username.",
+		},
+		{
+			Name:        "password-stdin",
+			Type:        "boolean",
+			Required:    false,
+			Description: "Read the password from the first line of stdin. Without it, the password is read from MANGAUPDATES_PASSWORD, or prompted for on the terminal without echoing it.",
+		},
+	},
+	InputJSON:     utils.SchemaHelp{Label: "Request Body Schema (required)", Schema: &utils.Schema{Ref: "AccountLoginRequestV1"}},
+	OutputJSON:    "Prints the logged in username; the session token is stored, not printed.",
+	ErrorExamples: map[string]string{"400": "Validation or Service Error", "401": "Invalid credentials"},
+	AuthRequired:  false,
+}
+
+var logoutHelpContent = utils.HelpContent{
+	Usage:         "mangaupdatescli account logout",
+	Description:   "Invalidate the session on MangaUpdates and remove the token from the active profile.",
+	Arguments:     nil,
+	InputJSON:     "None",
+	OutputJSON:    utils.SchemaHelp{Label: "Schema (on 200)", Schema: &utils.Schema{Ref: "ApiResponseV1"}},
+	ErrorExamples: map[string]string{"401": "Session already expired (the stored token is removed anyway)"},
+	AuthRequired:  true,
+}
@@ -0,0 +1,134 @@
+package account
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/TheDucker1/mangaupdatescli/internal/apiclient"
+	"github.com/TheDucker1/mangaupdatescli/internal/config"
+	"github.com/TheDucker1/mangaupdatescli/internal/testutil"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+// useAccountServer points the default client, with session token token, at
+// a server for /account/login and /account/logout. Logout answers
+// logoutStatus. It returns the Authorization headers of the logout
+// requests.
+func useAccountServer(t *testing.T, token string, logoutStatus int) (*[]string, string) {
+	t.Helper()
+	var logouts []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "PUT" && r.URL.Path == "/v1/account/login":
+			var body struct{ Username, Password string }
+			json.NewDecoder(r.Body).Decode(&body)
+			if body.Username != "reader" || body.Password != "s3cret pass" {
+				w.WriteHeader(http.StatusUnauthorized)
+				io.WriteString(w, `{"status":"exception","reason":"Invalid credentials."}`)
+				return
+			}
+			io.WriteString(w, `{"status":"success","reason":"Logged in.","context":{"session_token":"NEWTOKEN","uid":7}}`)
+		case r.Method == "POST" && r.URL.Path == "/v1/account/logout":
+			logouts = append(logouts, r.Header.Get("Authorization"))
+			w.WriteHeader(logoutStatus)
+			io.WriteString(w, `{"status":"success","reason":"Logged out."}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	client, err := apiclient.New(
+		apiclient.WithBaseURL(srv.URL+"/v1"),
+		apiclient.WithSessionToken(token),
+		apiclient.WithLogger(log.New(io.Discard, "", 0)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved := apiclient.Default()
+	apiclient.SetDefault(client)
+	t.Cleanup(func() { apiclient.SetDefault(saved) })
+	return &logouts, client.BaseURL()
+}
+
+// useConfigDir stores the profiles of cfg in a new config directory.
+func useConfigDir(t *testing.T, cfg *config.Config) {
+	t.Helper()
+	t.Setenv("MANGAUPDATESCLI_CONFIG_DIR", t.TempDir())
+	t.Setenv("MANGAUPDATESCLI_PROFILE", "")
+	t.Setenv("MANGAUPDATES_PASSWORD", "")
+	if err := config.Save(cfg); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func loadProfile(t *testing.T) *config.Profile {
+	t.Helper()
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cfg.ActiveProfile()
+}
+
+func TestLoginStoresSession(t *testing.T) {
+	_, baseURL := useAccountServer(t, "", http.StatusOK)
+	useConfigDir(t, &config.Config{})
+	testutil.WithStdin(t, "s3cret pass\r\nignored\n", func() {
+		handleLogin(context.Background(), []string{"--username", "reader", "--password-stdin"})
+	})
+	p := loadProfile(t)
+	if p.Username != "reader" || p.SessionToken != "NEWTOKEN" || p.SessionAPIURL != baseURL {
+		t.Errorf("profile after login = %+v; want reader, NEWTOKEN and %s", p, baseURL)
+	}
+}
+
+func TestLogoutClearsSession(t *testing.T) {
+	for _, status := range []int{http.StatusOK, http.StatusUnauthorized} {
+		logouts, baseURL := useAccountServer(t, "OLDTOKEN", status)
+		useConfigDir(t, &config.Config{Profiles: map[string]*config.Profile{
+			config.DefaultProfile: {Username: "reader", SessionToken: "OLDTOKEN", SessionAPIURL: baseURL, Proxy: "http://proxy:3128"},
+		}})
+		handleLogout(context.Background(), nil)
+		if len(*logouts) != 1 || (*logouts)[0] != "Bearer OLDTOKEN" {
+			t.Errorf("logout (%d) sent Authorization %q; want Bearer OLDTOKEN", status, *logouts)
+		}
+		// An expired session is removed too; other settings stay.
+		p := loadProfile(t)
+		if p.Username != "" || p.SessionToken != "" || p.SessionAPIURL != "" || p.Proxy != "http://proxy:3128" {
+			t.Errorf("profile after logout (%d) = %+v; want no session and the proxy kept", status, p)
+		}
+	}
+}
+
+func TestReadPassword(t *testing.T) {
+	tests := []struct {
+		input     string
+		fromStdin bool
+		want      string
+		wantErr   string
+	}{
+		{input: "pass word\n", fromStdin: true, want: "pass word"},
+		{input: "last line", fromStdin: true, want: "last line"},
+		{input: "\nsecond\n", fromStdin: true, wantErr: "empty"},
+		{input: "", fromStdin: true, wantErr: "EOF"},
+		// A file is not a terminal, so without --password-stdin nothing is read.
+		{input: "pass\n", fromStdin: false, wantErr: "--password-stdin"},
+	}
+	for _, tt := range tests {
+		var got string
+		var err error
+		testutil.WithStdin(t, tt.input, func() { got, err = readPassword(os.Stdin, tt.fromStdin) })
+		switch {
+		case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+			t.Errorf("readPassword(%q, %v) error = %v; want one containing %q", tt.input, tt.fromStdin, err, tt.wantErr)
+		case tt.wantErr == "" && (err != nil || got != tt.want):
+			t.Errorf("readPassword(%q, %v) = %q, %v; want %q", tt.input, tt.fromStdin, got, err, tt.want)
+		}
+	}
+}
@@ -2,19 +2,19 @@
 package authors
 
 import (
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
-	"mangaupdatescli/internal/apiclient"
-	"mangaupdatescli/internal/utils"
-	"net/http"
+	"github.com/TheDucker1/mangaupdatescli/internal/utils"
+	"github.com/TheDucker1/mangaupdatescli/mangaupdates"
+	"io"
 	"os"
 	"sort"
-	"strings"
 )
 
 // CommandHandler defines the function signature for command handlers
-type CommandHandler func(args []string)
+type CommandHandler func(ctx context.Context, args []string)
 
 // CommandInfo stores the handler and its associated (generated) help content
 type CommandInfo struct {
@@ -37,7 +37,7 @@ func init() {
 	// Ensure helpRetrieveAuthorContent is defined in authors_generated_help.go
 	authorsCommands["retrieveAuthor"] = CommandInfo{
 		Handler: handleRetrieveAuthor,
-		Help:    helpRetrieveAuthorContent, // This var comes from the generated file
+		Help:    utils.BulkIDHelp(helpRetrieveAuthorContent), // This var comes from the generated file
 	}
 
 	// Ensure helpRetrieveAuthorLocksContent is defined in authors_generated_help.go
@@ -60,7 +60,7 @@ func init() {
 }
 
 // HandleCommand dispatches to the correct authors command handler
-func HandleCommand(command string, args []string) {
+func HandleCommand(ctx context.Context, command string, args []string) {
 	cmdInfo, ok := authorsCommands[command]
 	if !ok {
 		isJsonHelp, _, _ := utils.CheckHelpFlags(args)
@@ -68,7 +68,7 @@ func HandleCommand(command string, args []string) {
 		PrintAuthorsSubprogramHelp(isJsonHelp)
 		os.Exit(1)
 	}
-	cmdInfo.Handler(args)
+	cmdInfo.Handler(ctx, args)
 }
 
 // PrintAuthorsSubprogramHelp prints help for the entire 'authors' subprogram
@@ -121,9 +121,9 @@ func PrintAuthorsSubprogramHelp(jsonFormat bool) {
 // --- Handler Functions ---
 
 // handleRetrieveAuthor (GET /authors/{id})
-func handleRetrieveAuthor(args []string) {
+func handleRetrieveAuthor(ctx context.Context, args []string) {
 	fs := flag.NewFlagSet("retrieveAuthor", flag.ContinueOnError)
-	authorID := fs.Int64("id", 0, "Author ID (required).")                                      // From help: Name "id"
+	authorIDs := utils.AddBulkIDFlags(fs, "Author")
 	unrenderedFields := fs.Bool("unrenderedFields", false, "Output fields in unrendered form.") // From help: Name "unrenderedFields"
 
 	isJsonHelp, isTextHelp, remainingArgs := utils.CheckHelpFlags(args)
@@ -132,47 +132,54 @@ func handleRetrieveAuthor(args []string) {
 	}
 
 	if isJsonHelp {
-		utils.PrintJSONHelp(helpRetrieveAuthorContent)
+		utils.PrintJSONHelp(utils.BulkIDHelp(helpRetrieveAuthorContent))
 		return
 	}
 	if isTextHelp {
-		utils.PrintFormattedHelp(helpRetrieveAuthorContent)
+		utils.PrintFormattedHelp(utils.BulkIDHelp(helpRetrieveAuthorContent))
 		return
 	}
 
-	if *authorID == 0 {
+	ids, err := authorIDs.IDs(os.Stdin)
+	if err != nil {
+		utils.PrintErrorAndExit("Invalid arguments for 'retrieveAuthor'", err)
+	}
+	if len(ids) == 0 {
 		fmt.Fprintln(os.Stderr, "Error: --id is required for retrieveAuthor.")
-		utils.PrintFormattedHelp(helpRetrieveAuthorContent)
+		utils.PrintFormattedHelp(utils.BulkIDHelp(helpRetrieveAuthorContent))
 		os.Exit(1)
 	}
 
-	path := fmt.Sprintf("/authors/%d", *authorID)
-	queryParams := make(map[string]string)
-	if *unrenderedFields {
-		queryParams["unrenderedFields"] = "true"
-	}
+	opts := &mangaupdates.RetrieveOptions{UnrenderedFields: *unrenderedFields}
 
-	fullURL, err := apiclient.BuildURL(path, queryParams)
-	if err != nil {
-		utils.PrintErrorAndExit("Failed to build URL for /authors/{id}", err)
+	if authorIDs.IsBatch() {
+		err := utils.RunBulk(ctx, ids, authorIDs.Parallel, func(ctx context.Context, id int64) ([]byte, error) {
+			body, err := mangaupdates.Default().RetrieveAuthorRaw(ctx, id, opts)
+			if err != nil {
+				return nil, err
+			}
+			defer body.Close()
+			return io.ReadAll(body)
+		})
+		if err != nil {
+			utils.PrintErrorAndExit("Bulk retrieval failed for /authors/{id}", err)
+		}
+		return
 	}
 
-	respBody, statusCode, err := apiclient.DoRequest("GET", fullURL, nil)
+	respBody, err := mangaupdates.Default().RetrieveAuthorRaw(ctx, ids[0], opts)
 	if err != nil {
 		utils.PrintErrorAndExit("API request failed for /authors/{id}", err)
 	}
+	defer respBody.Close()
 
-	if statusCode == http.StatusOK || statusCode == http.StatusNotFound { // 404 is an expected response
-		utils.PrintJSON(respBody)
-	} else {
-		fmt.Fprintf(os.Stderr, "API request for /authors/{id} failed with status %d:\n", statusCode)
-		utils.PrintJSON(respBody)
-		os.Exit(1)
+	if err := utils.PrintJSONStream(respBody); err != nil {
+		utils.PrintErrorAndExit("API request failed for /authors/{id}", err)
 	}
 }
 
 // handleRetrieveAuthorLocks (GET /authors/{id}/locks)
-func handleRetrieveAuthorLocks(args []string) {
+func handleRetrieveAuthorLocks(ctx context.Context, args []string) {
 	fs := flag.NewFlagSet("retrieveAuthorLocks", flag.ContinueOnError)
 	authorID := fs.Int64("id", 0, "Author ID (required).") // From help: Name "id"
 
@@ -196,53 +203,22 @@ func handleRetrieveAuthorLocks(args []string) {
 		os.Exit(1)
 	}
 
-	path := fmt.Sprintf("/authors/%d/locks", *authorID)
-	fullURL, err := apiclient.BuildURL(path, nil)
-	if err != nil {
-		utils.PrintErrorAndExit("Failed to build URL for /authors/{id}/locks", err)
-	}
-
-	respBody, statusCode, err := apiclient.DoRequest("GET", fullURL, nil)
+	respBody, err := mangaupdates.Default().RetrieveAuthorLocksRaw(ctx, *authorID)
 	if err != nil {
 		utils.PrintErrorAndExit("API request failed for /authors/{id}/locks", err)
 	}
+	defer respBody.Close()
 
-	// This endpoint might require auth in practice, but spec implies it might be readable.
-	// If it returns 401/403, that's an API-level restriction.
-	if statusCode == http.StatusOK || statusCode == http.StatusNotFound { // 404 if author not found
-		utils.PrintJSON(respBody)
-	} else {
-		fmt.Fprintf(os.Stderr, "API request for /authors/{id}/locks failed with status %d:\n", statusCode)
-		utils.PrintJSON(respBody)
-		os.Exit(1)
+	if err := utils.PrintJSONStream(respBody); err != nil {
+		utils.PrintErrorAndExit("API request failed for /authors/{id}/locks", err)
 	}
 }
 
 // handleSearchAuthorsPost (POST /authors/search)
-// Define AuthorsSearchRequestV1 for the request body (from OpenAPI spec, simplified)
-type AuthorsSearchRequestV1 struct {
-	Search  string   `json:"search,omitempty"`
-	AddedBy int64    `json:"added_by,omitempty"` // Assuming int64, adjust if needed
-	Page    int      `json:"page,omitempty"`
-	Perpage int      `json:"perpage,omitempty"`
-	Letter  string   `json:"letter,omitempty"`
-	Genre   []string `json:"genre,omitempty"`
-	Orderby string   `json:"orderby,omitempty"` // enum: name, series, score
-	Pending bool     `json:"pending,omitempty"`
-}
-
-func handleSearchAuthorsPost(args []string) {
+func handleSearchAuthorsPost(ctx context.Context, args []string) {
 	fs := flag.NewFlagSet("searchAuthorsPost", flag.ContinueOnError)
-	var reqBody AuthorsSearchRequestV1
-
-	fs.StringVar(&reqBody.Search, "search", "", "Search term.")
-	addedBy := fs.Int64("added_by", 0, "Filter by user ID who added the author.") // Flag name matches param in spec
-	fs.IntVar(&reqBody.Page, "page", 0, "Page number.")
-	fs.IntVar(&reqBody.Perpage, "perpage", 0, "Results per page.")
-	fs.StringVar(&reqBody.Letter, "letter", "", "Filter by starting letter.")
-	genreStr := fs.String("genre", "", "Comma-separated list of genres.")
-	fs.StringVar(&reqBody.Orderby, "orderby", "", "Order by (name, series, score).")
-	fs.BoolVar(&reqBody.Pending, "pending", false, "Include pending authors.")
+	var reqBody mangaupdates.AuthorsSearchRequestV1
+	bodyFlags := utils.AddBodyFlags(fs, helpSearchAuthorsPostContent) // --search, --orderby, ... from the request schema
 
 	isJsonHelp, isTextHelp, remainingArgs := utils.CheckHelpFlags(args)
 	if err := fs.Parse(remainingArgs); err != nil {
@@ -258,44 +234,28 @@ func handleSearchAuthorsPost(args []string) {
 		return
 	}
 
-	// Populate struct from flags
-	if *addedBy != 0 {
-		reqBody.AddedBy = *addedBy
-	}
-	if *genreStr != "" {
-		reqBody.Genre = strings.Split(*genreStr, ",")
-	}
-	// API defaults will handle page/perpage if 0 and omitempty is used.
-
-	fullURL, err := apiclient.BuildURL("/authors/search", nil)
-	if err != nil {
-		utils.PrintErrorAndExit("Failed to build URL for /authors/search", err)
+	// API defaults will handle fields without a flag, since they are omitted.
+	if err := bodyFlags.Decode(&reqBody); err != nil {
+		utils.PrintErrorAndExit("Invalid flags for 'searchAuthorsPost'", err)
 	}
 
-	respBody, statusCode, err := apiclient.DoRequest("POST", fullURL, reqBody)
+	respBody, err := mangaupdates.Default().SearchAuthorsRaw(ctx, &reqBody)
 	if err != nil {
 		utils.PrintErrorAndExit("API request failed for /authors/search", err)
 	}
+	defer respBody.Close()
 
-	if statusCode == http.StatusOK { // 400 is for validation/service error
-		utils.PrintJSON(respBody)
-	} else {
-		fmt.Fprintf(os.Stderr, "API request for /authors/search failed with status %d:\n", statusCode)
-		utils.PrintJSON(respBody)
-		os.Exit(1)
+	if err := utils.PrintJSONStream(respBody); err != nil {
+		utils.PrintErrorAndExit("API request failed for /authors/search", err)
 	}
 }
 
 // handleRetrieveAuthorSeries (POST /authors/{id}/series)
-type AuthorsSeriesListRequestV1 struct {
-	Orderby string `json:"orderby,omitempty"` // enum: title, year
-}
-
-func handleRetrieveAuthorSeries(args []string) {
+func handleRetrieveAuthorSeries(ctx context.Context, args []string) {
 	fs := flag.NewFlagSet("retrieveAuthorSeries", flag.ContinueOnError)
 	authorID := fs.Int64("id", 0, "Author ID (required).") // Path parameter
-	var reqBody AuthorsSeriesListRequestV1
-	fs.StringVar(&reqBody.Orderby, "orderby", "", "Order by (title, year).") // Request body field
+	var reqBody mangaupdates.AuthorsSeriesListRequestV1
+	bodyFlags := utils.AddBodyFlags(fs, helpRetrieveAuthorSeriesContent) // Request body fields
 
 	isJsonHelp, isTextHelp, remainingArgs := utils.CheckHelpFlags(args)
 	if err := fs.Parse(remainingArgs); err != nil {
@@ -316,23 +276,17 @@ func handleRetrieveAuthorSeries(args []string) {
 		utils.PrintFormattedHelp(helpRetrieveAuthorSeriesContent)
 		os.Exit(1)
 	}
-
-	path := fmt.Sprintf("/authors/%d/series", *authorID)
-	fullURL, err := apiclient.BuildURL(path, nil)
-	if err != nil {
-		utils.PrintErrorAndExit("Failed to build URL for /authors/{id}/series", err)
+	if err := bodyFlags.Decode(&reqBody); err != nil {
+		utils.PrintErrorAndExit("Invalid flags for 'retrieveAuthorSeries'", err)
 	}
 
-	respBody, statusCode, err := apiclient.DoRequest("POST", fullURL, reqBody)
+	respBody, err := mangaupdates.Default().RetrieveAuthorSeriesRaw(ctx, *authorID, &reqBody)
 	if err != nil {
 		utils.PrintErrorAndExit("API request failed for /authors/{id}/series", err)
 	}
+	defer respBody.Close()
 
-	if statusCode == http.StatusOK || statusCode == http.StatusNotFound { // 404 if author not found
-		utils.PrintJSON(respBody)
-	} else {
-		fmt.Fprintf(os.Stderr, "API request for /authors/{id}/series failed with status %d:\n", statusCode)
-		utils.PrintJSON(respBody)
-		os.Exit(1)
+	if err := utils.PrintJSONStream(respBody); err != nil {
+		utils.PrintErrorAndExit("API request failed for /authors/{id}/series", err)
 	}
 }
@@ -0,0 +1,187 @@
+// cmd/cache/cache.go
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/TheDucker1/mangaupdatescli/internal/apiclient"
+	"github.com/TheDucker1/mangaupdatescli/internal/config"
+	"github.com/TheDucker1/mangaupdatescli/internal/utils"
+	"os"
+	"sort"
+)
+
+// CommandHandler defines the function signature for command handlers
+type CommandHandler func(ctx context.Context, args []string)
+
+// CommandInfo stores the handler and its associated help content
+type CommandInfo struct {
+	Handler CommandHandler
+	Help    utils.HelpContent
+}
+
+// cacheCommands maps the CLI command name to its handler and help
+var cacheCommands = make(map[string]CommandInfo)
+
+// init populates cacheCommands. The help variables live in cache_help.go.
+func init() {
+	cacheCommands["stats"] = CommandInfo{Handler: handleStats, Help: statsHelpContent}
+	cacheCommands["clear"] = CommandInfo{Handler: handleClear, Help: clearHelpContent}
+	cacheCommands["prune"] = CommandInfo{Handler: handlePrune, Help: pruneHelpContent}
+}
+
+// HandleCommand dispatches to the correct cache command handler
+func HandleCommand(ctx context.Context, command string, args []string) {
+	cmdInfo, ok := cacheCommands[command]
+	if !ok {
+		isJsonHelp, _, _ := utils.CheckHelpFlags(args)
+		fmt.Fprintf(os.Stderr, "Error: Unknown cache command: %s\n\n", command)
+		PrintCacheSubprogramHelp(isJsonHelp)
+		os.Exit(1)
+	}
+	cmdInfo.Handler(ctx, args)
+}
+
+// PrintCacheSubprogramHelp prints help for the entire 'cache' subprogram
+func PrintCacheSubprogramHelp(jsonFormat bool) {
+	if jsonFormat {
+		type CommandHelpSummary struct {
+			Command     string `json:"command"`
+			Usage       string `json:"usage"`
+			Description string `json:"description"`
+		}
+		var summaries []CommandHelpSummary
+		var commandNames []string
+		for name := range cacheCommands {
+			commandNames = append(commandNames, name)
+		}
+		sort.Strings(commandNames)
+
+		for _, name := range commandNames {
+			cmdInfo := cacheCommands[name]
+			summaries = append(summaries, CommandHelpSummary{
+				Command:     name,
+				Usage:       cmdInfo.Help.Usage,
+				Description: cmdInfo.Help.Description,
+			})
+		}
+		outputData := map[string]interface{}{
+			"subprogram":  "cache",
+			"description": "Commands for managing the local API response cache.",
+			"commands":    summaries,
+		}
+		jsonData, _ := json.MarshalIndent(outputData, "", "  ")
+		fmt.Println(string(jsonData))
+	} else {
+		fmt.Println("`cache` subprogram: Commands for managing the local API response cache.")
+		fmt.Println("Available commands:")
+		var commandNames []string
+		for name := range cacheCommands {
+			commandNames = append(commandNames, name)
+		}
+		sort.Strings(commandNames)
+
+		for _, name := range commandNames {
+			cmdInfo := cacheCommands[name]
+			fmt.Printf("  %-30s %s\n", name, cmdInfo.Help.Description)
+		}
+		fmt.Println("\nUse 'mangaupdatescli cache <command> -hh' for more detailed help on a specific command.")
+	}
+}
+
+// --- Handler Functions ---
+
+// openCache returns the response cache used by the API commands.
+func openCache() *apiclient.Cache {
+	cacheDir, err := config.CacheDir()
+	if err != nil {
+		utils.PrintErrorAndExit("Failed to locate cache directory", err)
+	}
+	return apiclient.NewCache(apiclient.DefaultCacheDir(cacheDir))
+}
+
+// handleStats prints cache statistics as JSON
+func handleStats(ctx context.Context, args []string) {
+	fs := flag.NewFlagSet("stats", flag.ContinueOnError)
+
+	isJsonHelp, isTextHelp, remainingArgs := utils.CheckHelpFlags(args)
+	if err := fs.Parse(remainingArgs); err != nil {
+		utils.PrintErrorAndExit("Failed to parse flags for 'stats'", err)
+	}
+
+	if isJsonHelp {
+		utils.PrintJSONHelp(statsHelpContent)
+		return
+	}
+	if isTextHelp {
+		utils.PrintFormattedHelp(statsHelpContent)
+		return
+	}
+
+	stats, err := openCache().Stats()
+	if err != nil {
+		utils.PrintErrorAndExit("Failed to read cache", err)
+	}
+	ttls := make(map[string]string)
+	for family, ttl := range apiclient.CacheTTLs {
+		ttls[family] = ttl.String()
+	}
+	output := struct {
+		apiclient.CacheStats
+		TTLs map[string]string `json:"ttls"`
+	}{stats, ttls}
+	jsonData, _ := json.Marshal(output)
+	utils.PrintJSON(jsonData)
+}
+
+// handleClear deletes every cache entry
+func handleClear(ctx context.Context, args []string) {
+	fs := flag.NewFlagSet("clear", flag.ContinueOnError)
+
+	isJsonHelp, isTextHelp, remainingArgs := utils.CheckHelpFlags(args)
+	if err := fs.Parse(remainingArgs); err != nil {
+		utils.PrintErrorAndExit("Failed to parse flags for 'clear'", err)
+	}
+
+	if isJsonHelp {
+		utils.PrintJSONHelp(clearHelpContent)
+		return
+	}
+	if isTextHelp {
+		utils.PrintFormattedHelp(clearHelpContent)
+		return
+	}
+
+	removed, err := openCache().Clear()
+	if err != nil {
+		utils.PrintErrorAndExit("Failed to clear cache", err)
+	}
+	fmt.Printf("Removed %d cached responses.\n", removed)
+}
+
+// handlePrune deletes expired cache entries
+func handlePrune(ctx context.Context, args []string) {
+	fs := flag.NewFlagSet("prune", flag.ContinueOnError)
+
+	isJsonHelp, isTextHelp, remainingArgs := utils.CheckHelpFlags(args)
+	if err := fs.Parse(remainingArgs); err != nil {
+		utils.PrintErrorAndExit("Failed to parse flags for 'prune'", err)
+	}
+
+	if isJsonHelp {
+		utils.PrintJSONHelp(pruneHelpContent)
+		return
+	}
+	if isTextHelp {
+		utils.PrintFormattedHelp(pruneHelpContent)
+		return
+	}
+
+	removed, err := openCache().Prune()
+	if err != nil {
+		utils.PrintErrorAndExit("Failed to prune cache", err)
+	}
+	fmt.Printf("Removed %d expired responses.\n", removed)
+}
@@ -0,0 +1,34 @@
+// cmd/cache/cache_help.go
+package cache
+
+import "github.com/TheDucker1/mangaupdatescli/internal/utils"
+
+var statsHelpContent = utils.HelpContent{
+	Usage:         "mangaupdatescli cache stats",
+	Description:   "Show the location, size and number of cached API responses.",
+	Arguments:     nil,
+	InputJSON:     "None",
+	OutputJSON:    "Object with dir, entries, expired, bytes, families and ttls",
+	ErrorExamples: map[string]string{"Generic": "Unreadable cache directory."},
+	AuthRequired:  false,
+}
+
+var clearHelpContent = utils.HelpContent{
+	Usage:         "mangaupdatescli cache clear",
+	Description:   "Delete every cached API response.",
+	Arguments:     nil,
+	InputJSON:     "None",
+	OutputJSON:    "Confirmation message",
+	ErrorExamples: map[string]string{"Generic": "Cache directory could not be removed."},
+	AuthRequired:  false,
+}
+
+var pruneHelpContent = utils.HelpContent{
+	Usage:         "mangaupdatescli cache prune",
+	Description:   "Delete cached API responses whose TTL has expired.",
+	Arguments:     nil,
+	InputJSON:     "None",
+	OutputJSON:    "Confirmation message",
+	ErrorExamples: map[string]string{"Generic": "Unreadable cache directory."},
+	AuthRequired:  false,
+}
@@ -0,0 +1,227 @@
+// cmd/call/call.go
+package call
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/TheDucker1/mangaupdatescli/internal/openapi"
+	"github.com/TheDucker1/mangaupdatescli/internal/utils"
+	"github.com/TheDucker1/mangaupdatescli/mangaupdates"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+)
+
+// methods are the HTTP methods that start a 'call METHOD /path'.
+var methods = []string{"GET", "POST", "PUT", "PATCH", "DELETE"}
+
+// paramList is a flag.Value for --param name=value; repeating the flag appends.
+type paramList [][2]string
+
+func (l *paramList) String() string {
+	parts := make([]string, len(*l))
+	for i, p := range *l {
+		parts[i] = p[0] + "=" + p[1]
+	}
+	return strings.Join(parts, " ")
+}
+
+func (l *paramList) Set(s string) error {
+	name, value, ok := strings.Cut(s, "=")
+	if !ok || name == "" {
+		return fmt.Errorf("%q is not name=value", s)
+	}
+	*l = append(*l, [2]string{name, value})
+	return nil
+}
+
+// PrintCallHelp prints help for the 'call' subprogram
+func PrintCallHelp(jsonFormat bool) {
+	if jsonFormat {
+		utils.PrintJSONHelp(callHelpContent)
+	} else {
+		utils.PrintFormattedHelp(callHelpContent)
+	}
+}
+
+// HandleCommand sends the request that target and args describe. target is
+// an operation ID, or an HTTP method with the path as the first of args.
+func HandleCommand(ctx context.Context, target string, args []string) {
+	method := strings.ToUpper(target)
+	isRaw := slices.Contains(methods, method)
+	rawPath := ""
+	if isRaw && len(args) > 0 && strings.HasPrefix(args[0], "/") {
+		rawPath, args = args[0], args[1:]
+	}
+
+	fs := flag.NewFlagSet("call", flag.ContinueOnError)
+	var params paramList
+	fs.Var(&params, "param", "A path or query parameter as name=value; may be repeated.")
+	body := fs.String("body", "", "Request body as a JSON or YAML document, @file to read it from a file, or - for stdin.")
+
+	isJsonHelp, isTextHelp, remainingArgs := utils.CheckHelpFlags(args)
+	if err := fs.Parse(remainingArgs); err != nil {
+		utils.PrintErrorAndExit("Failed to parse flags for 'call'", err)
+	}
+	if isJsonHelp {
+		utils.PrintJSONHelp(callHelpContent)
+		return
+	}
+	if isTextHelp {
+		utils.PrintFormattedHelp(callHelpContent)
+		return
+	}
+	if fs.NArg() > 0 {
+		utils.PrintErrorAndExit(fmt.Sprintf("Unexpected argument %q for 'call'", fs.Arg(0)), nil)
+	}
+	if isRaw && rawPath == "" {
+		fmt.Fprintf(os.Stderr, "Error: a path starting with / must follow %s.\n", method)
+		utils.PrintFormattedHelp(callHelpContent)
+		os.Exit(1)
+	}
+
+	req := mangaupdates.Request{PathParams: make(map[string]string), Query: make(map[string]string)}
+	spec, specErr := openapi.Load()
+	var op openapi.Operation
+	var pathValues map[string]string
+	known := false
+	if isRaw {
+		path, rawQuery, _ := strings.Cut(rawPath, "?")
+		query, err := url.ParseQuery(rawQuery)
+		if err != nil {
+			utils.PrintErrorAndExit(fmt.Sprintf("Invalid query in %q", rawPath), err)
+		}
+		// Parameters in the path's query come first, so --param overrides them.
+		var fromPath paramList
+		for name, values := range query {
+			fromPath = append(fromPath, [2]string{name, values[len(values)-1]})
+		}
+		params = append(fromPath, params...)
+		req.Method, req.Path = method, path
+		if specErr == nil {
+			op, pathValues, known = spec.Match(method, path)
+		}
+		switch {
+		case specErr != nil:
+			fmt.Fprintf(os.Stderr, "Warning: %v; parameters are not checked.\n", specErr)
+		case !known:
+			fmt.Fprintf(os.Stderr, "Warning: %s %s is not in the OpenAPI spec; parameters are not checked.\n", method, path)
+		}
+	} else {
+		if specErr != nil {
+			utils.PrintErrorAndExit("Failed to load the OpenAPI spec", specErr)
+		}
+		op, known = spec.Operation(target)
+		if !known {
+			utils.PrintErrorAndExit(fmt.Sprintf("Unknown operation %q (see 'mangaupdatescli spec ops', or give METHOD /path)", target), nil)
+		}
+		req.Method, req.Path = op.Method, op.Path
+	}
+
+	name := req.Method + " " + req.Path
+	if known {
+		name = op.ID
+		checkParams(op, params, pathValues, isRaw)
+		req.Auth = op.Auth
+	}
+	for _, p := range params {
+		if known && paramIn(op, p[0]) == "path" {
+			req.PathParams[p[0]] = p[1]
+		} else {
+			req.Query[p[0]] = p[1]
+		}
+	}
+
+	switch {
+	case *body != "":
+		if known && len(op.BodyContent) == 0 {
+			utils.PrintErrorAndExit(fmt.Sprintf("%s takes no request body", name), nil)
+		}
+		if known && !slices.Contains(op.BodyContent, "application/json") {
+			utils.PrintErrorAndExit(fmt.Sprintf("%s takes a %s request body; only JSON can be sent", name, strings.Join(op.BodyContent, " or ")), nil)
+		}
+		data, err := utils.ReadBody(*body)
+		if err != nil {
+			utils.PrintErrorAndExit("Invalid value for --body", err)
+		}
+		req.Body = data
+	case known && op.BodyRequired:
+		fmt.Fprintf(os.Stderr, "Error: --body is required for %s.\n", name)
+		utils.PrintFormattedHelp(callHelpContent)
+		os.Exit(1)
+	}
+
+	respBody, err := mangaupdates.Default().DoRaw(ctx, req)
+	if err != nil {
+		utils.PrintErrorAndExit("API request failed for "+req.Path, err)
+	}
+	defer respBody.Close()
+
+	if err := utils.PrintJSONStream(respBody); err != nil {
+		utils.PrintErrorAndExit("API request failed for "+req.Path, err)
+	}
+}
+
+// paramIn returns where op takes the parameter called name, or "".
+func paramIn(op openapi.Operation, name string) string {
+	for _, p := range op.Params {
+		if p.Name == name {
+			return p.In
+		}
+	}
+	return ""
+}
+
+// checkParams exits with an error unless params and the path parameters
+// in pathValues are valid for op. With a raw path, path parameters cannot
+// be given with --param.
+func checkParams(op openapi.Operation, params paramList, pathValues map[string]string, isRaw bool) {
+	given := make(map[string]bool)
+	for _, p := range params {
+		given[p[0]] = true
+	}
+	var valid []string
+	for _, p := range op.Params {
+		if p.In == "query" || (p.In == "path" && !isRaw) {
+			valid = append(valid, p.Name)
+		}
+	}
+	for _, p := range params {
+		if !slices.Contains(valid, p[0]) {
+			if paramIn(op, p[0]) == "path" {
+				utils.PrintErrorAndExit(fmt.Sprintf("Parameter %q of %s is part of the path", p[0], op.ID), nil)
+			}
+			utils.PrintErrorAndExit(fmt.Sprintf("Unknown parameter %q for %s (valid: %s)", p[0], op.ID, strings.Join(valid, ", ")), nil)
+		}
+	}
+
+	for _, p := range op.Params {
+		check := utils.OperationParam{Name: p.Name, In: p.In, Type: p.Type, Enum: p.Enum}
+		if p.In == "path" && isRaw {
+			v, err := url.PathUnescape(pathValues[p.Name])
+			if err == nil {
+				err = check.Check(v)
+			}
+			if err != nil {
+				utils.PrintErrorAndExit(fmt.Sprintf("Invalid path parameter %s", p.Name), err)
+			}
+			continue
+		}
+		if !given[p.Name] {
+			if p.Required && slices.Contains(valid, p.Name) {
+				utils.PrintErrorAndExit(fmt.Sprintf("--param %s=<value> is required for %s", p.Name, op.ID), nil)
+			}
+			continue
+		}
+		for _, q := range params {
+			if q[0] != p.Name {
+				continue
+			}
+			if err := check.Check(q[1]); err != nil {
+				utils.PrintErrorAndExit(fmt.Sprintf("Invalid value for parameter %s", p.Name), err)
+			}
+		}
+	}
+}
@@ -0,0 +1,17 @@
+// cmd/call/call_help.go
+package call
+
+import "github.com/TheDucker1/mangaupdatescli/internal/utils"
+
+var callHelpContent = utils.HelpContent{
+	Usage:       "mangaupdatescli call <operationId | METHOD /path> [--param <name=value> ...] [--body <JSON | YAML | @file | ->]",
+	Description: "Send any API request: an operation of the embedded OpenAPI spec by its ID, or a method and path. Parameters are checked against the spec; a path the spec does not know is sent unchecked.",
+	Arguments: []utils.ArgHelp{
+		{Name: "param", Type: "name=value", Description: "A path or query parameter of the operation; may be repeated. With METHOD /path, path parameters are part of the path and --param gives query parameters."},
+		{Name: "body", Type: "JSON|YAML", Description: "Request body as a JSON or YAML document, @file to read it from a file, or - for stdin."},
+	},
+	InputJSON:     "The request body of the operation, if any (see 'mangaupdatescli spec show <operationId>')",
+	OutputJSON:    "The API response",
+	ErrorExamples: map[string]string{"Generic": "Unknown operation, unknown or invalid parameter, or any API error."},
+	AuthRequired:  false,
+}
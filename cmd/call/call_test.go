@@ -0,0 +1,127 @@
+package call
+
+import (
+	"context"
+	"github.com/TheDucker1/mangaupdatescli/internal/testutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// capture returns what fn writes to os.Stdout and os.Stderr.
+func capture(t *testing.T, fn func()) (stdout, stderr string) {
+	t.Helper()
+	dir := t.TempDir()
+	outFile, err := os.Create(filepath.Join(dir, "stdout"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	errFile, err := os.Create(filepath.Join(dir, "stderr"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	savedOut, savedErr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = outFile, errFile
+	fn()
+	os.Stdout, os.Stderr = savedOut, savedErr
+	outFile.Close()
+	errFile.Close()
+	out, _ := os.ReadFile(outFile.Name())
+	errOut, _ := os.ReadFile(errFile.Name())
+	return string(out), string(errOut)
+}
+
+func TestHandleCommand(t *testing.T) {
+	sent := testutil.UseServer(t)
+	tests := []struct {
+		name     string
+		target   string
+		args     []string
+		want     testutil.Request
+		wantWarn string // "" means no warning
+	}{
+		{
+			name:   "operation ID",
+			target: "retrieveSeries",
+			args:   []string{"--param", "id=42", "--param", "unrenderedFields=true"},
+			want:   testutil.Request{Method: "GET", Path: "/series/42", Query: "unrenderedFields=true"},
+		},
+		{
+			name:   "method and path",
+			target: "get",
+			args:   []string{"/series/42"},
+			want:   testutil.Request{Method: "GET", Path: "/series/42"},
+		},
+		{
+			name:   "--param overrides the query of the path",
+			target: "GET",
+			args:   []string{"/series/42?unrenderedFields=false", "--param", "unrenderedFields=true"},
+			want:   testutil.Request{Method: "GET", Path: "/series/42", Query: "unrenderedFields=true"},
+		},
+		{
+			name:   "literal path wins over a parameter",
+			target: "POST",
+			args:   []string{"/series/search", "--body", "search: berserk"},
+			want:   testutil.Request{Method: "POST", Path: "/series/search", Body: `{"search":"berserk"}`},
+		},
+		{
+			name:   "operation ID with a JSON body",
+			target: "searchSeriesPost",
+			args:   []string{"--body", `{"search":"berserk"}`},
+			want:   testutil.Request{Method: "POST", Path: "/series/search", Body: `{"search":"berserk"}`},
+		},
+		{
+			name:     "path not in the spec",
+			target:   "DELETE",
+			args:     []string{"/nowhere/1?x=y", "--param", "z=1"},
+			want:     testutil.Request{Method: "DELETE", Path: "/nowhere/1", Query: "x=y&z=1"},
+			wantWarn: "Warning: DELETE /nowhere/1 is not in the OpenAPI spec; parameters are not checked.",
+		},
+	}
+	for _, tt := range tests {
+		*sent = nil
+		stdout, stderr := capture(t, func() {
+			HandleCommand(context.Background(), tt.target, tt.args)
+		})
+		tt.want.Authorization = "Bearer token"
+		if len(*sent) != 1 || (*sent)[0] != tt.want {
+			t.Errorf("%s: sent %+v; want %+v", tt.name, *sent, tt.want)
+		}
+		if stdout != "{\n  \"ok\": true\n}\n" {
+			t.Errorf("%s: printed %q", tt.name, stdout)
+		}
+		if strings.TrimSpace(stderr) != tt.wantWarn {
+			t.Errorf("%s: stderr = %q; want %q", tt.name, stderr, tt.wantWarn)
+		}
+	}
+}
+
+func TestHandleCommandErrors(t *testing.T) {
+	if args, ok := testutil.ExitingArgs(); ok {
+		HandleCommand(context.Background(), args[0], args[1:])
+		return
+	}
+	tests := []struct {
+		name    string
+		args    []string // target first
+		wantErr string
+	}{
+		{"unknown operation", []string{"nope"}, `Unknown operation "nope"`},
+		{"method without a path", []string{"GET", "--param", "id=1"}, "a path starting with / must follow GET"},
+		{"unknown parameter", []string{"retrieveSeries", "--param", "id=1", "--param", "x=1"}, `Unknown parameter "x" for retrieveSeries (valid: id, unrenderedFields)`},
+		{"missing path parameter", []string{"retrieveSeries"}, "--param id=<value> is required for retrieveSeries"},
+		{"invalid parameter", []string{"retrieveSeries", "--param", "id=abc"}, "Invalid value for parameter id"},
+		{"path parameter of a raw path", []string{"GET", "/series/1", "--param", "id=2"}, `Parameter "id" of retrieveSeries is part of the path`},
+		{"invalid value in a raw path", []string{"GET", "/series/abc"}, "Invalid path parameter id"},
+		{"malformed --param", []string{"retrieveSeries", "--param", "id"}, `"id" is not name=value`},
+		{"body for an operation without one", []string{"retrieveSeries", "--param", "id=1", "--body", "{}"}, "retrieveSeries takes no request body"},
+		{"extra argument", []string{"retrieveSeries", "--param", "id=1", "more"}, `Unexpected argument "more" for 'call'`},
+	}
+	for _, tt := range tests {
+		stderr, code := testutil.RunExiting(t, "TestHandleCommandErrors", tt.args)
+		if code != 1 || !strings.Contains(stderr, tt.wantErr) {
+			t.Errorf("%s: exit code %d, stderr:\n%s\nwant 1 and %q", tt.name, code, stderr, tt.wantErr)
+		}
+	}
+}
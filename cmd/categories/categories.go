@@ -2,19 +2,19 @@
 package categories
 
 import (
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
-	"mangaupdatescli/internal/apiclient"
-	"mangaupdatescli/internal/utils"
-	"net/http"
+	"github.com/TheDucker1/mangaupdatescli/internal/utils"
+	"github.com/TheDucker1/mangaupdatescli/mangaupdates"
 	"os"
 	"sort"
 	// "strings" // Not immediately needed, but common
 )
 
 // CommandHandler defines the function signature for command handlers
-type CommandHandler func(args []string)
+type CommandHandler func(ctx context.Context, args []string)
 
 // CommandInfo stores the handler and its associated (generated) help content
 type CommandInfo struct {
@@ -53,7 +53,7 @@ func init() {
 }
 
 // HandleCommand dispatches to the correct categories command handler
-func HandleCommand(command string, args []string) {
+func HandleCommand(ctx context.Context, command string, args []string) {
 	cmdInfo, ok := categoriesCommands[command]
 	if !ok {
 		isJsonHelp, _, _ := utils.CheckHelpFlags(args)
@@ -61,7 +61,7 @@ func HandleCommand(command string, args []string) {
 		PrintCategoriesSubprogramHelp(isJsonHelp)
 		os.Exit(1)
 	}
-	cmdInfo.Handler(args)
+	cmdInfo.Handler(ctx, args)
 }
 
 // PrintCategoriesSubprogramHelp prints help for the entire 'categories' subprogram
@@ -113,16 +113,10 @@ func PrintCategoriesSubprogramHelp(jsonFormat bool) {
 
 // --- Handler Functions ---
 
-// Define CategoriesModelUpdateV1 for request bodies (from OpenAPI spec)
-// This schema is used by findCategoryByPrefix and findCategoryByExact
-type CategoriesModelUpdateV1 struct {
-	Category string `json:"category"` // Required
-}
-
 // handleFindCategoryByPrefix (POST /categories/findByPrefix)
-func handleFindCategoryByPrefix(args []string) {
+func handleFindCategoryByPrefix(ctx context.Context, args []string) {
 	fs := flag.NewFlagSet("findCategoryByPrefix", flag.ContinueOnError)
-	var reqBody CategoriesModelUpdateV1
+	var reqBody mangaupdates.CategoriesModelUpdateV1
 	fs.StringVar(&reqBody.Category, "category", "", "The category prefix to search for (required).")
 
 	isJsonHelp, isTextHelp, remainingArgs := utils.CheckHelpFlags(args)
@@ -145,29 +139,21 @@ func handleFindCategoryByPrefix(args []string) {
 		os.Exit(1)
 	}
 
-	fullURL, err := apiclient.BuildURL("/categories/findByPrefix", nil)
-	if err != nil {
-		utils.PrintErrorAndExit("Failed to build URL for /categories/findByPrefix", err)
-	}
-
-	respBody, statusCode, err := apiclient.DoRequest("POST", fullURL, reqBody)
+	respBody, err := mangaupdates.Default().FindCategoryByPrefixRaw(ctx, reqBody.Category)
 	if err != nil {
 		utils.PrintErrorAndExit("API request failed for /categories/findByPrefix", err)
 	}
+	defer respBody.Close()
 
-	if statusCode == http.StatusOK { // Spec does not list 404 for this, assumes empty array if no match
-		utils.PrintJSON(respBody)
-	} else {
-		fmt.Fprintf(os.Stderr, "API request for /categories/findByPrefix failed with status %d:\n", statusCode)
-		utils.PrintJSON(respBody) // Print error response from API (e.g., 400)
-		os.Exit(1)
+	if err := utils.PrintJSONStream(respBody); err != nil {
+		utils.PrintErrorAndExit("API request failed for /categories/findByPrefix", err)
 	}
 }
 
 // handleFindCategoryByExact (POST /categories/findByExact)
-func handleFindCategoryByExact(args []string) {
+func handleFindCategoryByExact(ctx context.Context, args []string) {
 	fs := flag.NewFlagSet("findCategoryByExact", flag.ContinueOnError)
-	var reqBody CategoriesModelUpdateV1
+	var reqBody mangaupdates.CategoriesModelUpdateV1
 	fs.StringVar(&reqBody.Category, "category", "", "The exact category name to search for (required).")
 
 	isJsonHelp, isTextHelp, remainingArgs := utils.CheckHelpFlags(args)
@@ -190,44 +176,22 @@ func handleFindCategoryByExact(args []string) {
 		os.Exit(1)
 	}
 
-	fullURL, err := apiclient.BuildURL("/categories/findByExact", nil)
-	if err != nil {
-		utils.PrintErrorAndExit("Failed to build URL for /categories/findByExact", err)
-	}
-
-	respBody, statusCode, err := apiclient.DoRequest("POST", fullURL, reqBody)
+	respBody, err := mangaupdates.Default().FindCategoryByExactRaw(ctx, reqBody.Category)
 	if err != nil {
 		utils.PrintErrorAndExit("API request failed for /categories/findByExact", err)
 	}
+	defer respBody.Close()
 
-	if statusCode == http.StatusOK || statusCode == http.StatusNotFound { // 404 is an expected response
-		utils.PrintJSON(respBody)
-	} else {
-		fmt.Fprintf(os.Stderr, "API request for /categories/findByExact failed with status %d:\n", statusCode)
-		utils.PrintJSON(respBody)
-		os.Exit(1)
+	if err := utils.PrintJSONStream(respBody); err != nil {
+		utils.PrintErrorAndExit("API request failed for /categories/findByExact", err)
 	}
 }
 
-// Define CategoriesSearchRequestV1 for the request body (from OpenAPI spec, simplified)
-type CategoriesSearchRequestV1 struct {
-	Search  string `json:"search,omitempty"`
-	Page    int    `json:"page,omitempty"`
-	Perpage int    `json:"perpage,omitempty"`
-	Letter  string `json:"letter,omitempty"`
-	Orderby string `json:"orderby,omitempty"` // enum: category, agree, disagree, usage
-}
-
 // handleSearchCategoriesPost (POST /categories/search)
-func handleSearchCategoriesPost(args []string) {
+func handleSearchCategoriesPost(ctx context.Context, args []string) {
 	fs := flag.NewFlagSet("searchCategoriesPost", flag.ContinueOnError)
-	var reqBody CategoriesSearchRequestV1
-
-	fs.StringVar(&reqBody.Search, "search", "", "Search term for categories.")
-	fs.IntVar(&reqBody.Page, "page", 0, "Page number.")
-	fs.IntVar(&reqBody.Perpage, "perpage", 0, "Results per page.")
-	fs.StringVar(&reqBody.Letter, "letter", "", "Filter by starting letter.")
-	fs.StringVar(&reqBody.Orderby, "orderby", "", "Order by (category, agree, disagree, usage).")
+	var reqBody mangaupdates.CategoriesSearchRequestV1
+	bodyFlags := utils.AddBodyFlags(fs, helpSearchCategoriesPostContent) // --search, --orderby, ... from the request schema
 
 	isJsonHelp, isTextHelp, remainingArgs := utils.CheckHelpFlags(args)
 	if err := fs.Parse(remainingArgs); err != nil {
@@ -245,22 +209,17 @@ func handleSearchCategoriesPost(args []string) {
 
 	// No specific required fields for the search request body itself,
 	// an empty body is valid for a broad search.
-
-	fullURL, err := apiclient.BuildURL("/categories/search", nil)
-	if err != nil {
-		utils.PrintErrorAndExit("Failed to build URL for /categories/search", err)
+	if err := bodyFlags.Decode(&reqBody); err != nil {
+		utils.PrintErrorAndExit("Invalid flags for 'searchCategoriesPost'", err)
 	}
 
-	respBody, statusCode, err := apiclient.DoRequest("POST", fullURL, reqBody)
+	respBody, err := mangaupdates.Default().SearchCategoriesRaw(ctx, &reqBody)
 	if err != nil {
 		utils.PrintErrorAndExit("API request failed for /categories/search", err)
 	}
+	defer respBody.Close()
 
-	if statusCode == http.StatusOK { // Expect 200 for success
-		utils.PrintJSON(respBody)
-	} else {
-		fmt.Fprintf(os.Stderr, "API request for /categories/search failed with status %d:\n", statusCode)
-		utils.PrintJSON(respBody)
-		os.Exit(1)
+	if err := utils.PrintJSONStream(respBody); err != nil {
+		utils.PrintErrorAndExit("API request failed for /categories/search", err)
 	}
 }
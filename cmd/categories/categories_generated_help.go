@@ -0,0 +1,105 @@
+// Code generated by tools/helpcodegen/main.go; DO NOT EDIT.
+package categories
+
+import "github.com/TheDucker1/mangaupdatescli/internal/utils"
+
+var helpFindCategoryByExactContent = utils.HelpContent{
+	Usage:       "mangaupdatescli categories findCategoryByExact --category <string> [--body <JSON|YAML|@file|->]",
+	Description: "Find a category by its exact name",
+	Arguments: []utils.ArgHelp{
+		{
+			Name:        "category",
+			Type:        "string",
+			Required:    true,
+			Description: "Category name.",
+			In:          "body",
+		},
+		{
+			Name:        "body",
+			Type:        "JSON|YAML",
+			Required:    false,
+			Description: "Request body as a JSON or YAML document, @file to read it from a file, or - for stdin. The flags of its fields override it.",
+		},
+	},
+	InputJSON:     utils.SchemaHelp{Label: "Request Body Schema (required)", Schema: &utils.Schema{Ref: "CategoriesModelUpdateV1"}},
+	OutputJSON:    utils.SchemaHelp{Label: "Schema (on 200)", Schema: &utils.Schema{Ref: "CategoriesModelV1"}},
+	ErrorExamples: map[string]string{"404": "Category not found"},
+	AuthRequired:  false,
+}
+
+var helpFindCategoryByPrefixContent = utils.HelpContent{
+	Usage:       "mangaupdatescli categories findCategoryByPrefix --category <string> [--body <JSON|YAML|@file|->]",
+	Description: "Find the categories starting with a prefix",
+	Arguments: []utils.ArgHelp{
+		{
+			Name:        "category",
+			Type:        "string",
+			Required:    true,
+			Description: "Start of the category names.",
+			In:          "body",
+		},
+		{
+			Name:        "body",
+			Type:        "JSON|YAML",
+			Required:    false,
+			Description: "Request body as a JSON or YAML document, @file to read it from a file, or - for stdin. The flags of its fields override it.",
+		},
+	},
+	InputJSON:     utils.SchemaHelp{Label: "Request Body Schema (required)", Schema: &utils.Schema{Ref: "CategoriesModelUpdateV1"}},
+	OutputJSON:    utils.SchemaHelp{Label: "Schema (on 200)", Schema: &utils.Schema{Type: "array", Items: &utils.Schema{Ref: "CategoriesModelV1"}}},
+	ErrorExamples: map[string]string{"400": "Validation or Service Error"},
+	AuthRequired:  false,
+}
+
+var helpSearchCategoriesPostContent = utils.HelpContent{
+	Usage:       "mangaupdatescli categories searchCategoriesPost [--search <string>] [--page <integer>] [--perpage <integer>] [--letter <string>] [--orderby <string>] [--body <JSON|YAML|@file|->]",
+	Description: "Search categories",
+	Arguments: []utils.ArgHelp{
+		{
+			Name:        "search",
+			Type:        "string",
+			Required:    false,
+			Description: "Search term for categories.",
+			In:          "body",
+		},
+		{
+			Name:        "page",
+			Type:        "integer",
+			Required:    false,
+			Description: "Page number.",
+			In:          "body",
+		},
+		{
+			Name:        "perpage",
+			Type:        "integer",
+			Required:    false,
+			Description: "Results per page.",
+			In:          "body",
+		},
+		{
+			Name:        "letter",
+			Type:        "string",
+			Required:    false,
+			Description: "Filter by starting letter.",
+			In:          "body",
+		},
+		{
+			Name:        "orderby",
+			Type:        "string",
+			Required:    false,
+			Description: "Order by (category, agree, disagree, usage).",
+			In:          "body",
+			Enum:        []string{"category", "agree", "disagree", "usage"},
+		},
+		{
+			Name:        "body",
+			Type:        "JSON|YAML",
+			Required:    false,
+			Description: "Request body as a JSON or YAML document, @file to read it from a file, or - for stdin. The flags of its fields override it.",
+		},
+	},
+	InputJSON:     utils.SchemaHelp{Label: "Request Body Schema", Schema: &utils.Schema{Ref: "CategoriesSearchRequestV1"}},
+	OutputJSON:    utils.SchemaHelp{Label: "Schema (on 200)", Schema: &utils.Schema{Ref: "CategoriesSearchResponseV1"}},
+	ErrorExamples: map[string]string{"400": "Validation or Service Error"},
+	AuthRequired:  false,
+}
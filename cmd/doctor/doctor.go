@@ -0,0 +1,272 @@
+// cmd/doctor/doctor.go
+package doctor
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/TheDucker1/mangaupdatescli/internal/apiclient"
+	"github.com/TheDucker1/mangaupdatescli/internal/config"
+	"github.com/TheDucker1/mangaupdatescli/internal/utils"
+	"net/http"
+	"os"
+	"sort"
+	"time"
+)
+
+// CommandHandler defines the function signature for command handlers
+type CommandHandler func(ctx context.Context, args []string)
+
+// CommandInfo stores the handler and its associated help content
+type CommandInfo struct {
+	Handler CommandHandler
+	Help    utils.HelpContent
+}
+
+// doctorCommands maps the CLI command name to its handler and help
+var doctorCommands = make(map[string]CommandInfo)
+
+// init populates doctorCommands. The help variables live in doctor_help.go.
+func init() {
+	doctorCommands["check"] = CommandInfo{Handler: handleCheck, Help: checkHelpContent}
+}
+
+// Settings is the effective configuration resolved by main from the global
+// flags, the environment and the active profile, with where each value came
+// from, so doctor can explain it.
+type Settings struct {
+	Profile       string
+	APIURLSource  string
+	Transport     apiclient.TransportConfig
+	ProxySource   string
+	CAFileSource  string
+	Timeout       time.Duration
+	TimeoutSource string
+}
+
+var settings Settings
+
+// SetSettings records the effective configuration for the checks.
+func SetSettings(s Settings) {
+	settings = s
+}
+
+// HandleCommand dispatches to the correct doctor command handler
+func HandleCommand(ctx context.Context, command string, args []string) {
+	cmdInfo, ok := doctorCommands[command]
+	if !ok {
+		isJsonHelp, _, _ := utils.CheckHelpFlags(args)
+		fmt.Fprintf(os.Stderr, "Error: Unknown doctor command: %s\n\n", command)
+		PrintDoctorSubprogramHelp(isJsonHelp)
+		os.Exit(1)
+	}
+	cmdInfo.Handler(ctx, args)
+}
+
+// PrintDoctorSubprogramHelp prints help for the entire 'doctor' subprogram
+func PrintDoctorSubprogramHelp(jsonFormat bool) {
+	if jsonFormat {
+		type CommandHelpSummary struct {
+			Command     string `json:"command"`
+			Usage       string `json:"usage"`
+			Description string `json:"description"`
+		}
+		var summaries []CommandHelpSummary
+		var commandNames []string
+		for name := range doctorCommands {
+			commandNames = append(commandNames, name)
+		}
+		sort.Strings(commandNames)
+
+		for _, name := range commandNames {
+			cmdInfo := doctorCommands[name]
+			summaries = append(summaries, CommandHelpSummary{
+				Command:     name,
+				Usage:       cmdInfo.Help.Usage,
+				Description: cmdInfo.Help.Description,
+			})
+		}
+		outputData := map[string]interface{}{
+			"subprogram":  "doctor",
+			"description": "Diagnose configuration and connectivity problems.",
+			"commands":    summaries,
+		}
+		jsonData, _ := json.MarshalIndent(outputData, "", "  ")
+		fmt.Println(string(jsonData))
+	} else {
+		fmt.Println("`doctor` subprogram: Diagnose configuration and connectivity problems.")
+		fmt.Println("Available commands:")
+		var commandNames []string
+		for name := range doctorCommands {
+			commandNames = append(commandNames, name)
+		}
+		sort.Strings(commandNames)
+
+		for _, name := range commandNames {
+			cmdInfo := doctorCommands[name]
+			fmt.Printf("  %-30s %s\n", name, cmdInfo.Help.Description)
+		}
+		fmt.Println("\nUse 'mangaupdatescli doctor <command> -hh' for more detailed help on a specific command.")
+	}
+}
+
+// --- Handler Functions ---
+
+// checkResult is one line of the doctor report.
+type checkResult struct {
+	Check  string `json:"check"`
+	Status string `json:"status"` // ok, warn or fail
+	Detail string `json:"detail"`
+}
+
+// handleCheck runs every check and prints the report as JSON
+func handleCheck(ctx context.Context, args []string) {
+	fs := flag.NewFlagSet("check", flag.ContinueOnError)
+
+	isJsonHelp, isTextHelp, remainingArgs := utils.CheckHelpFlags(args)
+	if err := fs.Parse(remainingArgs); err != nil {
+		utils.PrintErrorAndExit("Failed to parse flags for 'check'", err)
+	}
+
+	if isJsonHelp {
+		utils.PrintJSONHelp(checkHelpContent)
+		return
+	}
+	if isTextHelp {
+		utils.PrintFormattedHelp(checkHelpContent)
+		return
+	}
+
+	results := []checkResult{
+		checkConfig(),
+		checkCacheDir(),
+		checkAPIURL(),
+		checkProxy(),
+		checkTLS(),
+		checkTimeout(),
+		checkConnectivity(ctx),
+	}
+	jsonData, _ := json.Marshal(results)
+	utils.PrintJSON(jsonData)
+	for _, r := range results {
+		if r.Status == "fail" {
+			os.Exit(utils.ExitError)
+		}
+	}
+}
+
+func checkConfig() checkResult {
+	r := checkResult{Check: "config", Status: "ok"}
+	dir, err := config.Dir()
+	if err != nil {
+		return checkResult{r.Check, "fail", err.Error()}
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return checkResult{r.Check, "fail", err.Error()}
+	}
+	r.Detail = fmt.Sprintf("%s, profile %q", dir, settings.Profile)
+	if p := cfg.Profiles[settings.Profile]; p != nil && p.SessionToken != "" {
+		r.Detail += fmt.Sprintf(" logged in as %s", p.Username)
+	} else {
+		r.Detail += " not logged in"
+	}
+	return r
+}
+
+func checkCacheDir() checkResult {
+	r := checkResult{Check: "cache_dir", Status: "ok"}
+	dir, err := config.CacheDir()
+	if err == nil {
+		err = os.MkdirAll(dir, 0700)
+	}
+	if err == nil {
+		var f *os.File
+		if f, err = os.CreateTemp(dir, "doctor-*"); err == nil {
+			f.Close()
+			os.Remove(f.Name())
+		}
+	}
+	if err != nil {
+		// Caching and rate limiting degrade gracefully without it.
+		return checkResult{r.Check, "warn", fmt.Sprintf("not writable: %v", err)}
+	}
+	r.Detail = dir
+	return r
+}
+
+func checkAPIURL() checkResult {
+	return checkResult{"api_url", "ok", fmt.Sprintf("%s (from %s)", apiclient.Default().BaseURL(), settings.APIURLSource)}
+}
+
+func checkProxy() checkResult {
+	r := checkResult{Check: "proxy", Status: "ok"}
+	if settings.Transport.Proxy != "" {
+		proxyURL, err := apiclient.ValidateProxyURL(settings.Transport.Proxy)
+		if err != nil {
+			return checkResult{r.Check, "fail", err.Error()}
+		}
+		r.Detail = fmt.Sprintf("%s (from %s)", proxyURL.Redacted(), settings.ProxySource)
+		return r
+	}
+	req, _ := http.NewRequest("GET", apiclient.Default().BaseURL(), nil)
+	proxyURL, err := http.ProxyFromEnvironment(req)
+	switch {
+	case err != nil:
+		return checkResult{r.Check, "fail", fmt.Sprintf("invalid proxy environment variable: %v", err)}
+	case proxyURL != nil:
+		r.Detail = fmt.Sprintf("%s (from HTTP(S)_PROXY environment)", proxyURL.Redacted())
+	default:
+		r.Detail = "none, connecting directly"
+	}
+	return r
+}
+
+func checkTLS() checkResult {
+	r := checkResult{Check: "tls", Status: "ok", Detail: "system certificate roots"}
+	if settings.Transport.CAFile != "" {
+		_, n, err := apiclient.LoadCAFile(settings.Transport.CAFile)
+		if err != nil {
+			return checkResult{r.Check, "fail", err.Error()}
+		}
+		r.Detail = fmt.Sprintf("system roots plus %d certificate(s) from %s (from %s)", n, settings.Transport.CAFile, settings.CAFileSource)
+	}
+	if settings.Transport.InsecureSkipVerify {
+		r.Status = "warn"
+		r.Detail = "certificate verification DISABLED (insecure_skip_verify); connections can be intercepted"
+	}
+	return r
+}
+
+func checkTimeout() checkResult {
+	if settings.Timeout == 0 {
+		return checkResult{"timeout", "warn", fmt.Sprintf("none; requests may hang forever (from %s)", settings.TimeoutSource)}
+	}
+	return checkResult{"timeout", "ok", fmt.Sprintf("%s (from %s)", settings.Timeout, settings.TimeoutSource)}
+}
+
+// checkConnectivity makes one uncached request without retries, so the
+// result reflects the network as it is right now.
+func checkConnectivity(ctx context.Context) checkResult {
+	r := checkResult{Check: "connectivity", Status: "ok"}
+	client, err := apiclient.Default().With(
+		apiclient.WithCache(nil, apiclient.CacheBypass),
+		apiclient.WithRetryPolicy(apiclient.RetryPolicy{MaxRetries: 0, MaxWait: time.Second}),
+	)
+	if err != nil {
+		return checkResult{r.Check, "fail", err.Error()}
+	}
+	fullURL, err := client.BuildURL("/misc/time", nil)
+	if err != nil {
+		return checkResult{r.Check, "fail", err.Error()}
+	}
+	start := time.Now()
+	_, statusCode, err := client.Do(ctx, "GET", fullURL, nil)
+	elapsed := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		return checkResult{r.Check, "fail", fmt.Sprintf("GET %s: %v", fullURL, err)}
+	}
+	r.Detail = fmt.Sprintf("GET %s returned %d in %s", fullURL, statusCode, elapsed)
+	return r
+}
@@ -0,0 +1,16 @@
+// cmd/doctor/doctor_help.go
+package doctor
+
+import "github.com/TheDucker1/mangaupdatescli/internal/utils"
+
+var checkHelpContent = utils.HelpContent{
+	Usage:       "mangaupdatescli doctor [check]",
+	Description: "Check the configuration, network settings and API connectivity of the active profile.",
+	Arguments:   nil,
+	InputJSON:   "None",
+	OutputJSON:  "Array of {check, status (ok, warn or fail), detail}",
+	ErrorExamples: map[string]string{
+		"Generic": "Exits with status 1 if any check fails.",
+	},
+	AuthRequired: false,
+}
@@ -2,18 +2,18 @@
 package genre
 
 import (
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
-	"mangaupdatescli/internal/apiclient"
-	"mangaupdatescli/internal/utils"
-	"net/http"
+	"github.com/TheDucker1/mangaupdatescli/internal/utils"
+	"github.com/TheDucker1/mangaupdatescli/mangaupdates"
 	"os"
 	"sort"
 )
 
 // CommandHandler defines the function signature for command handlers
-type CommandHandler func(args []string)
+type CommandHandler func(ctx context.Context, args []string)
 
 // CommandInfo stores the handler and its associated (generated) help content
 type CommandInfo struct {
@@ -45,7 +45,7 @@ func init() {
 }
 
 // HandleCommand dispatches to the correct genre command handler
-func HandleCommand(command string, args []string) {
+func HandleCommand(ctx context.Context, command string, args []string) {
 	cmdInfo, ok := genreCommands[command]
 	if !ok {
 		isJsonHelp, _, _ := utils.CheckHelpFlags(args)
@@ -53,7 +53,7 @@ func HandleCommand(command string, args []string) {
 		PrintGenreSubprogramHelp(isJsonHelp)
 		os.Exit(1)
 	}
-	cmdInfo.Handler(args)
+	cmdInfo.Handler(ctx, args)
 }
 
 // PrintGenreSubprogramHelp prints help for the entire 'genre' subprogram
@@ -106,7 +106,7 @@ func PrintGenreSubprogramHelp(jsonFormat bool) {
 // --- Handler Functions ---
 
 // handleRetrieveGenres (GET /genres)
-func handleRetrieveGenres(args []string) {
+func handleRetrieveGenres(ctx context.Context, args []string) {
 	fs := flag.NewFlagSet("retrieveGenres", flag.ContinueOnError)
 	// No arguments/flags for this specific endpoint as per the spec for GET /genres
 
@@ -124,27 +124,19 @@ func handleRetrieveGenres(args []string) {
 		return
 	}
 
-	fullURL, err := apiclient.BuildURL("/genres", nil)
-	if err != nil {
-		utils.PrintErrorAndExit("Failed to build URL for /genres", err)
-	}
-
-	respBody, statusCode, err := apiclient.DoRequest("GET", fullURL, nil)
+	respBody, err := mangaupdates.Default().RetrieveGenresRaw(ctx)
 	if err != nil {
 		utils.PrintErrorAndExit("API request failed for /genres", err)
 	}
+	defer respBody.Close()
 
-	if statusCode == http.StatusOK {
-		utils.PrintJSON(respBody)
-	} else {
-		fmt.Fprintf(os.Stderr, "API request for /genres failed with status %d:\n", statusCode)
-		utils.PrintJSON(respBody) // Print error response from API
-		os.Exit(1)
+	if err := utils.PrintJSONStream(respBody); err != nil {
+		utils.PrintErrorAndExit("API request failed for /genres", err)
 	}
 }
 
 // handleRetrieveGenreById (GET /genres/{id})
-func handleRetrieveGenreById(args []string) {
+func handleRetrieveGenreById(ctx context.Context, args []string) {
 	fs := flag.NewFlagSet("retrieveGenreById", flag.ContinueOnError)
 	genreID := fs.Int64("id", 0, "Genre ID (required).")                                                    // Path parameter: id
 	unrenderedFields := fs.Bool("unrenderedFields", false, "Output fields in unrendered form for editing.") // Query parameter
@@ -169,30 +161,13 @@ func handleRetrieveGenreById(args []string) {
 		os.Exit(1)
 	}
 
-	path := fmt.Sprintf("/genres/%d", *genreID)
-	queryParams := make(map[string]string)
-	if *unrenderedFields {
-		queryParams["unrenderedFields"] = "true"
-	}
-
-	fullURL, err := apiclient.BuildURL(path, queryParams)
-	if err != nil {
-		utils.PrintErrorAndExit("Failed to build URL for /genres/{id}", err)
-	}
-
-	respBody, statusCode, err := apiclient.DoRequest("GET", fullURL, nil)
+	respBody, err := mangaupdates.Default().RetrieveGenreRaw(ctx, *genreID, &mangaupdates.RetrieveOptions{UnrenderedFields: *unrenderedFields})
 	if err != nil {
 		utils.PrintErrorAndExit("API request failed for /genres/{id}", err)
 	}
+	defer respBody.Close()
 
-	// Spec doesn't explicitly list 404 for GET /genres/{id}, but it's a common pattern.
-	// The schema `GenreModelStatsV1` is returned on 200.
-	// Assuming 404 is possible if ID doesn't exist.
-	if statusCode == http.StatusOK || statusCode == http.StatusNotFound {
-		utils.PrintJSON(respBody)
-	} else {
-		fmt.Fprintf(os.Stderr, "API request for /genres/{id} failed with status %d:\n", statusCode)
-		utils.PrintJSON(respBody)
-		os.Exit(1)
+	if err := utils.PrintJSONStream(respBody); err != nil {
+		utils.PrintErrorAndExit("API request failed for /genres/{id}", err)
 	}
 }
@@ -0,0 +1,40 @@
+// Code generated by tools/helpcodegen/main.go; DO NOT EDIT.
+package genre
+
+import "github.com/TheDucker1/mangaupdatescli/internal/utils"
+
+var helpRetrieveGenreByIdContent = utils.HelpContent{
+	Usage:       "mangaupdatescli genre retrieveGenreById --id <integer(int64)> [--unrenderedFields <boolean>]",
+	Description: "Retrieve a genre",
+	Arguments: []utils.ArgHelp{
+		{
+			Name:        "id",
+			Type:        "integer(int64)",
+			Required:    true,
+			Description: "",
+			In:          "path",
+		},
+		{
+			Name:        "unrenderedFields",
+			Type:        "boolean",
+			Required:    false,
+			Description: "Output fields in unrendered form for editing.",
+			Default:     "false",
+			In:          "query",
+		},
+	},
+	InputJSON:     "Path/Query Parameters",
+	OutputJSON:    utils.SchemaHelp{Label: "Schema (on 200)", Schema: &utils.Schema{Ref: "GenreModelV1"}},
+	ErrorExamples: map[string]string{"404": "Genre not found"},
+	AuthRequired:  false,
+}
+
+var helpRetrieveGenresContent = utils.HelpContent{
+	Usage:         "mangaupdatescli genre retrieveGenres",
+	Description:   "List all genres",
+	Arguments:     nil,
+	InputJSON:     "None",
+	OutputJSON:    utils.SchemaHelp{Label: "Schema (on 200)", Schema: &utils.Schema{Type: "array", Items: &utils.Schema{Ref: "GenreModelV1"}}},
+	ErrorExamples: map[string]string{"Generic": "Standard API errors."},
+	AuthRequired:  false,
+}
@@ -2,19 +2,19 @@
 package groups
 
 import (
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
-	"mangaupdatescli/internal/apiclient"
-	"mangaupdatescli/internal/utils"
-	"net/http"
+	"github.com/TheDucker1/mangaupdatescli/internal/utils"
+	"github.com/TheDucker1/mangaupdatescli/mangaupdates"
+	"io"
 	"os"
 	"sort"
-	"strings"
 )
 
 // CommandHandler defines the function signature for command handlers
-type CommandHandler func(args []string)
+type CommandHandler func(ctx context.Context, args []string)
 
 // CommandInfo stores the handler and its associated (generated) help content
 type CommandInfo struct {
@@ -36,7 +36,7 @@ func init() {
 	// Ensure helpRetrieveGroupContent is defined in groups_generated_help.go
 	groupsCommands["retrieveGroup"] = CommandInfo{
 		Handler: handleRetrieveGroup,
-		Help:    helpRetrieveGroupContent, // This var comes from the generated file
+		Help:    utils.BulkIDHelp(helpRetrieveGroupContent), // This var comes from the generated file
 	}
 
 	// Ensure helpSearchGroupsPostContent is defined in groups_generated_help.go
@@ -53,7 +53,7 @@ func init() {
 }
 
 // HandleCommand dispatches to the correct groups command handler
-func HandleCommand(command string, args []string) {
+func HandleCommand(ctx context.Context, command string, args []string) {
 	cmdInfo, ok := groupsCommands[command]
 	if !ok {
 		isJsonHelp, _, _ := utils.CheckHelpFlags(args)
@@ -61,7 +61,7 @@ func HandleCommand(command string, args []string) {
 		PrintGroupsSubprogramHelp(isJsonHelp)
 		os.Exit(1)
 	}
-	cmdInfo.Handler(args)
+	cmdInfo.Handler(ctx, args)
 }
 
 // PrintGroupsSubprogramHelp prints help for the entire 'groups' subprogram
@@ -114,9 +114,9 @@ func PrintGroupsSubprogramHelp(jsonFormat bool) {
 // --- Handler Functions ---
 
 // handleRetrieveGroup (GET /groups/{id})
-func handleRetrieveGroup(args []string) {
+func handleRetrieveGroup(ctx context.Context, args []string) {
 	fs := flag.NewFlagSet("retrieveGroup", flag.ContinueOnError)
-	groupID := fs.Int64("id", 0, "Group ID (required).")                                        // Path parameter: id
+	groupIDs := utils.AddBulkIDFlags(fs, "Group")
 	unrenderedFields := fs.Bool("unrenderedFields", false, "Output fields in unrendered form.") // Query parameter
 
 	isJsonHelp, isTextHelp, remainingArgs := utils.CheckHelpFlags(args)
@@ -125,69 +125,58 @@ func handleRetrieveGroup(args []string) {
 	}
 
 	if isJsonHelp {
-		utils.PrintJSONHelp(helpRetrieveGroupContent)
+		utils.PrintJSONHelp(utils.BulkIDHelp(helpRetrieveGroupContent))
 		return
 	}
 	if isTextHelp {
-		utils.PrintFormattedHelp(helpRetrieveGroupContent)
+		utils.PrintFormattedHelp(utils.BulkIDHelp(helpRetrieveGroupContent))
 		return
 	}
 
-	if *groupID == 0 {
+	ids, err := groupIDs.IDs(os.Stdin)
+	if err != nil {
+		utils.PrintErrorAndExit("Invalid arguments for 'retrieveGroup'", err)
+	}
+	if len(ids) == 0 {
 		fmt.Fprintln(os.Stderr, "Error: --id is required for retrieveGroup.")
-		utils.PrintFormattedHelp(helpRetrieveGroupContent)
+		utils.PrintFormattedHelp(utils.BulkIDHelp(helpRetrieveGroupContent))
 		os.Exit(1)
 	}
 
-	path := fmt.Sprintf("/groups/%d", *groupID)
-	queryParams := make(map[string]string)
-	if *unrenderedFields {
-		queryParams["unrenderedFields"] = "true"
-	}
+	opts := &mangaupdates.RetrieveOptions{UnrenderedFields: *unrenderedFields}
 
-	fullURL, err := apiclient.BuildURL(path, queryParams)
-	if err != nil {
-		utils.PrintErrorAndExit("Failed to build URL for /groups/{id}", err)
+	if groupIDs.IsBatch() {
+		err := utils.RunBulk(ctx, ids, groupIDs.Parallel, func(ctx context.Context, id int64) ([]byte, error) {
+			body, err := mangaupdates.Default().RetrieveGroupRaw(ctx, id, opts)
+			if err != nil {
+				return nil, err
+			}
+			defer body.Close()
+			return io.ReadAll(body)
+		})
+		if err != nil {
+			utils.PrintErrorAndExit("Bulk retrieval failed for /groups/{id}", err)
+		}
+		return
 	}
 
-	respBody, statusCode, err := apiclient.DoRequest("GET", fullURL, nil)
+	respBody, err := mangaupdates.Default().RetrieveGroupRaw(ctx, ids[0], opts)
 	if err != nil {
 		utils.PrintErrorAndExit("API request failed for /groups/{id}", err)
 	}
+	defer respBody.Close()
 
-	if statusCode == http.StatusOK || statusCode == http.StatusNotFound { // 404 is an expected response
-		utils.PrintJSON(respBody)
-	} else {
-		fmt.Fprintf(os.Stderr, "API request for /groups/{id} failed with status %d:\n", statusCode)
-		utils.PrintJSON(respBody)
-		os.Exit(1)
+	if err := utils.PrintJSONStream(respBody); err != nil {
+		utils.PrintErrorAndExit("API request failed for /groups/{id}", err)
 	}
 }
 
-// Define GroupsSearchRequestV1 for the request body (from OpenAPI spec, simplified)
-type GroupsSearchRequestV1 struct {
-	Search  string `json:"search,omitempty"`
-	AddedBy int64  `json:"added_by,omitempty"`
-	Page    int    `json:"page,omitempty"`
-	Perpage int    `json:"perpage,omitempty"`
-	Letter  string `json:"letter,omitempty"`
-	Active  *bool  `json:"active,omitempty"`  // Use pointer for optional boolean
-	Pending *bool  `json:"pending,omitempty"` // Use pointer for optional boolean
-}
-
 // handleSearchGroupsPost (POST /groups/search)
-func handleSearchGroupsPost(args []string) {
+func handleSearchGroupsPost(ctx context.Context, args []string) {
 	fs := flag.NewFlagSet("searchGroupsPost", flag.ContinueOnError)
-	var reqBody GroupsSearchRequestV1
-
-	fs.StringVar(&reqBody.Search, "search", "", "Search term for groups.")
-	addedBy := fs.Int64("added_by", 0, "Filter by user ID who added the group.")
-	fs.IntVar(&reqBody.Page, "page", 0, "Page number.")
-	fs.IntVar(&reqBody.Perpage, "perpage", 0, "Results per page.")
-	fs.StringVar(&reqBody.Letter, "letter", "", "Filter by starting letter.")
-	// For optional booleans, we need to check if the flag was set
-	activeStr := fs.String("active", "", "Filter by active status (true/false). Leave empty for no filter.")
-	pendingStr := fs.String("pending", "", "Filter by pending status (true/false). Leave empty for no filter.")
+	var reqBody mangaupdates.GroupsSearchRequestV1
+	// Optional booleans such as --active are only sent if the flag is given
+	bodyFlags := utils.AddBodyFlags(fs, helpSearchGroupsPostContent)
 
 	isJsonHelp, isTextHelp, remainingArgs := utils.CheckHelpFlags(args)
 	if err := fs.Parse(remainingArgs); err != nil {
@@ -204,39 +193,23 @@ func handleSearchGroupsPost(args []string) {
 	}
 
 	// Populate struct from flags
-	if *addedBy != 0 {
-		reqBody.AddedBy = *addedBy
-	}
-	if *activeStr != "" {
-		val := strings.ToLower(*activeStr) == "true"
-		reqBody.Active = &val
-	}
-	if *pendingStr != "" {
-		val := strings.ToLower(*pendingStr) == "true"
-		reqBody.Pending = &val
-	}
-
-	fullURL, err := apiclient.BuildURL("/groups/search", nil)
-	if err != nil {
-		utils.PrintErrorAndExit("Failed to build URL for /groups/search", err)
+	if err := bodyFlags.Decode(&reqBody); err != nil {
+		utils.PrintErrorAndExit("Invalid flags for 'searchGroupsPost'", err)
 	}
 
-	respBody, statusCode, err := apiclient.DoRequest("POST", fullURL, reqBody)
+	respBody, err := mangaupdates.Default().SearchGroupsRaw(ctx, &reqBody)
 	if err != nil {
 		utils.PrintErrorAndExit("API request failed for /groups/search", err)
 	}
+	defer respBody.Close()
 
-	if statusCode == http.StatusOK { // 400 for validation error
-		utils.PrintJSON(respBody)
-	} else {
-		fmt.Fprintf(os.Stderr, "API request for /groups/search failed with status %d:\n", statusCode)
-		utils.PrintJSON(respBody)
-		os.Exit(1)
+	if err := utils.PrintJSONStream(respBody); err != nil {
+		utils.PrintErrorAndExit("API request failed for /groups/search", err)
 	}
 }
 
 // handleRetrieveGroupSeries (GET /groups/{id}/series)
-func handleRetrieveGroupSeries(args []string) {
+func handleRetrieveGroupSeries(ctx context.Context, args []string) {
 	fs := flag.NewFlagSet("retrieveGroupSeries", flag.ContinueOnError)
 	groupID := fs.Int64("id", 0, "Group ID (required).") // Path parameter
 
@@ -260,22 +233,13 @@ func handleRetrieveGroupSeries(args []string) {
 		os.Exit(1)
 	}
 
-	path := fmt.Sprintf("/groups/%d/series", *groupID)
-	fullURL, err := apiclient.BuildURL(path, nil)
-	if err != nil {
-		utils.PrintErrorAndExit("Failed to build URL for /groups/{id}/series", err)
-	}
-
-	respBody, statusCode, err := apiclient.DoRequest("GET", fullURL, nil)
+	respBody, err := mangaupdates.Default().RetrieveGroupSeriesRaw(ctx, *groupID)
 	if err != nil {
 		utils.PrintErrorAndExit("API request failed for /groups/{id}/series", err)
 	}
+	defer respBody.Close()
 
-	if statusCode == http.StatusOK || statusCode == http.StatusNotFound { // 404 if group not found
-		utils.PrintJSON(respBody)
-	} else {
-		fmt.Fprintf(os.Stderr, "API request for /groups/{id}/series failed with status %d:\n", statusCode)
-		utils.PrintJSON(respBody)
-		os.Exit(1)
+	if err := utils.PrintJSONStream(respBody); err != nil {
+		utils.PrintErrorAndExit("API request failed for /groups/{id}/series", err)
 	}
 }
@@ -0,0 +1,114 @@
+// Code generated by tools/helpcodegen/main.go; DO NOT EDIT.
+package groups
+
+import "github.com/TheDucker1/mangaupdatescli/internal/utils"
+
+var helpRetrieveGroupContent = utils.HelpContent{
+	Usage:       "mangaupdatescli groups retrieveGroup --id <integer(int64)> [--unrenderedFields <boolean>]",
+	Description: "Retrieve a group",
+	Arguments: []utils.ArgHelp{
+		{
+			Name:        "id",
+			Type:        "integer(int64)",
+			Required:    true,
+			Description: "",
+			In:          "path",
+		},
+		{
+			Name:        "unrenderedFields",
+			Type:        "boolean",
+			Required:    false,
+			Description: "Output fields in unrendered form.",
+			Default:     "false",
+			In:          "query",
+		},
+	},
+	InputJSON:     "Path/Query Parameters",
+	OutputJSON:    utils.SchemaHelp{Label: "Schema (on 200)", Schema: &utils.Schema{Ref: "GroupModelV1"}},
+	ErrorExamples: map[string]string{"404": "Group not found"},
+	AuthRequired:  false,
+}
+
+var helpRetrieveGroupSeriesContent = utils.HelpContent{
+	Usage:       "mangaupdatescli groups retrieveGroupSeries --id <integer(int64)>",
+	Description: "List the series a group releases",
+	Arguments: []utils.ArgHelp{
+		{
+			Name:        "id",
+			Type:        "integer(int64)",
+			Required:    true,
+			Description: "",
+			In:          "path",
+		},
+	},
+	InputJSON:     "Path/Query Parameters",
+	OutputJSON:    utils.SchemaHelp{Label: "Schema (on 200)", Schema: &utils.Schema{Ref: "GroupsSeriesListResponseV1"}},
+	ErrorExamples: map[string]string{"404": "Group not found"},
+	AuthRequired:  false,
+}
+
+var helpSearchGroupsPostContent = utils.HelpContent{
+	Usage:       "mangaupdatescli groups searchGroupsPost [--search <string>] [--added_by <integer(int64)>] [--page <integer>] [--perpage <integer>] [--letter <string>] [--active <boolean>] [--pending <boolean>] [--body <JSON|YAML|@file|->]",
+	Description: "Search groups",
+	Arguments: []utils.ArgHelp{
+		{
+			Name:        "search",
+			Type:        "string",
+			Required:    false,
+			Description: "Search term for groups.",
+			In:          "body",
+		},
+		{
+			Name:        "added_by",
+			Type:        "integer(int64)",
+			Required:    false,
+			Description: "Filter by user ID who added the group.",
+			In:          "body",
+		},
+		{
+			Name:        "page",
+			Type:        "integer",
+			Required:    false,
+			Description: "Page number.",
+			In:          "body",
+		},
+		{
+			Name:        "perpage",
+			Type:        "integer",
+			Required:    false,
+			Description: "Results per page.",
+			In:          "body",
+		},
+		{
+			Name:        "letter",
+			Type:        "string",
+			Required:    false,
+			Description: "Filter by starting letter.",
+			In:          "body",
+		},
+		{
+			Name:        "active",
+			Type:        "boolean",
+			Required:    false,
+			Description: "Filter by active status.",
+			In:          "body",
+		},
+		{
+			Name:        "pending",
+			Type:        "boolean",
+			Required:    false,
+			Description: "Filter by pending status.",
+			In:          "body",
+		},
+		{
+			Name:        "body",
+			Type:        "JSON|YAML",
+			Required:    false,
+			Description: "Request body as a JSON or YAML document, @file to read it from a file, or - for stdin. The flags of its fields override it.",
+		},
+	},
+	InputJSON:     utils.SchemaHelp{Label: "Request Body Schema", Schema: &utils.Schema{Ref: "GroupsSearchRequestV1"}},
+	OutputJSON:    utils.SchemaHelp{Label: "Schema (on 200)", Schema: &utils.Schema{Ref: "GroupsSearchResponseV1"}},
+	ErrorExamples: map[string]string{"400": "Validation or Service Error"},
+	AuthRequired:  false,
+}
@@ -3,17 +3,19 @@
 package misc
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
-	"mangaupdatescli/internal/apiclient"
-	"mangaupdatescli/internal/utils"
-	"net/http"
+	"github.com/TheDucker1/mangaupdatescli/internal/utils"
+	"github.com/TheDucker1/mangaupdatescli/mangaupdates"
 	"os"
 	"sort"
+	"time"
 )
 
-type MiscCommandHandler func(args []string)
+type MiscCommandHandler func(ctx context.Context, args []string)
 
 type CommandInfo struct {
 	Handler MiscCommandHandler
@@ -53,12 +55,23 @@ func init() {
 	// Ensure `helpRetrieveSlowTransactionStatusContent` is defined in misc_generated_help.go
 	miscCommands["retrieveSlowTransactionStatus"] = CommandInfo{
 		Handler: handleRetrieveSlowTransactionStatus,
-		Help:    helpRetrieveSlowTransactionStatusContent,
+		Help:    retrieveSlowTransactionStatusHelp(),
 	}
 }
 
+// retrieveSlowTransactionStatusHelp is the generated help plus the CLI-only
+// --wait flags, which are not part of the API spec.
+func retrieveSlowTransactionStatusHelp() utils.HelpContent {
+	hc := helpRetrieveSlowTransactionStatusContent
+	hc.Arguments = append(append([]utils.ArgHelp{}, hc.Arguments...),
+		utils.ArgHelp{Name: "wait", Type: "boolean", Description: "Poll until the transaction finishes, reporting progress on stderr.", Default: "false"},
+		utils.ArgHelp{Name: "wait-timeout", Type: "duration", Description: "With --wait, give up (exit code 9) after this long.", Default: mangaupdates.DefaultTransactionWaitTimeout.String()},
+	)
+	return hc
+}
+
 // HandleCommand dispatches to the correct misc command handler
-func HandleCommand(command string, args []string) {
+func HandleCommand(ctx context.Context, command string, args []string) {
 	cmdInfo, ok := miscCommands[command]
 	if !ok {
 		isJsonHelp, _, _ := utils.CheckHelpFlags(args) // Check if they tried `misc unknown -h`
@@ -66,7 +79,7 @@ func HandleCommand(command string, args []string) {
 		PrintMiscSubprogramHelp(isJsonHelp) // Show subprogram help if command is unknown
 		os.Exit(1)
 	}
-	cmdInfo.Handler(args)
+	cmdInfo.Handler(ctx, args)
 }
 
 // PrintMiscSubprogramHelp prints help for the entire 'misc' subprogram
@@ -119,7 +132,7 @@ func PrintMiscSubprogramHelp(jsonFormat bool) {
 // --- Handler Functions ---
 
 // handleTime corresponds to operationId: time
-func handleTime(args []string) {
+func handleTime(ctx context.Context, args []string) {
 	fs := flag.NewFlagSet("time", flag.ContinueOnError)
 
 	isJsonHelp, isTextHelp, remainingArgs := utils.CheckHelpFlags(args)
@@ -136,27 +149,19 @@ func handleTime(args []string) {
 		return
 	}
 
-	fullURL, err := apiclient.BuildURL("/misc/time", nil)
-	if err != nil {
-		utils.PrintErrorAndExit("Failed to build URL for /misc/time", err)
-	}
-
-	respBody, statusCode, err := apiclient.DoRequest("GET", fullURL, nil)
+	respBody, err := mangaupdates.Default().RetrieveTimeRaw(ctx)
 	if err != nil {
 		utils.PrintErrorAndExit("API request failed for /misc/time", err)
 	}
+	defer respBody.Close()
 
-	if statusCode == http.StatusOK {
-		utils.PrintJSON(respBody)
-	} else {
-		fmt.Fprintf(os.Stderr, "API request for /misc/time failed with status %d:\n", statusCode)
-		utils.PrintJSON(respBody)
-		os.Exit(1)
+	if err := utils.PrintJSONStream(respBody); err != nil {
+		utils.PrintErrorAndExit("API request failed for /misc/time", err)
 	}
 }
 
 // handleListOnlineUsers corresponds to operationId: listOnlineUsers
-func handleListOnlineUsers(args []string) {
+func handleListOnlineUsers(ctx context.Context, args []string) {
 	fs := flag.NewFlagSet("online", flag.ContinueOnError) // CLI command is "online"
 
 	isJsonHelp, isTextHelp, remainingArgs := utils.CheckHelpFlags(args)
@@ -173,27 +178,19 @@ func handleListOnlineUsers(args []string) {
 		return
 	}
 
-	fullURL, err := apiclient.BuildURL("/misc/online", nil)
-	if err != nil {
-		utils.PrintErrorAndExit("Failed to build URL for /misc/online", err)
-	}
-
-	respBody, statusCode, err := apiclient.DoRequest("GET", fullURL, nil)
+	respBody, err := mangaupdates.Default().ListOnlineUsersRaw(ctx)
 	if err != nil {
 		utils.PrintErrorAndExit("API request failed for /misc/online", err)
 	}
+	defer respBody.Close()
 
-	if statusCode == http.StatusOK {
-		utils.PrintJSON(respBody)
-	} else {
-		fmt.Fprintf(os.Stderr, "API request for /misc/online failed with status %d:\n", statusCode)
-		utils.PrintJSON(respBody)
-		os.Exit(1)
+	if err := utils.PrintJSONStream(respBody); err != nil {
+		utils.PrintErrorAndExit("API request failed for /misc/online", err)
 	}
 }
 
 // handleSiteStats corresponds to operationId: siteStats
-func handleSiteStats(args []string) {
+func handleSiteStats(ctx context.Context, args []string) {
 	fs := flag.NewFlagSet("stats", flag.ContinueOnError) // CLI command is "stats"
 
 	isJsonHelp, isTextHelp, remainingArgs := utils.CheckHelpFlags(args)
@@ -210,29 +207,23 @@ func handleSiteStats(args []string) {
 		return
 	}
 
-	fullURL, err := apiclient.BuildURL("/misc/stats", nil)
-	if err != nil {
-		utils.PrintErrorAndExit("Failed to build URL for /misc/stats", err)
-	}
-
-	respBody, statusCode, err := apiclient.DoRequest("GET", fullURL, nil)
+	respBody, err := mangaupdates.Default().SiteStatsRaw(ctx)
 	if err != nil {
 		utils.PrintErrorAndExit("API request failed for /misc/stats", err)
 	}
+	defer respBody.Close()
 
-	if statusCode == http.StatusOK {
-		utils.PrintJSON(respBody)
-	} else {
-		fmt.Fprintf(os.Stderr, "API request for /misc/stats failed with status %d:\n", statusCode)
-		utils.PrintJSON(respBody)
-		os.Exit(1)
+	if err := utils.PrintJSONStream(respBody); err != nil {
+		utils.PrintErrorAndExit("API request failed for /misc/stats", err)
 	}
 }
 
 // handleRetrieveSlowTransactionStatus corresponds to operationId: retrieveSlowTransactionStatus
-func handleRetrieveSlowTransactionStatus(args []string) {
+func handleRetrieveSlowTransactionStatus(ctx context.Context, args []string) {
 	fs := flag.NewFlagSet("retrieveSlowTransactionStatus", flag.ContinueOnError)
 	transactionID := fs.String("transaction_id", "", "The transaction ID (required).") // From generated help's Arguments
+	wait := fs.Bool("wait", false, "Poll until the transaction finishes.")
+	waitTimeout := fs.Duration("wait-timeout", mangaupdates.DefaultTransactionWaitTimeout, "With --wait, give up after this long.")
 
 	isJsonHelp, isTextHelp, remainingArgs := utils.CheckHelpFlags(args)
 	if err := fs.Parse(remainingArgs); err != nil {
@@ -240,36 +231,50 @@ func handleRetrieveSlowTransactionStatus(args []string) {
 	}
 
 	if isJsonHelp {
-		utils.PrintJSONHelp(helpRetrieveSlowTransactionStatusContent) // Uses generated help
+		utils.PrintJSONHelp(retrieveSlowTransactionStatusHelp())
 		return
 	}
 	if isTextHelp {
-		utils.PrintFormattedHelp(helpRetrieveSlowTransactionStatusContent) // Uses generated help
+		utils.PrintFormattedHelp(retrieveSlowTransactionStatusHelp())
 		return
 	}
 
 	if *transactionID == "" {
 		fmt.Fprintln(os.Stderr, "Error: --transaction_id is required for retrieveSlowTransactionStatus.")
-		utils.PrintFormattedHelp(helpRetrieveSlowTransactionStatusContent)
+		utils.PrintFormattedHelp(retrieveSlowTransactionStatusHelp())
 		os.Exit(1)
 	}
 
-	path := fmt.Sprintf("/misc/slow-transaction-status/%s", *transactionID)
-	fullURL, err := apiclient.BuildURL(path, nil)
-	if err != nil {
-		utils.PrintErrorAndExit("Failed to build URL", err)
+	if *wait {
+		if *waitTimeout <= 0 {
+			fmt.Fprintln(os.Stderr, "Error: --wait-timeout must be positive.")
+			os.Exit(1)
+		}
+		_, raw, err := mangaupdates.Default().WaitForTransaction(ctx, *transactionID, mangaupdates.WaitOptions{
+			Timeout: *waitTimeout,
+			Progress: func(id, status, reason string, elapsed time.Duration) {
+				if reason != "" {
+					status += ": " + reason
+				}
+				fmt.Fprintf(os.Stderr, "Transaction %s still pending (%s) after %s...\n", id, status, elapsed.Round(time.Second))
+			},
+		})
+		if err != nil {
+			utils.PrintErrorAndExit("Waiting for transaction status failed", err)
+		}
+		if err := utils.PrintJSONStream(bytes.NewReader(raw)); err != nil {
+			utils.PrintErrorAndExit("Failed to print transaction status", err)
+		}
+		return
 	}
 
-	respBody, statusCode, err := apiclient.DoRequest("GET", fullURL, nil)
+	respBody, err := mangaupdates.Default().RetrieveSlowTransactionStatusRaw(ctx, *transactionID)
 	if err != nil {
 		utils.PrintErrorAndExit("API request failed for transaction status", err)
 	}
+	defer respBody.Close()
 
-	if statusCode == http.StatusOK || statusCode == http.StatusNotFound {
-		utils.PrintJSON(respBody)
-	} else {
-		fmt.Fprintf(os.Stderr, "API request for transaction status failed with status %d:\n", statusCode)
-		utils.PrintJSON(respBody)
-		os.Exit(1)
+	if err := utils.PrintJSONStream(respBody); err != nil {
+		utils.PrintErrorAndExit("API request failed for transaction status", err)
 	}
 }
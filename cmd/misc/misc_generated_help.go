@@ -0,0 +1,52 @@
+// Code generated by tools/helpcodegen/main.go; DO NOT EDIT.
+package misc
+
+import "github.com/TheDucker1/mangaupdatescli/internal/utils"
+
+var helpListOnlineUsersContent = utils.HelpContent{
+	Usage:         "mangaupdatescli misc listOnlineUsers",
+	Description:   "List the users currently online",
+	Arguments:     nil,
+	InputJSON:     "None",
+	OutputJSON:    utils.SchemaHelp{Label: "Schema (on 200)", Schema: &utils.Schema{Ref: "OnlineUsersV1"}},
+	ErrorExamples: map[string]string{"Generic": "Standard API errors."},
+	AuthRequired:  false,
+}
+
+var helpRetrieveSlowTransactionStatusContent = utils.HelpContent{
+	Usage:       "mangaupdatescli misc retrieveSlowTransactionStatus --transaction-id <string>",
+	Description: "Retrieve the status of a slow transaction",
+	Arguments: []utils.ArgHelp{
+		{
+			Name:        "transaction_id",
+			Type:        "string",
+			Required:    true,
+			Description: "The transaction ID returned by the request that started it.",
+			In:          "path",
+		},
+	},
+	InputJSON:     "Path/Query Parameters",
+	OutputJSON:    utils.SchemaHelp{Label: "Schema (on 200)", Schema: &utils.Schema{Ref: "ApiResponseV1"}},
+	ErrorExamples: map[string]string{"404": "Transaction not found"},
+	AuthRequired:  false,
+}
+
+var helpSiteStatsContent = utils.HelpContent{
+	Usage:         "mangaupdatescli misc siteStats",
+	Description:   "Retrieve site statistics",
+	Arguments:     nil,
+	InputJSON:     "None",
+	OutputJSON:    utils.SchemaHelp{Label: "Schema (on 200)", Schema: &utils.Schema{Ref: "SiteStatsV1"}},
+	ErrorExamples: map[string]string{"Generic": "Standard API errors."},
+	AuthRequired:  false,
+}
+
+var helpTimeContent = utils.HelpContent{
+	Usage:         "mangaupdatescli misc time",
+	Description:   "Retrieve the server time",
+	Arguments:     nil,
+	InputJSON:     "None",
+	OutputJSON:    utils.SchemaHelp{Label: "Schema (on 200)", Schema: &utils.Schema{Ref: "TimeV1"}},
+	ErrorExamples: map[string]string{"Generic": "Standard API errors."},
+	AuthRequired:  false,
+}
@@ -0,0 +1,197 @@
+// cmd/mock/mock.go
+package mock
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/TheDucker1/mangaupdatescli/internal/mockserver"
+	"github.com/TheDucker1/mangaupdatescli/internal/openapi"
+	"github.com/TheDucker1/mangaupdatescli/internal/utils"
+	"net"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CommandHandler defines the function signature for command handlers
+type CommandHandler func(ctx context.Context, args []string)
+
+// CommandInfo stores the handler and its associated help content
+type CommandInfo struct {
+	Handler CommandHandler
+	Help    utils.HelpContent
+}
+
+// mockCommands maps the CLI command name to its handler and help
+var mockCommands = make(map[string]CommandInfo)
+
+// init populates mockCommands. The help variables live in mock_help.go.
+func init() {
+	mockCommands["serve"] = CommandInfo{Handler: handleServe, Help: serveHelpContent}
+}
+
+// HandleCommand dispatches to the correct mock command handler
+func HandleCommand(ctx context.Context, command string, args []string) {
+	cmdInfo, ok := mockCommands[command]
+	if !ok {
+		isJsonHelp, _, _ := utils.CheckHelpFlags(args)
+		fmt.Fprintf(os.Stderr, "Error: Unknown mock command: %s\n\n", command)
+		PrintMockSubprogramHelp(isJsonHelp)
+		os.Exit(1)
+	}
+	cmdInfo.Handler(ctx, args)
+}
+
+// PrintMockSubprogramHelp prints help for the entire 'mock' subprogram
+func PrintMockSubprogramHelp(jsonFormat bool) {
+	if jsonFormat {
+		type CommandHelpSummary struct {
+			Command     string `json:"command"`
+			Usage       string `json:"usage"`
+			Description string `json:"description"`
+		}
+		var summaries []CommandHelpSummary
+		var commandNames []string
+		for name := range mockCommands {
+			commandNames = append(commandNames, name)
+		}
+		sort.Strings(commandNames)
+
+		for _, name := range commandNames {
+			cmdInfo := mockCommands[name]
+			summaries = append(summaries, CommandHelpSummary{
+				Command:     name,
+				Usage:       cmdInfo.Help.Usage,
+				Description: cmdInfo.Help.Description,
+			})
+		}
+		outputData := map[string]interface{}{
+			"subprogram":  "mock",
+			"description": "A stand-in API server generated from the OpenAPI spec, for working without the network.",
+			"commands":    summaries,
+		}
+		jsonData, _ := json.MarshalIndent(outputData, "", "  ")
+		fmt.Println(string(jsonData))
+	} else {
+		fmt.Println("`mock` subprogram: A stand-in API server generated from the OpenAPI spec, for working without the network.")
+		fmt.Println("Available commands:")
+		var commandNames []string
+		for name := range mockCommands {
+			commandNames = append(commandNames, name)
+		}
+		sort.Strings(commandNames)
+
+		for _, name := range commandNames {
+			cmdInfo := mockCommands[name]
+			fmt.Printf("  %-30s %s\n", name, cmdInfo.Help.Description)
+		}
+		fmt.Println("\nUse 'mangaupdatescli mock <command> -hh' for more detailed help on a specific command.")
+	}
+}
+
+// --- Handler Functions ---
+
+// ruleList is a flag.Value collecting the rules of a repeated flag.
+type ruleList struct {
+	rules []mockserver.Rule
+	parse func(string) (mockserver.Rule, error)
+	text  []string
+}
+
+func (l *ruleList) String() string {
+	if l == nil {
+		return ""
+	}
+	return strings.Join(l.text, " ")
+}
+
+func (l *ruleList) Set(s string) error {
+	rule, err := l.parse(s)
+	if err != nil {
+		return err
+	}
+	l.rules = append(l.rules, rule)
+	l.text = append(l.text, s)
+	return nil
+}
+
+// handleServe runs the mock server until interrupted
+func handleServe(ctx context.Context, args []string) {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	specFile := fs.String("spec", "", "OpenAPI spec to serve (default: the spec embedded in the CLI).")
+	host := fs.String("host", "127.0.0.1", "Address to listen on.")
+	port := fs.Int("port", 8080, "Port to listen on; 0 picks a free one.")
+	fixtures := fs.String("fixtures", "", "Directory of response fixtures that override the spec's examples.")
+	errorRules := &ruleList{parse: mockserver.ParseErrorRule}
+	fs.Var(errorRules, "error", "Answer with an error status: [operationId:]status[=rate]; may be repeated.")
+	latencyRules := &ruleList{parse: mockserver.ParseLatencyRule}
+	fs.Var(latencyRules, "latency", "Delay responses: [operationId:]duration[=rate]; may be repeated.")
+	seed := fs.Uint64("seed", 0, "Seed for the rules with a rate (default: random).")
+
+	isJsonHelp, isTextHelp, remainingArgs := utils.CheckHelpFlags(args)
+	if err := fs.Parse(remainingArgs); err != nil {
+		utils.PrintErrorAndExit("Failed to parse flags for 'serve'", err)
+	}
+	if isJsonHelp {
+		utils.PrintJSONHelp(serveHelpContent)
+		return
+	}
+	if isTextHelp {
+		utils.PrintFormattedHelp(serveHelpContent)
+		return
+	}
+
+	var spec *openapi.Spec
+	var err error
+	if *specFile != "" {
+		spec, err = openapi.LoadFile(*specFile)
+	} else {
+		spec, err = openapi.Load()
+	}
+	if err != nil {
+		utils.PrintErrorAndExit("Failed to load the OpenAPI spec", err)
+	}
+	for _, rule := range append(errorRules.rules, latencyRules.rules...) {
+		if _, ok := spec.Operation(rule.Operation); rule.Operation != "" && !ok {
+			utils.PrintErrorAndExit(fmt.Sprintf("Unknown operation %q in a rule (see 'mangaupdatescli spec ops')", rule.Operation), nil)
+		}
+	}
+	if *fixtures != "" {
+		if info, err := os.Stat(*fixtures); err != nil || !info.IsDir() {
+			utils.PrintErrorAndExit(fmt.Sprintf("--fixtures %s is not a directory", *fixtures), err)
+		}
+	}
+	if *seed == 0 {
+		*seed = uint64(time.Now().UnixNano())
+	}
+
+	server := mockserver.NewServer(spec, *seed)
+	server.Fixtures = *fixtures
+	server.Errors = errorRules.rules
+	server.Latency = latencyRules.rules
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(*host, strconv.Itoa(*port)))
+	if err != nil {
+		utils.PrintErrorAndExit("Failed to listen", err)
+	}
+	httpServer := &http.Server{Handler: server}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		httpServer.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Fprintf(os.Stderr, "Serving %d operations of %s %s at http://%s%s (Ctrl-C to stop)\n",
+		len(spec.Operations), spec.Title, spec.Version, listener.Addr(), spec.BasePath)
+	fmt.Fprintf(os.Stderr, "Point the CLI at it with --api-url http://%s%s\n", listener.Addr(), spec.BasePath)
+	if err := httpServer.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+		utils.PrintErrorAndExit("Mock server failed", err)
+	}
+}
@@ -0,0 +1,22 @@
+// cmd/mock/mock_help.go
+package mock
+
+import "github.com/TheDucker1/mangaupdatescli/internal/utils"
+
+var serveHelpContent = utils.HelpContent{
+	Usage:       "mangaupdatescli mock serve [--spec <file>] [--host <string>] [--port <integer>] [--fixtures <dir>] [--error <rule> ...] [--latency <rule> ...] [--seed <integer>]",
+	Description: "Serve every operation of the OpenAPI spec over HTTP until interrupted, answering with the spec's examples or with data synthesized from the response schemas. Fixture files override responses, and rules inject error statuses and latency, so scripts and the CLI can be tested without network access (point them at it with --api-url). Operations that require a session answer 401 without a bearer token.",
+	Arguments: []utils.ArgHelp{
+		{Name: "spec", Type: "file", Description: "OpenAPI spec to serve.", Default: "the spec embedded in the CLI"},
+		{Name: "host", Type: "string", Description: "Address to listen on.", Default: "127.0.0.1"},
+		{Name: "port", Type: "integer", Description: "Port to listen on; 0 picks a free one.", Default: "8080"},
+		{Name: "fixtures", Type: "dir", Description: "Directory of fixtures: <operationId>/<path parameters joined by _>.json or <operationId>.json, holding the response body or a cassette saved with --record (which also sets the status)."},
+		{Name: "error", Type: "[operationId:]status[=rate]", Description: "Answer with an error status, for every operation or only operationId, always or for the given fraction of requests, e.g. 503=0.2 or retrieveSeries:404. May be repeated; the first rule that fires applies. 429 and 503 come with Retry-After: 1."},
+		{Name: "latency", Type: "[operationId:]duration[=rate]", Description: "Delay responses, e.g. 300ms or searchSeriesPost:5s=0.1. May be repeated; the first rule that fires applies."},
+		{Name: "seed", Type: "integer", Description: "Seed for rules with a rate, to make runs repeatable.", Default: "random"},
+	},
+	InputJSON:     "None",
+	OutputJSON:    "None; each request is logged on stderr",
+	ErrorExamples: map[string]string{"Generic": "Unreadable spec, bad rule, or the port is in use."},
+	AuthRequired:  false,
+}
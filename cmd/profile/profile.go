@@ -0,0 +1,422 @@
+// cmd/profile/profile.go
+package profile
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/TheDucker1/mangaupdatescli/internal/apiclient"
+	"github.com/TheDucker1/mangaupdatescli/internal/config"
+	"github.com/TheDucker1/mangaupdatescli/internal/utils"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CommandHandler defines the function signature for command handlers
+type CommandHandler func(ctx context.Context, args []string)
+
+// CommandInfo stores the handler and its associated help content
+type CommandInfo struct {
+	Handler CommandHandler
+	Help    utils.HelpContent
+}
+
+// profileCommands maps the CLI command name to its handler and help
+var profileCommands = make(map[string]CommandInfo)
+
+// init populates profileCommands. The help variables live in profile_help.go.
+func init() {
+	profileCommands["list"] = CommandInfo{Handler: handleList, Help: listHelpContent}
+	profileCommands["add"] = CommandInfo{Handler: handleAdd, Help: addHelpContent}
+	profileCommands["remove"] = CommandInfo{Handler: handleRemove, Help: removeHelpContent}
+	profileCommands["use"] = CommandInfo{Handler: handleUse, Help: useHelpContent}
+	profileCommands["set"] = CommandInfo{Handler: handleSet, Help: setHelpContent}
+}
+
+// HandleCommand dispatches to the correct profile command handler
+func HandleCommand(ctx context.Context, command string, args []string) {
+	cmdInfo, ok := profileCommands[command]
+	if !ok {
+		isJsonHelp, _, _ := utils.CheckHelpFlags(args)
+		fmt.Fprintf(os.Stderr, "Error: Unknown profile command: %s\n\n", command)
+		PrintProfileSubprogramHelp(isJsonHelp)
+		os.Exit(1)
+	}
+	cmdInfo.Handler(ctx, args)
+}
+
+// PrintProfileSubprogramHelp prints help for the entire 'profile' subprogram
+func PrintProfileSubprogramHelp(jsonFormat bool) {
+	if jsonFormat {
+		type CommandHelpSummary struct {
+			Command     string `json:"command"`
+			Usage       string `json:"usage"`
+			Description string `json:"description"`
+		}
+		var summaries []CommandHelpSummary
+		var commandNames []string
+		for name := range profileCommands {
+			commandNames = append(commandNames, name)
+		}
+		sort.Strings(commandNames)
+
+		for _, name := range commandNames {
+			cmdInfo := profileCommands[name]
+			summaries = append(summaries, CommandHelpSummary{
+				Command:     name,
+				Usage:       cmdInfo.Help.Usage,
+				Description: cmdInfo.Help.Description,
+			})
+		}
+		outputData := map[string]interface{}{
+			"subprogram":  "profile",
+			"description": "Commands for managing named credential profiles.",
+			"commands":    summaries,
+		}
+		jsonData, _ := json.MarshalIndent(outputData, "", "  ")
+		fmt.Println(string(jsonData))
+	} else {
+		fmt.Println("`profile` subprogram: Commands for managing named credential profiles.")
+		fmt.Println("Available commands:")
+		var commandNames []string
+		for name := range profileCommands {
+			commandNames = append(commandNames, name)
+		}
+		sort.Strings(commandNames)
+
+		for _, name := range commandNames {
+			cmdInfo := profileCommands[name]
+			fmt.Printf("  %-30s %s\n", name, cmdInfo.Help.Description)
+		}
+		fmt.Println("\nUse 'mangaupdatescli profile <command> -hh' for more detailed help on a specific command.")
+	}
+}
+
+// --- Handler Functions ---
+
+// handleList prints every stored profile as JSON
+func handleList(ctx context.Context, args []string) {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+
+	isJsonHelp, isTextHelp, remainingArgs := utils.CheckHelpFlags(args)
+	if err := fs.Parse(remainingArgs); err != nil {
+		utils.PrintErrorAndExit("Failed to parse flags for 'list'", err)
+	}
+
+	if isJsonHelp {
+		utils.PrintJSONHelp(listHelpContent)
+		return
+	}
+	if isTextHelp {
+		utils.PrintFormattedHelp(listHelpContent)
+		return
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		utils.PrintErrorAndExit("Failed to load config", err)
+	}
+
+	type profileSummary struct {
+		Name     string `json:"name"`
+		Current  bool   `json:"current"` // stored default for new shells
+		Active   bool   `json:"active"`  // used by this invocation
+		Username string `json:"username,omitempty"`
+		LoggedIn bool   `json:"logged_in"`
+		// SessionAPIURL is the API root the session was created for.
+		SessionAPIURL string `json:"session_api_url,omitempty"`
+		APIURL        string `json:"api_url,omitempty"`
+		Proxy         string `json:"proxy,omitempty"`
+		CAFile        string `json:"ca_file,omitempty"`
+		Insecure      bool   `json:"insecure_skip_verify,omitempty"`
+		Timeout       string `json:"timeout,omitempty"`
+	}
+	current := cfg.CurrentProfile
+	if current == "" {
+		current = config.DefaultProfile
+	}
+	summaries := []profileSummary{}
+	for _, name := range cfg.ProfileNames() {
+		p := cfg.Profiles[name]
+		summaries = append(summaries, profileSummary{
+			Name:          name,
+			Current:       name == current,
+			Active:        name == cfg.ActiveProfileName(),
+			Username:      p.Username,
+			LoggedIn:      p.SessionToken != "",
+			SessionAPIURL: p.SessionAPIURL,
+			APIURL:        p.APIURL,
+			Proxy:         p.Proxy,
+			CAFile:        p.CAFile,
+			Insecure:      p.InsecureSkipVerify,
+			Timeout:       p.Timeout,
+		})
+	}
+	jsonData, _ := json.Marshal(summaries)
+	utils.PrintJSON(jsonData)
+}
+
+// handleAdd creates a new, logged-out profile
+func handleAdd(ctx context.Context, args []string) {
+	fs := flag.NewFlagSet("add", flag.ContinueOnError)
+	nameFlag := fs.String("name", "", "Profile name (required).")
+	use := fs.Bool("use", false, "Also make it the current profile.")
+
+	isJsonHelp, isTextHelp, remainingArgs := utils.CheckHelpFlags(args)
+	if err := fs.Parse(remainingArgs); err != nil {
+		utils.PrintErrorAndExit("Failed to parse flags for 'add'", err)
+	}
+
+	if isJsonHelp {
+		utils.PrintJSONHelp(addHelpContent)
+		return
+	}
+	if isTextHelp {
+		utils.PrintFormattedHelp(addHelpContent)
+		return
+	}
+
+	name := profileNameArg(fs, *nameFlag, addHelpContent)
+	cfg, err := config.Load()
+	if err != nil {
+		utils.PrintErrorAndExit("Failed to load config", err)
+	}
+	if _, exists := cfg.Profiles[name]; exists {
+		utils.PrintErrorAndExit(fmt.Sprintf("Profile %q already exists", name), nil)
+	}
+	if cfg.Profiles == nil {
+		cfg.Profiles = make(map[string]*config.Profile)
+	}
+	cfg.Profiles[name] = &config.Profile{}
+	if *use {
+		cfg.CurrentProfile = name
+	}
+	if err := config.Save(cfg); err != nil {
+		utils.PrintErrorAndExit("Failed to save config", err)
+	}
+	fmt.Printf("Added profile %q.\n", name)
+}
+
+// handleRemove deletes a profile and its stored token
+func handleRemove(ctx context.Context, args []string) {
+	fs := flag.NewFlagSet("remove", flag.ContinueOnError)
+	nameFlag := fs.String("name", "", "Profile name (required).")
+
+	isJsonHelp, isTextHelp, remainingArgs := utils.CheckHelpFlags(args)
+	if err := fs.Parse(remainingArgs); err != nil {
+		utils.PrintErrorAndExit("Failed to parse flags for 'remove'", err)
+	}
+
+	if isJsonHelp {
+		utils.PrintJSONHelp(removeHelpContent)
+		return
+	}
+	if isTextHelp {
+		utils.PrintFormattedHelp(removeHelpContent)
+		return
+	}
+
+	name := profileNameArg(fs, *nameFlag, removeHelpContent)
+	cfg, err := config.Load()
+	if err != nil {
+		utils.PrintErrorAndExit("Failed to load config", err)
+	}
+	if _, exists := cfg.Profiles[name]; !exists {
+		utils.PrintErrorAndExit(fmt.Sprintf("Unknown profile %q", name), nil)
+	}
+	delete(cfg.Profiles, name)
+	if cfg.CurrentProfile == name {
+		cfg.CurrentProfile = ""
+	}
+	if err := config.Save(cfg); err != nil {
+		utils.PrintErrorAndExit("Failed to save config", err)
+	}
+	fmt.Printf("Removed profile %q.\n", name)
+}
+
+// handleUse switches the stored current profile
+func handleUse(ctx context.Context, args []string) {
+	fs := flag.NewFlagSet("use", flag.ContinueOnError)
+	nameFlag := fs.String("name", "", "Profile name (required).")
+
+	isJsonHelp, isTextHelp, remainingArgs := utils.CheckHelpFlags(args)
+	if err := fs.Parse(remainingArgs); err != nil {
+		utils.PrintErrorAndExit("Failed to parse flags for 'use'", err)
+	}
+
+	if isJsonHelp {
+		utils.PrintJSONHelp(useHelpContent)
+		return
+	}
+	if isTextHelp {
+		utils.PrintFormattedHelp(useHelpContent)
+		return
+	}
+
+	name := profileNameArg(fs, *nameFlag, useHelpContent)
+	cfg, err := config.Load()
+	if err != nil {
+		utils.PrintErrorAndExit("Failed to load config", err)
+	}
+	if _, exists := cfg.Profiles[name]; !exists && name != config.DefaultProfile {
+		utils.PrintErrorAndExit(fmt.Sprintf("Unknown profile %q (create it with 'profile add')", name), nil)
+	}
+	cfg.CurrentProfile = name
+	if err := config.Save(cfg); err != nil {
+		utils.PrintErrorAndExit("Failed to save config", err)
+	}
+	fmt.Printf("Now using profile %q.\n", name)
+}
+
+// profileSettings maps the keys accepted by 'profile set' to a function that
+// validates a value and stores it in the profile. An empty value clears it.
+var profileSettings = map[string]func(p *config.Profile, value string) error{
+	"api_url": func(p *config.Profile, value string) error {
+		if value != "" {
+			if _, err := apiclient.NormalizeBaseURL(value); err != nil {
+				return err
+			}
+		}
+		p.APIURL = value
+		return nil
+	},
+	"proxy": func(p *config.Profile, value string) error {
+		if value != "" {
+			if _, err := apiclient.ValidateProxyURL(value); err != nil {
+				return err
+			}
+		}
+		p.Proxy = value
+		return nil
+	},
+	"ca_file": func(p *config.Profile, value string) error {
+		if value != "" {
+			abs, err := filepath.Abs(value)
+			if err != nil {
+				return err
+			}
+			if _, _, err := apiclient.LoadCAFile(abs); err != nil {
+				return err
+			}
+			value = abs
+		}
+		p.CAFile = value
+		return nil
+	},
+	"insecure_skip_verify": func(p *config.Profile, value string) error {
+		if value == "" {
+			p.InsecureSkipVerify = false
+			return nil
+		}
+		insecure, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("expected true or false, got %q", value)
+		}
+		p.InsecureSkipVerify = insecure
+		return nil
+	},
+	"timeout": func(p *config.Profile, value string) error {
+		if value != "" {
+			if d, err := time.ParseDuration(value); err != nil || d < 0 {
+				return fmt.Errorf("expected a non-negative duration such as 30s or 2m, got %q", value)
+			}
+		}
+		p.Timeout = value
+		return nil
+	},
+}
+
+// handleSet changes the settings of an existing profile
+func handleSet(ctx context.Context, args []string) {
+	fs := flag.NewFlagSet("set", flag.ContinueOnError)
+	nameFlag := fs.String("name", "", "Profile to change (default: active profile).")
+
+	isJsonHelp, isTextHelp, remainingArgs := utils.CheckHelpFlags(args)
+	if err := fs.Parse(remainingArgs); err != nil {
+		utils.PrintErrorAndExit("Failed to parse flags for 'set'", err)
+	}
+
+	if isJsonHelp {
+		utils.PrintJSONHelp(setHelpContent)
+		return
+	}
+	if isTextHelp {
+		utils.PrintFormattedHelp(setHelpContent)
+		return
+	}
+
+	// Settings are key=value arguments rather than flags so they never clash
+	// with the global flags of the same name.
+	if fs.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "Error: at least one key=value setting is required for set.")
+		utils.PrintFormattedHelp(setHelpContent)
+		os.Exit(1)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		utils.PrintErrorAndExit("Failed to load config", err)
+	}
+	name := *nameFlag
+	if name == "" {
+		name = cfg.ActiveProfileName()
+	}
+	profile, exists := cfg.Profiles[name]
+	if !exists {
+		utils.PrintErrorAndExit(fmt.Sprintf("Unknown profile %q (create it with 'profile add')", name), nil)
+	}
+
+	for _, arg := range fs.Args() {
+		key, value, ok := strings.Cut(arg, "=")
+		apply, known := profileSettings[key]
+		if !ok || !known {
+			utils.PrintErrorAndExit(fmt.Sprintf("Invalid setting %q; expected key=value with key one of: %s", arg, strings.Join(settingKeys(), ", ")), nil)
+		}
+		if err := apply(profile, value); err != nil {
+			utils.PrintErrorAndExit(fmt.Sprintf("Invalid value for %s", key), err)
+		}
+	}
+
+	if err := config.Save(cfg); err != nil {
+		utils.PrintErrorAndExit("Failed to save config", err)
+	}
+	fmt.Printf("Updated profile %q.\n", name)
+}
+
+// settingKeys returns the keys of profileSettings in sorted order.
+func settingKeys() []string {
+	keys := make([]string, 0, len(profileSettings))
+	for key := range profileSettings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// profileNameArg returns the --name flag or the first positional argument,
+// exiting with usage help when neither is a valid profile name. It must be
+// called before reading any other flag of fs.
+func profileNameArg(fs *flag.FlagSet, nameFlag string, help utils.HelpContent) string {
+	name := nameFlag
+	if name == "" && fs.NArg() > 0 {
+		name = fs.Arg(0)
+		// Flags after the positional name ("add bot --use") are still flags.
+		if err := fs.Parse(fs.Args()[1:]); err != nil {
+			utils.PrintErrorAndExit(fmt.Sprintf("Failed to parse flags for '%s'", fs.Name()), err)
+		}
+	}
+	if name == "" {
+		fmt.Fprintf(os.Stderr, "Error: --name is required for %s.\n", fs.Name())
+		utils.PrintFormattedHelp(help)
+		os.Exit(1)
+	}
+	if !config.ValidProfileName(name) {
+		utils.PrintErrorAndExit(fmt.Sprintf("Invalid profile name %q", name), nil)
+	}
+	return name
+}
@@ -0,0 +1,68 @@
+// cmd/profile/profile_help.go
+package profile
+
+import "github.com/TheDucker1/mangaupdatescli/internal/utils"
+
+var nameArgHelp = utils.ArgHelp{
+	Name:        "name",
+	Type:        "string",
+	Required:    true,
+	Description: "Profile name (letters, digits, '-', '_' and '.'). May also be given as the first positional argument.",
+}
+
+var listHelpContent = utils.HelpContent{
+	Usage:         "mangaupdatescli profile list",
+	Description:   "List stored profiles and which one is active.",
+	Arguments:     nil,
+	InputJSON:     "None",
+	OutputJSON:    "Array of {name, current, active, username, logged_in}",
+	ErrorExamples: map[string]string{"Generic": "Unreadable config file."},
+	AuthRequired:  false,
+}
+
+var addHelpContent = utils.HelpContent{
+	Usage:         "mangaupdatescli profile add --name <string> [--use]",
+	Description:   "Create a profile. Log in to it with 'mangaupdatescli --profile <name> account login'.",
+	Arguments:     []utils.ArgHelp{nameArgHelp, {Name: "use", Type: "boolean", Description: "Also make it the current profile."}},
+	InputJSON:     "None",
+	OutputJSON:    "Confirmation message",
+	ErrorExamples: map[string]string{"Generic": "Invalid name or profile already exists."},
+	AuthRequired:  false,
+}
+
+var removeHelpContent = utils.HelpContent{
+	Usage:         "mangaupdatescli profile remove --name <string>",
+	Description:   "Delete a profile and its stored token. The session is not logged out on the server.",
+	Arguments:     []utils.ArgHelp{nameArgHelp},
+	InputJSON:     "None",
+	OutputJSON:    "Confirmation message",
+	ErrorExamples: map[string]string{"Generic": "Unknown profile."},
+	AuthRequired:  false,
+}
+
+var useHelpContent = utils.HelpContent{
+	Usage:         "mangaupdatescli profile use --name <string>",
+	Description:   "Make a profile the current one for all later commands.",
+	Arguments:     []utils.ArgHelp{nameArgHelp},
+	InputJSON:     "None",
+	OutputJSON:    "Confirmation message",
+	ErrorExamples: map[string]string{"Generic": "Unknown profile."},
+	AuthRequired:  false,
+}
+
+var setHelpContent = utils.HelpContent{
+	Usage:       "mangaupdatescli profile set [--name <string>] <key>=<value>...",
+	Description: "Change settings of a profile. An empty value clears a setting.",
+	Arguments: []utils.ArgHelp{
+		{Name: "name", Type: "string", Description: "Profile to change.", Default: "active profile"},
+		{Name: "api_url=<url>", Type: "setting", Description: "API base URL for this profile, e.g. a staging or local stand-in server."},
+		{Name: "proxy=<url>", Type: "setting", Description: "http, https or socks5 proxy for API requests (default: HTTP(S)_PROXY environment)."},
+		{Name: "ca_file=<path>", Type: "setting", Description: "PEM bundle of extra trusted CAs, e.g. a corporate proxy CA."},
+		{Name: "insecure_skip_verify=<bool>", Type: "setting", Description: "Disable TLS certificate verification. Unsafe; for debugging only."},
+		{Name: "timeout=<duration>", Type: "setting", Description: "Timeout of a single HTTP request, e.g. 30s; 0 disables."},
+	},
+	InputJSON:     "None",
+	OutputJSON:    "Confirmation message",
+	ErrorExamples: map[string]string{"Generic": "Unknown profile, unknown key or invalid value."},
+	AuthRequired:  false,
+}
@@ -2,19 +2,19 @@
 package publishers
 
 import (
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
-	"mangaupdatescli/internal/apiclient"
-	"mangaupdatescli/internal/utils"
-	"net/http"
+	"github.com/TheDucker1/mangaupdatescli/internal/utils"
+	"github.com/TheDucker1/mangaupdatescli/mangaupdates"
+	"io"
 	"os"
 	"sort"
-	"strings"
 )
 
 // CommandHandler defines the function signature for command handlers
-type CommandHandler func(args []string)
+type CommandHandler func(ctx context.Context, args []string)
 
 // CommandInfo stores the handler and its associated (generated) help content
 type CommandInfo struct {
@@ -36,7 +36,7 @@ func init() {
 
 	publishersCommands["retrievePublisher"] = CommandInfo{
 		Handler: handleRetrievePublisher,
-		Help:    helpRetrievePublisherContent, // From generated file
+		Help:    utils.BulkIDHelp(helpRetrievePublisherContent), // From generated file
 	}
 	publishersCommands["searchPublishersPost"] = CommandInfo{
 		Handler: handleSearchPublishersPost,
@@ -53,7 +53,7 @@ func init() {
 }
 
 // HandleCommand dispatches to the correct publishers command handler
-func HandleCommand(command string, args []string) {
+func HandleCommand(ctx context.Context, command string, args []string) {
 	cmdInfo, ok := publishersCommands[command]
 	if !ok {
 		isJsonHelp, _, _ := utils.CheckHelpFlags(args)
@@ -61,7 +61,7 @@ func HandleCommand(command string, args []string) {
 		PrintPublishersSubprogramHelp(isJsonHelp)
 		os.Exit(1)
 	}
-	cmdInfo.Handler(args)
+	cmdInfo.Handler(ctx, args)
 }
 
 // PrintPublishersSubprogramHelp prints help for the entire 'publishers' subprogram
@@ -114,9 +114,9 @@ func PrintPublishersSubprogramHelp(jsonFormat bool) {
 // --- Handler Functions ---
 
 // handleRetrievePublisher (GET /publishers/{id})
-func handleRetrievePublisher(args []string) {
+func handleRetrievePublisher(ctx context.Context, args []string) {
 	fs := flag.NewFlagSet("retrievePublisher", flag.ContinueOnError)
-	publisherID := fs.Int64("id", 0, "Publisher ID (required).")
+	publisherIDs := utils.AddBulkIDFlags(fs, "Publisher")
 	unrenderedFields := fs.Bool("unrenderedFields", false, "Output fields in unrendered form.")
 
 	isJsonHelp, isTextHelp, remainingArgs := utils.CheckHelpFlags(args)
@@ -125,68 +125,57 @@ func handleRetrievePublisher(args []string) {
 	}
 
 	if isJsonHelp {
-		utils.PrintJSONHelp(helpRetrievePublisherContent)
+		utils.PrintJSONHelp(utils.BulkIDHelp(helpRetrievePublisherContent))
 		return
 	}
 	if isTextHelp {
-		utils.PrintFormattedHelp(helpRetrievePublisherContent)
+		utils.PrintFormattedHelp(utils.BulkIDHelp(helpRetrievePublisherContent))
 		return
 	}
 
-	if *publisherID == 0 {
+	ids, err := publisherIDs.IDs(os.Stdin)
+	if err != nil {
+		utils.PrintErrorAndExit("Invalid arguments for 'retrievePublisher'", err)
+	}
+	if len(ids) == 0 {
 		fmt.Fprintln(os.Stderr, "Error: --id is required for retrievePublisher.")
-		utils.PrintFormattedHelp(helpRetrievePublisherContent)
+		utils.PrintFormattedHelp(utils.BulkIDHelp(helpRetrievePublisherContent))
 		os.Exit(1)
 	}
 
-	path := fmt.Sprintf("/publishers/%d", *publisherID)
-	queryParams := make(map[string]string)
-	if *unrenderedFields {
-		queryParams["unrenderedFields"] = "true"
-	}
+	opts := &mangaupdates.RetrieveOptions{UnrenderedFields: *unrenderedFields}
 
-	fullURL, err := apiclient.BuildURL(path, queryParams)
-	if err != nil {
-		utils.PrintErrorAndExit("Failed to build URL for /publishers/{id}", err)
+	if publisherIDs.IsBatch() {
+		err := utils.RunBulk(ctx, ids, publisherIDs.Parallel, func(ctx context.Context, id int64) ([]byte, error) {
+			body, err := mangaupdates.Default().RetrievePublisherRaw(ctx, id, opts)
+			if err != nil {
+				return nil, err
+			}
+			defer body.Close()
+			return io.ReadAll(body)
+		})
+		if err != nil {
+			utils.PrintErrorAndExit("Bulk retrieval failed for /publishers/{id}", err)
+		}
+		return
 	}
 
-	respBody, statusCode, err := apiclient.DoRequest("GET", fullURL, nil)
+	respBody, err := mangaupdates.Default().RetrievePublisherRaw(ctx, ids[0], opts)
 	if err != nil {
 		utils.PrintErrorAndExit("API request failed for /publishers/{id}", err)
 	}
+	defer respBody.Close()
 
-	if statusCode == http.StatusOK || statusCode == http.StatusNotFound {
-		utils.PrintJSON(respBody)
-	} else {
-		fmt.Fprintf(os.Stderr, "API request for /publishers/{id} failed with status %d:\n", statusCode)
-		utils.PrintJSON(respBody)
-		os.Exit(1)
+	if err := utils.PrintJSONStream(respBody); err != nil {
+		utils.PrintErrorAndExit("API request failed for /publishers/{id}", err)
 	}
 }
 
-// Define PublishersSearchRequestV1 for the request body
-type PublishersSearchRequestV1 struct {
-	Search  string `json:"search,omitempty"`
-	AddedBy int64  `json:"added_by,omitempty"`
-	Page    int    `json:"page,omitempty"`
-	Perpage int    `json:"perpage,omitempty"`
-	Letter  string `json:"letter,omitempty"`
-	Orderby string `json:"orderby,omitempty"` // enum: score, name, series, publications, type
-	Pending *bool  `json:"pending,omitempty"`
-}
-
 // handleSearchPublishersPost (POST /publishers/search)
-func handleSearchPublishersPost(args []string) {
+func handleSearchPublishersPost(ctx context.Context, args []string) {
 	fs := flag.NewFlagSet("searchPublishersPost", flag.ContinueOnError)
-	var reqBody PublishersSearchRequestV1
-
-	fs.StringVar(&reqBody.Search, "search", "", "Search term for publishers.")
-	addedBy := fs.Int64("added_by", 0, "Filter by user ID who added the publisher.")
-	fs.IntVar(&reqBody.Page, "page", 0, "Page number.")
-	fs.IntVar(&reqBody.Perpage, "perpage", 0, "Results per page.")
-	fs.StringVar(&reqBody.Letter, "letter", "", "Filter by starting letter.")
-	fs.StringVar(&reqBody.Orderby, "orderby", "", "Order by (score, name, series, etc.).")
-	pendingStr := fs.String("pending", "", "Filter by pending status (true/false).")
+	var reqBody mangaupdates.PublishersSearchRequestV1
+	bodyFlags := utils.AddBodyFlags(fs, helpSearchPublishersPostContent) // --search, --orderby, ... from the request schema
 
 	isJsonHelp, isTextHelp, remainingArgs := utils.CheckHelpFlags(args)
 	if err := fs.Parse(remainingArgs); err != nil {
@@ -202,35 +191,23 @@ func handleSearchPublishersPost(args []string) {
 		return
 	}
 
-	if *addedBy != 0 {
-		reqBody.AddedBy = *addedBy
-	}
-	if *pendingStr != "" {
-		val := strings.ToLower(*pendingStr) == "true"
-		reqBody.Pending = &val
-	}
-
-	fullURL, err := apiclient.BuildURL("/publishers/search", nil)
-	if err != nil {
-		utils.PrintErrorAndExit("Failed to build URL for /publishers/search", err)
+	if err := bodyFlags.Decode(&reqBody); err != nil {
+		utils.PrintErrorAndExit("Invalid flags for 'searchPublishersPost'", err)
 	}
 
-	respBody, statusCode, err := apiclient.DoRequest("POST", fullURL, reqBody)
+	respBody, err := mangaupdates.Default().SearchPublishersRaw(ctx, &reqBody)
 	if err != nil {
 		utils.PrintErrorAndExit("API request failed for /publishers/search", err)
 	}
+	defer respBody.Close()
 
-	if statusCode == http.StatusOK {
-		utils.PrintJSON(respBody)
-	} else {
-		fmt.Fprintf(os.Stderr, "API request for /publishers/search failed with status %d:\n", statusCode)
-		utils.PrintJSON(respBody)
-		os.Exit(1)
+	if err := utils.PrintJSONStream(respBody); err != nil {
+		utils.PrintErrorAndExit("API request failed for /publishers/search", err)
 	}
 }
 
 // handleRetrievePublisherSeries (GET /publishers/{id}/series)
-func handleRetrievePublisherSeries(args []string) {
+func handleRetrievePublisherSeries(ctx context.Context, args []string) {
 	fs := flag.NewFlagSet("retrievePublisherSeries", flag.ContinueOnError)
 	publisherID := fs.Int64("id", 0, "Publisher ID (required).")
 
@@ -254,28 +231,19 @@ func handleRetrievePublisherSeries(args []string) {
 		os.Exit(1)
 	}
 
-	path := fmt.Sprintf("/publishers/%d/series", *publisherID)
-	fullURL, err := apiclient.BuildURL(path, nil)
-	if err != nil {
-		utils.PrintErrorAndExit("Failed to build URL for /publishers/{id}/series", err)
-	}
-
-	respBody, statusCode, err := apiclient.DoRequest("GET", fullURL, nil)
+	respBody, err := mangaupdates.Default().RetrievePublisherSeriesRaw(ctx, *publisherID)
 	if err != nil {
 		utils.PrintErrorAndExit("API request failed for /publishers/{id}/series", err)
 	}
+	defer respBody.Close()
 
-	if statusCode == http.StatusOK || statusCode == http.StatusNotFound {
-		utils.PrintJSON(respBody)
-	} else {
-		fmt.Fprintf(os.Stderr, "API request for /publishers/{id}/series failed with status %d:\n", statusCode)
-		utils.PrintJSON(respBody)
-		os.Exit(1)
+	if err := utils.PrintJSONStream(respBody); err != nil {
+		utils.PrintErrorAndExit("API request failed for /publishers/{id}/series", err)
 	}
 }
 
 // handleRetrievePublicationSeries (GET /publishers/publication)
-func handleRetrievePublicationSeries(args []string) {
+func handleRetrievePublicationSeries(ctx context.Context, args []string) {
 	fs := flag.NewFlagSet("retrievePublicationSeries", flag.ContinueOnError)
 	pubname := fs.String("pubname", "", "Publication name (required).") // Query parameter
 
@@ -299,25 +267,13 @@ func handleRetrievePublicationSeries(args []string) {
 		os.Exit(1)
 	}
 
-	queryParams := make(map[string]string)
-	queryParams["pubname"] = *pubname
-
-	fullURL, err := apiclient.BuildURL("/publishers/publication", queryParams)
-	if err != nil {
-		utils.PrintErrorAndExit("Failed to build URL for /publishers/publication", err)
-	}
-
-	respBody, statusCode, err := apiclient.DoRequest("GET", fullURL, nil)
+	respBody, err := mangaupdates.Default().RetrievePublicationSeriesRaw(ctx, *pubname)
 	if err != nil {
 		utils.PrintErrorAndExit("API request failed for /publishers/publication", err)
 	}
+	defer respBody.Close()
 
-	if statusCode == http.StatusOK || statusCode == http.StatusNotFound { // 404 if publication not found
-		utils.PrintJSON(respBody)
-	} else {
-		// 400 for validation error (e.g. missing pubname, though we check for it)
-		fmt.Fprintf(os.Stderr, "API request for /publishers/publication failed with status %d:\n", statusCode)
-		utils.PrintJSON(respBody)
-		os.Exit(1)
+	if err := utils.PrintJSONStream(respBody); err != nil {
+		utils.PrintErrorAndExit("API request failed for /publishers/publication", err)
 	}
 }
@@ -0,0 +1,133 @@
+// Code generated by tools/helpcodegen/main.go; DO NOT EDIT.
+package publishers
+
+import "github.com/TheDucker1/mangaupdatescli/internal/utils"
+
+var helpRetrievePublicationSeriesContent = utils.HelpContent{
+	Usage:       "mangaupdatescli publishers retrievePublicationSeries --pubname <string>",
+	Description: "List the series of a publication",
+	Arguments: []utils.ArgHelp{
+		{
+			Name:        "pubname",
+			Type:        "string",
+			Required:    true,
+			Description: "Publication name.",
+			In:          "query",
+		},
+	},
+	InputJSON:     "Path/Query Parameters",
+	OutputJSON:    utils.SchemaHelp{Label: "Schema (on 200)", Schema: &utils.Schema{Ref: "PublisherSeriesListResponseV1"}},
+	ErrorExamples: map[string]string{"404": "Publication not found"},
+	AuthRequired:  false,
+}
+
+var helpRetrievePublisherContent = utils.HelpContent{
+	Usage:       "mangaupdatescli publishers retrievePublisher --id <integer(int64)> [--unrenderedFields <boolean>]",
+	Description: "Retrieve a publisher",
+	Arguments: []utils.ArgHelp{
+		{
+			Name:        "id",
+			Type:        "integer(int64)",
+			Required:    true,
+			Description: "",
+			In:          "path",
+		},
+		{
+			Name:        "unrenderedFields",
+			Type:        "boolean",
+			Required:    false,
+			Description: "Output fields in unrendered form.",
+			Default:     "false",
+			In:          "query",
+		},
+	},
+	InputJSON:     "Path/Query Parameters",
+	OutputJSON:    utils.SchemaHelp{Label: "Schema (on 200)", Schema: &utils.Schema{Ref: "PublisherModelV1"}},
+	ErrorExamples: map[string]string{"404": "Publisher not found"},
+	AuthRequired:  false,
+}
+
+var helpRetrievePublisherSeriesContent = utils.HelpContent{
+	Usage:       "mangaupdatescli publishers retrievePublisherSeries --id <integer(int64)>",
+	Description: "List the series of a publisher",
+	Arguments: []utils.ArgHelp{
+		{
+			Name:        "id",
+			Type:        "integer(int64)",
+			Required:    true,
+			Description: "",
+			In:          "path",
+		},
+	},
+	InputJSON:     "Path/Query Parameters",
+	OutputJSON:    utils.SchemaHelp{Label: "Schema (on 200)", Schema: &utils.Schema{Ref: "PublisherSeriesListResponseV1"}},
+	ErrorExamples: map[string]string{"404": "Publisher not found"},
+	AuthRequired:  false,
+}
+
+var helpSearchPublishersPostContent = utils.HelpContent{
+	Usage:       "mangaupdatescli publishers searchPublishersPost [--search <string>] [--added_by <integer(int64)>] [--page <integer>] [--perpage <integer>] [--letter <string>] [--orderby <string>] [--pending <boolean>] [--body <JSON|YAML|@file|->]",
+	Description: "Search publishers",
+	Arguments: []utils.ArgHelp{
+		{
+			Name:        "search",
+			Type:        "string",
+			Required:    false,
+			Description: "Search term for publishers.",
+			In:          "body",
+		},
+		{
+			Name:        "added_by",
+			Type:        "integer(int64)",
+			Required:    false,
+			Description: "Filter by user ID who added the publisher.",
+			In:          "body",
+		},
+		{
+			Name:        "page",
+			Type:        "integer",
+			Required:    false,
+			Description: "Page number.",
+			In:          "body",
+		},
+		{
+			Name:        "perpage",
+			Type:        "integer",
+			Required:    false,
+			Description: "Results per page.",
+			In:          "body",
+		},
+		{
+			Name:        "letter",
+			Type:        "string",
+			Required:    false,
+			Description: "Filter by starting letter.",
+			In:          "body",
+		},
+		{
+			Name:        "orderby",
+			Type:        "string",
+			Required:    false,
+			Description: "Order by (score, name, series, etc.).",
+			In:          "body",
+			Enum:        []string{"score", "name", "series", "publications", "type"},
+		},
+		{
+			Name:        "pending",
+			Type:        "boolean",
+			Required:    false,
+			Description: "Filter by pending status.",
+			In:          "body",
+		},
+		{
+			Name:        "body",
+			Type:        "JSON|YAML",
+			Required:    false,
+			Description: "Request body as a JSON or YAML document, @file to read it from a file, or - for stdin. The flags of its fields override it.",
+		},
+	},
+	InputJSON:     utils.SchemaHelp{Label: "Request Body Schema", Schema: &utils.Schema{Ref: "PublishersSearchRequestV1"}},
+	OutputJSON:    utils.SchemaHelp{Label: "Schema (on 200)", Schema: &utils.Schema{Ref: "PublishersSearchResponseV1"}},
+	ErrorExamples: map[string]string{"400": "Validation or Service Error"},
+	AuthRequired:  false,
+}
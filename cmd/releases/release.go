@@ -2,20 +2,20 @@
 package releases
 
 import (
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
-	"mangaupdatescli/internal/apiclient"
-	"mangaupdatescli/internal/utils"
-	"net/http"
+	"github.com/TheDucker1/mangaupdatescli/internal/utils"
+	"github.com/TheDucker1/mangaupdatescli/mangaupdates"
+	"io"
 	"os"
 	"sort"
-	"strconv"
 	"strings"
 )
 
 // CommandHandler defines the function signature for command handlers
-type CommandHandler func(args []string)
+type CommandHandler func(ctx context.Context, args []string)
 
 // CommandInfo stores the handler and its associated (generated) help content
 type CommandInfo struct {
@@ -37,7 +37,7 @@ func init() {
 
 	releasesCommands["retrieveRelease"] = CommandInfo{
 		Handler: handleRetrieveRelease,
-		Help:    helpRetrieveReleaseContent, // From generated file
+		Help:    utils.BulkIDHelp(helpRetrieveReleaseContent), // From generated file
 	}
 	releasesCommands["listReleasesByDay"] = CommandInfo{
 		Handler: handleListReleasesByDay,
@@ -54,7 +54,7 @@ func init() {
 }
 
 // HandleCommand dispatches to the correct releases command handler
-func HandleCommand(command string, args []string) {
+func HandleCommand(ctx context.Context, command string, args []string) {
 	cmdInfo, ok := releasesCommands[command]
 	if !ok {
 		isJsonHelp, _, _ := utils.CheckHelpFlags(args)
@@ -62,7 +62,7 @@ func HandleCommand(command string, args []string) {
 		PrintReleasesSubprogramHelp(isJsonHelp)
 		os.Exit(1)
 	}
-	cmdInfo.Handler(args)
+	cmdInfo.Handler(ctx, args)
 }
 
 // PrintReleasesSubprogramHelp prints help for the entire 'releases' subprogram
@@ -115,9 +115,9 @@ func PrintReleasesSubprogramHelp(jsonFormat bool) {
 // --- Handler Functions ---
 
 // handleRetrieveRelease (GET /releases/{id})
-func handleRetrieveRelease(args []string) {
+func handleRetrieveRelease(ctx context.Context, args []string) {
 	fs := flag.NewFlagSet("retrieveRelease", flag.ContinueOnError)
-	releaseID := fs.Int64("id", 0, "Release ID (required).")
+	releaseIDs := utils.AddBulkIDFlags(fs, "Release")
 	unrenderedFields := fs.Bool("unrenderedFields", false, "Output fields in unrendered form.")
 
 	isJsonHelp, isTextHelp, remainingArgs := utils.CheckHelpFlags(args)
@@ -126,49 +126,54 @@ func handleRetrieveRelease(args []string) {
 	}
 
 	if isJsonHelp {
-		utils.PrintJSONHelp(helpRetrieveReleaseContent)
+		utils.PrintJSONHelp(utils.BulkIDHelp(helpRetrieveReleaseContent))
 		return
 	}
 	if isTextHelp {
-		utils.PrintFormattedHelp(helpRetrieveReleaseContent)
+		utils.PrintFormattedHelp(utils.BulkIDHelp(helpRetrieveReleaseContent))
 		return
 	}
 
-	if *releaseID == 0 {
+	ids, err := releaseIDs.IDs(os.Stdin)
+	if err != nil {
+		utils.PrintErrorAndExit("Invalid arguments for 'retrieveRelease'", err)
+	}
+	if len(ids) == 0 {
 		fmt.Fprintln(os.Stderr, "Error: --id is required for retrieveRelease.")
-		utils.PrintFormattedHelp(helpRetrieveReleaseContent)
+		utils.PrintFormattedHelp(utils.BulkIDHelp(helpRetrieveReleaseContent))
 		os.Exit(1)
 	}
 
-	path := fmt.Sprintf("/releases/%d", *releaseID)
-	queryParams := make(map[string]string)
-	if *unrenderedFields {
-		queryParams["unrenderedFields"] = "true"
-	}
+	opts := &mangaupdates.RetrieveOptions{UnrenderedFields: *unrenderedFields}
 
-	fullURL, err := apiclient.BuildURL(path, queryParams)
-	if err != nil {
-		utils.PrintErrorAndExit("Failed to build URL for /releases/{id}", err)
+	if releaseIDs.IsBatch() {
+		err := utils.RunBulk(ctx, ids, releaseIDs.Parallel, func(ctx context.Context, id int64) ([]byte, error) {
+			body, err := mangaupdates.Default().RetrieveReleaseRaw(ctx, id, opts)
+			if err != nil {
+				return nil, err
+			}
+			defer body.Close()
+			return io.ReadAll(body)
+		})
+		if err != nil {
+			utils.PrintErrorAndExit("Bulk retrieval failed for /releases/{id}", err)
+		}
+		return
 	}
 
-	respBody, statusCode, err := apiclient.DoRequest("GET", fullURL, nil)
+	respBody, err := mangaupdates.Default().RetrieveReleaseRaw(ctx, ids[0], opts)
 	if err != nil {
 		utils.PrintErrorAndExit("API request failed for /releases/{id}", err)
 	}
+	defer respBody.Close()
 
-	// Note: The spec mentions 401 for this endpoint. If it strictly requires auth even for GET,
-	// this unauthenticated call will fail with 401. We handle common cases.
-	if statusCode == http.StatusOK || statusCode == http.StatusNotFound {
-		utils.PrintJSON(respBody)
-	} else {
-		fmt.Fprintf(os.Stderr, "API request for /releases/{id} failed with status %d:\n", statusCode)
-		utils.PrintJSON(respBody)
-		os.Exit(1)
+	if err := utils.PrintJSONStream(respBody); err != nil {
+		utils.PrintErrorAndExit("API request failed for /releases/{id}", err)
 	}
 }
 
 // handleListReleasesByDay (GET /releases/days)
-func handleListReleasesByDay(args []string) {
+func handleListReleasesByDay(ctx context.Context, args []string) {
 	fs := flag.NewFlagSet("listReleasesByDay", flag.ContinueOnError)
 	page := fs.Int64("page", 0, "Start page (optional).") // Default will be API's default
 	includeMetadataStr := fs.String("include_metadata", "", "Include series metadata (true/false, default: false).")
@@ -187,39 +192,26 @@ func handleListReleasesByDay(args []string) {
 		return
 	}
 
-	queryParams := make(map[string]string)
-	if *page > 0 { // Only add if specified, to let API use its default
-		queryParams["page"] = strconv.FormatInt(*page, 10)
-	}
-	if *includeMetadataStr != "" { // Default is false, so only add if user specifies 'true'
-		if strings.ToLower(*includeMetadataStr) == "true" {
-			queryParams["include_metadata"] = "true"
-		} else if strings.ToLower(*includeMetadataStr) == "false" {
-			queryParams["include_metadata"] = "false" // Explicitly false if user wants
-		}
-	}
-
-	fullURL, err := apiclient.BuildURL("/releases/days", queryParams)
-	if err != nil {
-		utils.PrintErrorAndExit("Failed to build URL for /releases/days", err)
+	opts := mangaupdates.ListReleasesByDayOptions{Page: *page} // 0 lets the API use its default
+	switch strings.ToLower(*includeMetadataStr) {              // anything else leaves the API default (false)
+	case "true", "false":
+		val := strings.ToLower(*includeMetadataStr) == "true"
+		opts.IncludeMetadata = &val
 	}
 
-	respBody, statusCode, err := apiclient.DoRequest("GET", fullURL, nil)
+	respBody, err := mangaupdates.Default().ListReleasesByDayRaw(ctx, &opts)
 	if err != nil {
 		utils.PrintErrorAndExit("API request failed for /releases/days", err)
 	}
+	defer respBody.Close()
 
-	if statusCode == http.StatusOK {
-		utils.PrintJSON(respBody)
-	} else {
-		fmt.Fprintf(os.Stderr, "API request for /releases/days failed with status %d:\n", statusCode)
-		utils.PrintJSON(respBody)
-		os.Exit(1)
+	if err := utils.PrintJSONStream(respBody); err != nil {
+		utils.PrintErrorAndExit("API request failed for /releases/days", err)
 	}
 }
 
 // handleReleaseRssFeed (GET /releases/rss)
-func handleReleaseRssFeed(args []string) {
+func handleReleaseRssFeed(ctx context.Context, args []string) {
 	fs := flag.NewFlagSet("releaseRssFeed", flag.ContinueOnError)
 	// No arguments for this endpoint
 
@@ -237,62 +229,26 @@ func handleReleaseRssFeed(args []string) {
 		return
 	}
 
-	fullURL, err := apiclient.BuildURL("/releases/rss", nil)
-	if err != nil {
-		utils.PrintErrorAndExit("Failed to build URL for /releases/rss", err)
-	}
-
-	respBody, statusCode, err := apiclient.DoRequest("GET", fullURL, nil)
+	respBody, err := mangaupdates.Default().ReleaseRSSFeedRaw(ctx)
 	if err != nil {
 		utils.PrintErrorAndExit("API request failed for /releases/rss", err)
 	}
+	defer respBody.Close()
 
-	if statusCode == http.StatusOK {
-		// This endpoint returns XML, so print it directly
-		fmt.Println(string(respBody))
-	} else {
-		// If it's an error, it might be JSON from ApiResponseV1
-		fmt.Fprintf(os.Stderr, "API request for /releases/rss failed with status %d:\n", statusCode)
-		utils.PrintJSON(respBody) // Attempt to print as JSON if error
-		os.Exit(1)
+	// This endpoint returns XML, which PrintJSONStream copies unchanged
+	if err := utils.PrintJSONStream(respBody); err != nil {
+		utils.PrintErrorAndExit("API request failed for /releases/rss", err)
 	}
 }
 
-// Define ReleaseSearchRequestV1 (from OpenAPI spec, simplified)
-type ReleaseSearchRequestV1 struct {
-	Search          string `json:"search,omitempty"`
-	SearchType      string `json:"search_type,omitempty"` // enum: series, regular
-	AddedBy         int64  `json:"added_by,omitempty"`
-	Page            int    `json:"page,omitempty"`
-	Perpage         int    `json:"perpage,omitempty"`
-	Letter          string `json:"letter,omitempty"`
-	Orderby         string `json:"orderby,omitempty"`    // enum: date, time, title, vol, chap
-	StartDate       string `json:"start_date,omitempty"` // format: date
-	EndDate         string `json:"end_date,omitempty"`   // format: date
-	Asc             string `json:"asc,omitempty"`        // enum: asc, desc
-	GroupID         int64  `json:"group_id,omitempty"`
-	Pending         *bool  `json:"pending,omitempty"`
-	IncludeMetadata *bool  `json:"include_metadata,omitempty"`
-}
-
 // handleSearchReleasesPost (POST /releases/search)
-func handleSearchReleasesPost(args []string) {
+func handleSearchReleasesPost(ctx context.Context, args []string) {
 	fs := flag.NewFlagSet("searchReleasesPost", flag.ContinueOnError)
-	var reqBody ReleaseSearchRequestV1
-
-	fs.StringVar(&reqBody.Search, "search", "", "Search term.")
-	fs.StringVar(&reqBody.SearchType, "search_type", "", "Search type (series, regular).")
-	addedBy := fs.Int64("added_by", 0, "Filter by user ID who added the release.")
-	fs.IntVar(&reqBody.Page, "page", 0, "Page number.")
-	fs.IntVar(&reqBody.Perpage, "perpage", 0, "Results per page.")
-	fs.StringVar(&reqBody.Letter, "letter", "", "Filter by starting letter.")
-	fs.StringVar(&reqBody.Orderby, "orderby", "date", "Order by (default: date).") // Defaulting here as per common use
-	fs.StringVar(&reqBody.StartDate, "start_date", "", "Start date (YYYY-MM-DD).")
-	fs.StringVar(&reqBody.EndDate, "end_date", "", "End date (YYYY-MM-DD).")
-	fs.StringVar(&reqBody.Asc, "asc", "desc", "Sort direction (asc, desc; default: desc).") // Defaulting
-	groupID := fs.Int64("group_id", 0, "Filter by group ID.")
-	pendingStr := fs.String("pending", "", "Include pending releases (true/false).")
-	includeMetadataStr := fs.String("include_metadata", "", "Include series metadata (true/false).")
+	var reqBody mangaupdates.ReleaseSearchRequestV1
+	reqBody.Orderby = "date" // Defaulting here as per common use
+	reqBody.Asc = "desc"     // Defaulting
+	// --search, --orderby, ... from the request schema
+	bodyFlags := utils.AddBodyFlags(fs, helpSearchReleasesPostContent)
 
 	isJsonHelp, isTextHelp, remainingArgs := utils.CheckHelpFlags(args)
 	if err := fs.Parse(remainingArgs); err != nil {
@@ -308,36 +264,17 @@ func handleSearchReleasesPost(args []string) {
 		return
 	}
 
-	if *addedBy != 0 {
-		reqBody.AddedBy = *addedBy
-	}
-	if *groupID != 0 {
-		reqBody.GroupID = *groupID
-	}
-	if *pendingStr != "" {
-		val := strings.ToLower(*pendingStr) == "true"
-		reqBody.Pending = &val
-	}
-	if *includeMetadataStr != "" {
-		val := strings.ToLower(*includeMetadataStr) == "true"
-		reqBody.IncludeMetadata = &val
-	}
-
-	fullURL, err := apiclient.BuildURL("/releases/search", nil)
-	if err != nil {
-		utils.PrintErrorAndExit("Failed to build URL for /releases/search", err)
+	if err := bodyFlags.Decode(&reqBody); err != nil {
+		utils.PrintErrorAndExit("Invalid flags for 'searchReleasesPost'", err)
 	}
 
-	respBody, statusCode, err := apiclient.DoRequest("POST", fullURL, reqBody)
+	respBody, err := mangaupdates.Default().SearchReleasesRaw(ctx, &reqBody)
 	if err != nil {
 		utils.PrintErrorAndExit("API request failed for /releases/search", err)
 	}
+	defer respBody.Close()
 
-	if statusCode == http.StatusOK {
-		utils.PrintJSON(respBody)
-	} else {
-		fmt.Fprintf(os.Stderr, "API request for /releases/search failed with status %d:\n", statusCode)
-		utils.PrintJSON(respBody)
-		os.Exit(1)
+	if err := utils.PrintJSONStream(respBody); err != nil {
+		utils.PrintErrorAndExit("API request failed for /releases/search", err)
 	}
 }
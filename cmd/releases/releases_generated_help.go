@@ -0,0 +1,177 @@
+// Code generated by tools/helpcodegen/main.go; DO NOT EDIT.
+package releases
+
+import "github.com/TheDucker1/mangaupdatescli/internal/utils"
+
+var helpListReleasesByDayContent = utils.HelpContent{
+	Usage:       "mangaupdatescli releases listReleasesByDay [--page <integer>] [--include-metadata <boolean>]",
+	Description: "List releases by day",
+	Arguments: []utils.ArgHelp{
+		{
+			Name:        "page",
+			Type:        "integer",
+			Required:    false,
+			Description: "Start page.",
+			In:          "query",
+		},
+		{
+			Name:        "include_metadata",
+			Type:        "boolean",
+			Required:    false,
+			Description: "Include series metadata.",
+			Default:     "false",
+			In:          "query",
+		},
+	},
+	InputJSON:     "Path/Query Parameters",
+	OutputJSON:    utils.SchemaHelp{Label: "Schema (on 200)", Schema: &utils.Schema{Ref: "ReleaseSearchResponseV1"}},
+	ErrorExamples: map[string]string{"Generic": "Standard API errors."},
+	AuthRequired:  false,
+}
+
+var helpReleaseRssFeedContent = utils.HelpContent{
+	Usage:         "mangaupdatescli releases releaseRssFeed",
+	Description:   "RSS feed of the latest releases",
+	Arguments:     nil,
+	InputJSON:     "None",
+	OutputJSON:    "Output (on 200): application/rss+xml",
+	ErrorExamples: map[string]string{"Generic": "Standard API errors."},
+	AuthRequired:  false,
+}
+
+var helpRetrieveReleaseContent = utils.HelpContent{
+	Usage:       "mangaupdatescli releases retrieveRelease --id <integer(int64)> [--unrenderedFields <boolean>]",
+	Description: "Retrieve a release",
+	Arguments: []utils.ArgHelp{
+		{
+			Name:        "id",
+			Type:        "integer(int64)",
+			Required:    true,
+			Description: "",
+			In:          "path",
+		},
+		{
+			Name:        "unrenderedFields",
+			Type:        "boolean",
+			Required:    false,
+			Description: "Output fields in unrendered form.",
+			Default:     "false",
+			In:          "query",
+		},
+	},
+	InputJSON:     "Path/Query Parameters",
+	OutputJSON:    utils.SchemaHelp{Label: "Schema (on 200)", Schema: &utils.Schema{Ref: "ReleaseModelV1"}},
+	ErrorExamples: map[string]string{"404": "Release not found"},
+	AuthRequired:  false,
+}
+
+var helpSearchReleasesPostContent = utils.HelpContent{
+	Usage:       "mangaupdatescli releases searchReleasesPost [--search <string>] [--search_type <string>] [--added_by <integer(int64)>] [--page <integer>] [--perpage <integer>] [--letter <string>] [--orderby <string>] [--start_date <string>] [--end_date <string>] [--asc <string>] [--group_id <integer(int64)>] [--pending <boolean>] [--include_metadata <boolean>] [--body <JSON|YAML|@file|->]",
+	Description: "Search releases",
+	Arguments: []utils.ArgHelp{
+		{
+			Name:        "search",
+			Type:        "string",
+			Required:    false,
+			Description: "Search term.",
+			In:          "body",
+		},
+		{
+			Name:        "search_type",
+			Type:        "string",
+			Required:    false,
+			Description: "Search type (series, regular).",
+			In:          "body",
+			Enum:        []string{"series", "releases"},
+		},
+		{
+			Name:        "added_by",
+			Type:        "integer(int64)",
+			Required:    false,
+			Description: "Filter by user ID who added the release.",
+			In:          "body",
+		},
+		{
+			Name:        "page",
+			Type:        "integer",
+			Required:    false,
+			Description: "Page number.",
+			In:          "body",
+		},
+		{
+			Name:        "perpage",
+			Type:        "integer",
+			Required:    false,
+			Description: "Results per page.",
+			In:          "body",
+		},
+		{
+			Name:        "letter",
+			Type:        "string",
+			Required:    false,
+			Description: "Filter by starting letter.",
+			In:          "body",
+		},
+		{
+			Name:        "orderby",
+			Type:        "string",
+			Required:    false,
+			Description: "Order by (default: date).",
+			In:          "body",
+			Enum:        []string{"date", "time", "title", "vol", "chap"},
+		},
+		{
+			Name:        "start_date",
+			Type:        "string",
+			Required:    false,
+			Description: "Start date (YYYY-MM-DD).",
+			In:          "body",
+		},
+		{
+			Name:        "end_date",
+			Type:        "string",
+			Required:    false,
+			Description: "End date (YYYY-MM-DD).",
+			In:          "body",
+		},
+		{
+			Name:        "asc",
+			Type:        "string",
+			Required:    false,
+			Description: "Sort direction (asc, desc; default: desc).",
+			In:          "body",
+			Enum:        []string{"asc", "desc"},
+		},
+		{
+			Name:        "group_id",
+			Type:        "integer(int64)",
+			Required:    false,
+			Description: "Filter by group ID.",
+			In:          "body",
+		},
+		{
+			Name:        "pending",
+			Type:        "boolean",
+			Required:    false,
+			Description: "Include pending releases.",
+			In:          "body",
+		},
+		{
+			Name:        "include_metadata",
+			Type:        "boolean",
+			Required:    false,
+			Description: "Include series metadata.",
+			In:          "body",
+		},
+		{
+			Name:        "body",
+			Type:        "JSON|YAML",
+			Required:    false,
+			Description: "Request body as a JSON or YAML document, @file to read it from a file, or - for stdin. The flags of its fields override it.",
+		},
+	},
+	InputJSON:     utils.SchemaHelp{Label: "Request Body Schema", Schema: &utils.Schema{Ref: "ReleaseSearchRequestV1"}},
+	OutputJSON:    utils.SchemaHelp{Label: "Schema (on 200)", Schema: &utils.Schema{Ref: "ReleaseSearchResponseV1"}},
+	ErrorExamples: map[string]string{"400": "Validation or Service Error"},
+	AuthRequired:  false,
+}
@@ -2,19 +2,19 @@
 package series
 
 import (
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
-	"mangaupdatescli/internal/apiclient"
-	"mangaupdatescli/internal/utils"
-	"net/http"
+	"github.com/TheDucker1/mangaupdatescli/internal/utils"
+	"github.com/TheDucker1/mangaupdatescli/mangaupdates"
+	"io"
 	"os"
 	"sort"
-	"strings"
 )
 
 // CommandHandler defines the function signature for command handlers
-type CommandHandler func(args []string)
+type CommandHandler func(ctx context.Context, args []string)
 
 // CommandInfo stores the handler and its associated (generated) help content
 type CommandInfo struct {
@@ -46,7 +46,7 @@ func init() {
 }
 
 // HandleCommand dispatches to the correct series command handler
-func HandleCommand(command string, args []string) {
+func HandleCommand(ctx context.Context, command string, args []string) {
 	cmdInfo, ok := seriesCommands[command]
 	if !ok {
 		isJsonHelp, _, _ := utils.CheckHelpFlags(args)
@@ -54,7 +54,7 @@ func HandleCommand(command string, args []string) {
 		PrintSeriesSubprogramHelp(isJsonHelp)
 		os.Exit(1)
 	}
-	cmdInfo.Handler(args)
+	cmdInfo.Handler(ctx, args)
 }
 
 // PrintSeriesSubprogramHelp prints help for the entire 'series' subprogram
@@ -107,9 +107,9 @@ func PrintSeriesSubprogramHelp(jsonFormat bool) {
 // --- Handler Functions (Implementations for each command) ---
 
 // handleRetrieveSeries (GET /series/{id})
-func handleRetrieveSeries(args []string) {
+func handleRetrieveSeries(ctx context.Context, args []string) {
 	fs := flag.NewFlagSet("retrieveSeries", flag.ContinueOnError)
-	seriesID := fs.Int64("id", 0, "Series ID (required).")
+	seriesIDs := utils.AddBulkIDFlags(fs, "Series")
 	unrenderedFields := fs.Bool("unrenderedFields", false, "Output fields in unrendered form.")
 
 	isJsonHelp, isTextHelp, remainingArgs := utils.CheckHelpFlags(args)
@@ -118,94 +118,59 @@ func handleRetrieveSeries(args []string) {
 	}
 
 	if isJsonHelp {
-		utils.PrintJSONHelp(helpRetrieveSeriesContent)
+		utils.PrintJSONHelp(utils.BulkIDHelp(helpRetrieveSeriesContent))
 		return
 	}
 	if isTextHelp {
-		utils.PrintFormattedHelp(helpRetrieveSeriesContent)
+		utils.PrintFormattedHelp(utils.BulkIDHelp(helpRetrieveSeriesContent))
 		return
 	}
 
-	if *seriesID == 0 {
+	ids, err := seriesIDs.IDs(os.Stdin)
+	if err != nil {
+		utils.PrintErrorAndExit("Invalid arguments for 'retrieveSeries'", err)
+	}
+	if len(ids) == 0 {
 		fmt.Fprintln(os.Stderr, "Error: --id is required for retrieveSeries.")
-		utils.PrintFormattedHelp(helpRetrieveSeriesContent)
+		utils.PrintFormattedHelp(utils.BulkIDHelp(helpRetrieveSeriesContent))
 		os.Exit(1)
 	}
 
-	path := fmt.Sprintf("/series/%d", *seriesID)
-	queryParams := make(map[string]string)
-	if *unrenderedFields {
-		queryParams["unrenderedFields"] = "true"
-	}
+	opts := &mangaupdates.RetrieveOptions{UnrenderedFields: *unrenderedFields}
 
-	fullURL, err := apiclient.BuildURL(path, queryParams)
-	if err != nil {
-		utils.PrintErrorAndExit("Failed to build URL for /series/{id}", err)
+	if seriesIDs.IsBatch() {
+		err := utils.RunBulk(ctx, ids, seriesIDs.Parallel, func(ctx context.Context, id int64) ([]byte, error) {
+			body, err := mangaupdates.Default().RetrieveSeriesRaw(ctx, id, opts)
+			if err != nil {
+				return nil, err
+			}
+			defer body.Close()
+			return io.ReadAll(body)
+		})
+		if err != nil {
+			utils.PrintErrorAndExit("Bulk retrieval failed for /series/{id}", err)
+		}
+		return
 	}
 
-	respBody, statusCode, err := apiclient.DoRequest("GET", fullURL, nil)
+	respBody, err := mangaupdates.Default().RetrieveSeriesRaw(ctx, ids[0], opts)
 	if err != nil {
 		utils.PrintErrorAndExit("API request failed for /series/{id}", err)
 	}
+	defer respBody.Close()
 
-	if statusCode == http.StatusOK || statusCode == http.StatusNotFound {
-		utils.PrintJSON(respBody)
-	} else {
-		fmt.Fprintf(os.Stderr, "API request for /series/{id} failed with status %d:\n", statusCode)
-		utils.PrintJSON(respBody)
-		os.Exit(1)
+	if err := utils.PrintJSONStream(respBody); err != nil {
+		utils.PrintErrorAndExit("API request failed for /series/{id}", err)
 	}
 }
 
-// Define SeriesSearchRequestV1 (from OpenAPI spec, ensure it's complete for all desired flags)
-type SeriesSearchRequestV1 struct {
-	Search                string   `json:"search,omitempty"`
-	AddedBy               int64    `json:"added_by,omitempty"`
-	Stype                 string   `json:"stype,omitempty"`
-	Licensed              string   `json:"licensed,omitempty"`
-	Type                  []string `json:"type,omitempty"`
-	Year                  string   `json:"year,omitempty"`
-	FilterTypes           []string `json:"filter_types,omitempty"`
-	Category              []string `json:"category,omitempty"`
-	Pubname               string   `json:"pubname,omitempty"`
-	Filters               []string `json:"filters,omitempty"`
-	List                  string   `json:"list,omitempty"`
-	Page                  int      `json:"page,omitempty"`
-	Perpage               int      `json:"perpage,omitempty"`
-	Letter                string   `json:"letter,omitempty"`
-	Genre                 []string `json:"genre,omitempty"`
-	ExcludeGenre          []string `json:"exclude_genre,omitempty"`
-	Orderby               string   `json:"orderby,omitempty"`
-	Pending               *bool    `json:"pending,omitempty"`
-	IncludeRankMetadata   *bool    `json:"include_rank_metadata,omitempty"`
-	ExcludeFilteredGenres *bool    `json:"exclude_filtered_genres,omitempty"`
-}
-
 // handleSearchSeriesPost (POST /series/search) - (Already scaffolded, ensure flags are comprehensive)
-func handleSearchSeriesPost(args []string) {
+func handleSearchSeriesPost(ctx context.Context, args []string) {
 	fs := flag.NewFlagSet("searchSeriesPost", flag.ContinueOnError)
-	var reqBody SeriesSearchRequestV1
-
-	fs.StringVar(&reqBody.Search, "search", "", "Search term.")
-	addedBy := fs.Int64("added_by", 0, "Filter by user ID who added the series.")
-	fs.StringVar(&reqBody.Stype, "stype", "", "Search type (title, description).")
-	fs.StringVar(&reqBody.Licensed, "licensed", "", "Filter by licensed status (yes, no).")
-	typeStr := fs.String("type", "", "Comma-separated list of series types (Manga,Manhwa).")
-	fs.StringVar(&reqBody.Year, "year", "", "Filter by year.")
-	filterTypesStr := fs.String("filter_types", "", "Comma-separated list of types to filter out.")
-	categoryStr := fs.String("category", "", "Comma-separated list of categories.")
-	fs.StringVar(&reqBody.Pubname, "pubname", "", "Filter by publication name.")
-	filtersStr := fs.String("filters", "", "Comma-separated list of filters (scanlated,completed).")
-	fs.StringVar(&reqBody.List, "list", "", "Filter by user list type.")
-	fs.IntVar(&reqBody.Page, "page", 0, "Page number.")
-	fs.IntVar(&reqBody.Perpage, "perpage", 0, "Results per page.")
-	fs.StringVar(&reqBody.Letter, "letter", "", "Filter by starting letter.")
-	genreStr := fs.String("genre", "", "Comma-separated list of genres.")
-	excludeGenreStr := fs.String("exclude_genre", "", "Comma-separated list of genres to exclude.")
-	fs.StringVar(&reqBody.Orderby, "orderby", "", "Order by (score, title, rank, etc.).")
-	pendingStr := fs.String("pending", "", "Include pending series (true/false).")
-	includeRankStr := fs.String("include_rank_metadata", "", "Include rank metadata (true/false).")
-	excludeFilteredGenresStr := fs.String("exclude_filtered_genres", "", "Exclude filtered genres (true/false).")
+	var reqBody mangaupdates.SeriesSearchRequestV1
+	// --search, --type, --orderby, ...: one flag per field of the request
+	// schema; array fields such as --genre take repeated flags or comma lists.
+	bodyFlags := utils.AddBodyFlags(fs, helpSearchSeriesPostContent)
 
 	isJsonHelp, isTextHelp, remainingArgs := utils.CheckHelpFlags(args)
 	if err := fs.Parse(remainingArgs); err != nil {
@@ -220,59 +185,22 @@ func handleSearchSeriesPost(args []string) {
 		utils.PrintFormattedHelp(helpSearchSeriesPostContent)
 		return
 	}
-	if *addedBy != 0 {
-		reqBody.AddedBy = *addedBy
-	}
-	if *typeStr != "" {
-		reqBody.Type = strings.Split(*typeStr, ",")
-	}
-	if *filterTypesStr != "" {
-		reqBody.FilterTypes = strings.Split(*filterTypesStr, ",")
-	}
-	if *categoryStr != "" {
-		reqBody.Category = strings.Split(*categoryStr, ",")
-	}
-	if *filtersStr != "" {
-		reqBody.Filters = strings.Split(*filtersStr, ",")
-	}
-	if *genreStr != "" {
-		reqBody.Genre = strings.Split(*genreStr, ",")
-	}
-	if *excludeGenreStr != "" {
-		reqBody.ExcludeGenre = strings.Split(*excludeGenreStr, ",")
-	}
-	if *pendingStr != "" {
-		val := strings.ToLower(*pendingStr) == "true"
-		reqBody.Pending = &val
-	}
-	if *includeRankStr != "" {
-		val := strings.ToLower(*includeRankStr) == "true"
-		reqBody.IncludeRankMetadata = &val
-	}
-	if *excludeFilteredGenresStr != "" {
-		val := strings.ToLower(*excludeFilteredGenresStr) == "true"
-		reqBody.ExcludeFilteredGenres = &val
+	if err := bodyFlags.Decode(&reqBody); err != nil {
+		utils.PrintErrorAndExit("Invalid flags for 'searchSeriesPost'", err)
 	}
 
-	fullURL, err := apiclient.BuildURL("/series/search", nil)
-	if err != nil {
-		utils.PrintErrorAndExit("Failed to build URL for /series/search", err)
-	}
-	respBody, statusCode, err := apiclient.DoRequest("POST", fullURL, reqBody)
+	respBody, err := mangaupdates.Default().SearchSeriesRaw(ctx, &reqBody)
 	if err != nil {
 		utils.PrintErrorAndExit("API request failed for /series/search", err)
 	}
-	if statusCode == http.StatusOK {
-		utils.PrintJSON(respBody)
-	} else {
-		fmt.Fprintf(os.Stderr, "API request for /series/search failed with status %d:\n", statusCode)
-		utils.PrintJSON(respBody)
-		os.Exit(1)
+	defer respBody.Close()
+	if err := utils.PrintJSONStream(respBody); err != nil {
+		utils.PrintErrorAndExit("API request failed for /series/search", err)
 	}
 }
 
 // handleRetrieveSeriesCategoryVotes (GET /series/{id}/categories/votes)
-func handleRetrieveSeriesCategoryVotes(args []string) {
+func handleRetrieveSeriesCategoryVotes(ctx context.Context, args []string) {
 	fs := flag.NewFlagSet("retrieveSeriesCategoryVotes", flag.ContinueOnError)
 	seriesID := fs.Int64("id", 0, "Series ID (required).")
 
@@ -294,26 +222,19 @@ func handleRetrieveSeriesCategoryVotes(args []string) {
 		os.Exit(1)
 	}
 
-	path := fmt.Sprintf("/series/%d/categories/votes", *seriesID)
-	fullURL, _ := apiclient.BuildURL(path, nil)
-	respBody, statusCode, err := apiclient.DoRequest("GET", fullURL, nil)
+	respBody, err := mangaupdates.Default().RetrieveSeriesCategoryVotesRaw(ctx, *seriesID)
 
 	if err != nil {
 		utils.PrintErrorAndExit("API request failed", err)
 	}
-	// This endpoint likely requires auth to show user-specific votes.
-	// Without auth, it might return 200 with empty data, or 401/403.
-	if statusCode == http.StatusOK || statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden {
-		utils.PrintJSON(respBody)
-	} else {
-		fmt.Fprintf(os.Stderr, "API request failed with status %d:\n", statusCode)
-		utils.PrintJSON(respBody)
-		os.Exit(1)
+	defer respBody.Close()
+	if err := utils.PrintJSONStream(respBody); err != nil {
+		utils.PrintErrorAndExit("API request failed", err)
 	}
 }
 
 // handleRetrieveSeriesComment (GET /series/{id}/comments/{comment_id})
-func handleRetrieveSeriesComment(args []string) {
+func handleRetrieveSeriesComment(ctx context.Context, args []string) {
 	fs := flag.NewFlagSet("retrieveSeriesComment", flag.ContinueOnError)
 	seriesID := fs.Int64("id", 0, "Series ID (required).")
 	commentID := fs.Int64("comment_id", 0, "Comment ID (required).")
@@ -337,28 +258,19 @@ func handleRetrieveSeriesComment(args []string) {
 		os.Exit(1)
 	}
 
-	path := fmt.Sprintf("/series/%d/comments/%d", *seriesID, *commentID)
-	queryParams := make(map[string]string)
-	if *unrenderedFields {
-		queryParams["unrenderedFields"] = "true"
-	}
-	fullURL, _ := apiclient.BuildURL(path, queryParams)
-	respBody, statusCode, err := apiclient.DoRequest("GET", fullURL, nil)
+	respBody, err := mangaupdates.Default().RetrieveSeriesCommentRaw(ctx, *seriesID, *commentID, &mangaupdates.RetrieveOptions{UnrenderedFields: *unrenderedFields})
 
 	if err != nil {
 		utils.PrintErrorAndExit("API request failed", err)
 	}
-	if statusCode == http.StatusOK || statusCode == http.StatusNotFound {
-		utils.PrintJSON(respBody)
-	} else {
-		fmt.Fprintf(os.Stderr, "API request failed with status %d:\n", statusCode)
-		utils.PrintJSON(respBody)
-		os.Exit(1)
+	defer respBody.Close()
+	if err := utils.PrintJSONStream(respBody); err != nil {
+		utils.PrintErrorAndExit("API request failed", err)
 	}
 }
 
 // handleRetrieveMySeriesComment (GET /series/{id}/comments/my_comment)
-func handleRetrieveMySeriesComment(args []string) {
+func handleRetrieveMySeriesComment(ctx context.Context, args []string) {
 	fs := flag.NewFlagSet("retrieveMySeriesComment", flag.ContinueOnError)
 	seriesID := fs.Int64("id", 0, "Series ID (required).")
 	unrenderedFields := fs.Bool("unrenderedFields", false, "Output unrendered fields.")
@@ -381,29 +293,19 @@ func handleRetrieveMySeriesComment(args []string) {
 		os.Exit(1)
 	}
 
-	path := fmt.Sprintf("/series/%d/comments/my_comment", *seriesID)
-	queryParams := make(map[string]string)
-	if *unrenderedFields {
-		queryParams["unrenderedFields"] = "true"
-	}
-	fullURL, _ := apiclient.BuildURL(path, queryParams)
-	respBody, statusCode, err := apiclient.DoRequest("GET", fullURL, nil)
+	respBody, err := mangaupdates.Default().RetrieveMySeriesCommentRaw(ctx, *seriesID, &mangaupdates.RetrieveOptions{UnrenderedFields: *unrenderedFields})
 
 	if err != nil {
 		utils.PrintErrorAndExit("API request failed", err)
 	}
-	// Expects auth. Without it, likely 401/403 or 404 if "my_comment" isn't found due to no auth.
-	if statusCode == http.StatusOK || statusCode == http.StatusNotFound || statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden {
-		utils.PrintJSON(respBody)
-	} else {
-		fmt.Fprintf(os.Stderr, "API request failed with status %d:\n", statusCode)
-		utils.PrintJSON(respBody)
-		os.Exit(1)
+	defer respBody.Close()
+	if err := utils.PrintJSONStream(respBody); err != nil {
+		utils.PrintErrorAndExit("API request failed", err)
 	}
 }
 
 // handleRetrieveSeriesCommentLocation (GET /series/{id}/comments/{comment_id}/location)
-func handleRetrieveSeriesCommentLocation(args []string) {
+func handleRetrieveSeriesCommentLocation(ctx context.Context, args []string) {
 	fs := flag.NewFlagSet("retrieveSeriesCommentLocation", flag.ContinueOnError)
 	seriesID := fs.Int64("id", 0, "Series ID (required).")
 	commentID := fs.Int64("comment_id", 0, "Comment ID (required).")
@@ -426,40 +328,23 @@ func handleRetrieveSeriesCommentLocation(args []string) {
 		os.Exit(1)
 	}
 
-	path := fmt.Sprintf("/series/%d/comments/%d/location", *seriesID, *commentID)
-	fullURL, _ := apiclient.BuildURL(path, nil)
-	respBody, statusCode, err := apiclient.DoRequest("GET", fullURL, nil)
+	respBody, err := mangaupdates.Default().RetrieveSeriesCommentLocationRaw(ctx, *seriesID, *commentID)
 
 	if err != nil {
 		utils.PrintErrorAndExit("API request failed", err)
 	}
-	// Returns ApiResponseV1, so 200 is expected. 404 if not found.
-	if statusCode == http.StatusOK || statusCode == http.StatusNotFound {
-		utils.PrintJSON(respBody)
-	} else {
-		fmt.Fprintf(os.Stderr, "API request failed with status %d:\n", statusCode)
-		utils.PrintJSON(respBody)
-		os.Exit(1)
+	defer respBody.Close()
+	if err := utils.PrintJSONStream(respBody); err != nil {
+		utils.PrintErrorAndExit("API request failed", err)
 	}
 }
 
-// Define SeriesCommentSearchRequestV1
-type SeriesCommentSearchRequestV1 struct {
-	Method  string `json:"method,omitempty"` // useful, time_added
-	AddedBy int64  `json:"added_by,omitempty"`
-	Page    int    `json:"page,omitempty"`
-	Perpage int    `json:"perpage,omitempty"`
-}
-
 // handleSearchSeriesCommentsPost (POST /series/{id}/comments/search)
-func handleSearchSeriesCommentsPost(args []string) {
+func handleSearchSeriesCommentsPost(ctx context.Context, args []string) {
 	fs := flag.NewFlagSet("searchSeriesCommentsPost", flag.ContinueOnError)
 	seriesID := fs.Int64("id", 0, "Series ID (required).")
-	var reqBody SeriesCommentSearchRequestV1
-	fs.StringVar(&reqBody.Method, "method", "", "Search method (useful, time_added).")
-	addedBy := fs.Int64("added_by", 0, "Filter by author user ID.")
-	fs.IntVar(&reqBody.Page, "page", 0, "Page number.")
-	fs.IntVar(&reqBody.Perpage, "perpage", 0, "Results per page.")
+	var reqBody mangaupdates.SeriesCommentSearchRequestV1
+	bodyFlags := utils.AddBodyFlags(fs, helpSearchSeriesCommentsPostContent) // Request body fields
 
 	isJsonHelp, isTextHelp, remainingArgs := utils.CheckHelpFlags(args)
 	if err := fs.Parse(remainingArgs); err != nil {
@@ -478,28 +363,23 @@ func handleSearchSeriesCommentsPost(args []string) {
 		utils.PrintFormattedHelp(helpSearchSeriesCommentsPostContent)
 		os.Exit(1)
 	}
-	if *addedBy != 0 {
-		reqBody.AddedBy = *addedBy
+	if err := bodyFlags.Decode(&reqBody); err != nil {
+		utils.PrintErrorAndExit("Invalid flags for 'searchSeriesCommentsPost'", err)
 	}
 
-	path := fmt.Sprintf("/series/%d/comments/search", *seriesID)
-	fullURL, _ := apiclient.BuildURL(path, nil)
-	respBody, statusCode, err := apiclient.DoRequest("POST", fullURL, reqBody)
+	respBody, err := mangaupdates.Default().SearchSeriesCommentsRaw(ctx, *seriesID, &reqBody)
 
 	if err != nil {
 		utils.PrintErrorAndExit("API request failed", err)
 	}
-	if statusCode == http.StatusOK {
-		utils.PrintJSON(respBody)
-	} else {
-		fmt.Fprintf(os.Stderr, "API request failed with status %d:\n", statusCode)
-		utils.PrintJSON(respBody)
-		os.Exit(1)
+	defer respBody.Close()
+	if err := utils.PrintJSONStream(respBody); err != nil {
+		utils.PrintErrorAndExit("API request failed", err)
 	}
 }
 
 // handleRetrieveSeriesGroups (GET /series/{id}/groups)
-func handleRetrieveSeriesGroups(args []string) {
+func handleRetrieveSeriesGroups(ctx context.Context, args []string) {
 	fs := flag.NewFlagSet("retrieveSeriesGroups", flag.ContinueOnError)
 	seriesID := fs.Int64("id", 0, "Series ID (required).")
 
@@ -521,35 +401,23 @@ func handleRetrieveSeriesGroups(args []string) {
 		os.Exit(1)
 	}
 
-	path := fmt.Sprintf("/series/%d/groups", *seriesID)
-	fullURL, _ := apiclient.BuildURL(path, nil)
-	respBody, statusCode, err := apiclient.DoRequest("GET", fullURL, nil)
+	respBody, err := mangaupdates.Default().RetrieveSeriesGroupsRaw(ctx, *seriesID)
 
 	if err != nil {
 		utils.PrintErrorAndExit("API request failed", err)
 	}
-	if statusCode == http.StatusOK || statusCode == http.StatusNotFound {
-		utils.PrintJSON(respBody)
-	} else {
-		fmt.Fprintf(os.Stderr, "API request failed with status %d:\n", statusCode)
-		utils.PrintJSON(respBody)
-		os.Exit(1)
+	defer respBody.Close()
+	if err := utils.PrintJSONStream(respBody); err != nil {
+		utils.PrintErrorAndExit("API request failed", err)
 	}
 }
 
-// Define PerPageSearchRequestV1 (common schema used by history)
-type PerPageSearchRequestV1 struct {
-	Page    int `json:"page,omitempty"`
-	Perpage int `json:"perpage,omitempty"`
-}
-
 // handleSearchSeriesHistoryPost (POST /series/{id}/history)
-func handleSearchSeriesHistoryPost(args []string) {
+func handleSearchSeriesHistoryPost(ctx context.Context, args []string) {
 	fs := flag.NewFlagSet("searchSeriesHistoryPost", flag.ContinueOnError)
 	seriesID := fs.Int64("id", 0, "Series ID (required).")
-	var reqBody PerPageSearchRequestV1
-	fs.IntVar(&reqBody.Page, "page", 0, "Page number.")
-	fs.IntVar(&reqBody.Perpage, "perpage", 0, "Results per page.")
+	var reqBody mangaupdates.PerPageSearchRequestV1
+	bodyFlags := utils.AddBodyFlags(fs, helpSearchSeriesHistoryPostContent) // --page, --perpage
 
 	isJsonHelp, isTextHelp, remainingArgs := utils.CheckHelpFlags(args)
 	if err := fs.Parse(remainingArgs); err != nil {
@@ -568,25 +436,23 @@ func handleSearchSeriesHistoryPost(args []string) {
 		utils.PrintFormattedHelp(helpSearchSeriesHistoryPostContent)
 		os.Exit(1)
 	}
+	if err := bodyFlags.Decode(&reqBody); err != nil {
+		utils.PrintErrorAndExit("Invalid flags for 'searchSeriesHistoryPost'", err)
+	}
 
-	path := fmt.Sprintf("/series/%d/history", *seriesID)
-	fullURL, _ := apiclient.BuildURL(path, nil)
-	respBody, statusCode, err := apiclient.DoRequest("POST", fullURL, reqBody)
+	respBody, err := mangaupdates.Default().SearchSeriesHistoryRaw(ctx, *seriesID, &reqBody)
 
 	if err != nil {
 		utils.PrintErrorAndExit("API request failed", err)
 	}
-	if statusCode == http.StatusOK { // 400 for validation error
-		utils.PrintJSON(respBody)
-	} else {
-		fmt.Fprintf(os.Stderr, "API request failed with status %d:\n", statusCode)
-		utils.PrintJSON(respBody)
-		os.Exit(1)
+	defer respBody.Close()
+	if err := utils.PrintJSONStream(respBody); err != nil {
+		utils.PrintErrorAndExit("API request failed", err)
 	}
 }
 
 // handleRetrieveSeriesLocks (GET /series/{id}/locks)
-func handleRetrieveSeriesLocks(args []string) {
+func handleRetrieveSeriesLocks(ctx context.Context, args []string) {
 	fs := flag.NewFlagSet("retrieveSeriesLocks", flag.ContinueOnError)
 	seriesID := fs.Int64("id", 0, "Series ID (required).")
 
@@ -608,25 +474,19 @@ func handleRetrieveSeriesLocks(args []string) {
 		os.Exit(1)
 	}
 
-	path := fmt.Sprintf("/series/%d/locks", *seriesID)
-	fullURL, _ := apiclient.BuildURL(path, nil)
-	respBody, statusCode, err := apiclient.DoRequest("GET", fullURL, nil)
+	respBody, err := mangaupdates.Default().RetrieveSeriesLocksRaw(ctx, *seriesID)
 
 	if err != nil {
 		utils.PrintErrorAndExit("API request failed", err)
 	}
-	// This endpoint might require auth. Spec shows 200, 404.
-	if statusCode == http.StatusOK || statusCode == http.StatusNotFound {
-		utils.PrintJSON(respBody)
-	} else {
-		fmt.Fprintf(os.Stderr, "API request failed with status %d:\n", statusCode)
-		utils.PrintJSON(respBody)
-		os.Exit(1)
+	defer respBody.Close()
+	if err := utils.PrintJSONStream(respBody); err != nil {
+		utils.PrintErrorAndExit("API request failed", err)
 	}
 }
 
 // handleRetrieveSeriesRankLocation (GET /series/{id}/rank/{type})
-func handleRetrieveSeriesRankLocation(args []string) {
+func handleRetrieveSeriesRankLocation(ctx context.Context, args []string) {
 	fs := flag.NewFlagSet("retrieveSeriesRankLocation", flag.ContinueOnError)
 	seriesID := fs.Int64("id", 0, "Series ID (required).")
 	rankType := fs.String("type", "", "Stat type for rank (required).")
@@ -649,25 +509,19 @@ func handleRetrieveSeriesRankLocation(args []string) {
 		os.Exit(1)
 	}
 
-	path := fmt.Sprintf("/series/%d/rank/%s", *seriesID, *rankType)
-	fullURL, _ := apiclient.BuildURL(path, nil)
-	respBody, statusCode, err := apiclient.DoRequest("GET", fullURL, nil)
+	respBody, err := mangaupdates.Default().RetrieveSeriesRankLocationRaw(ctx, *seriesID, *rankType)
 
 	if err != nil {
 		utils.PrintErrorAndExit("API request failed", err)
 	}
-	// Returns ApiResponseV1, so 200 is expected. Might be 404 if series or type is invalid.
-	if statusCode == http.StatusOK || statusCode == http.StatusNotFound {
-		utils.PrintJSON(respBody)
-	} else {
-		fmt.Fprintf(os.Stderr, "API request failed with status %d:\n", statusCode)
-		utils.PrintJSON(respBody)
-		os.Exit(1)
+	defer respBody.Close()
+	if err := utils.PrintJSONStream(respBody); err != nil {
+		utils.PrintErrorAndExit("API request failed", err)
 	}
 }
 
 // handleRetrieveUserSeriesRating (GET /series/{id}/rating)
-func handleRetrieveUserSeriesRating(args []string) {
+func handleRetrieveUserSeriesRating(ctx context.Context, args []string) {
 	fs := flag.NewFlagSet("retrieveUserSeriesRating", flag.ContinueOnError)
 	seriesID := fs.Int64("id", 0, "Series ID (required).")
 
@@ -689,26 +543,19 @@ func handleRetrieveUserSeriesRating(args []string) {
 		os.Exit(1)
 	}
 
-	path := fmt.Sprintf("/series/%d/rating", *seriesID)
-	fullURL, _ := apiclient.BuildURL(path, nil)
-	respBody, statusCode, err := apiclient.DoRequest("GET", fullURL, nil)
+	respBody, err := mangaupdates.Default().RetrieveUserSeriesRatingRaw(ctx, *seriesID)
 
 	if err != nil {
 		utils.PrintErrorAndExit("API request failed", err)
 	}
-	// Expects auth for user-specific rating. 200 if found, 404 if series not found or no rating for user.
-	// 401/403 without auth.
-	if statusCode == http.StatusOK || statusCode == http.StatusNotFound || statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden {
-		utils.PrintJSON(respBody)
-	} else {
-		fmt.Fprintf(os.Stderr, "API request failed with status %d:\n", statusCode)
-		utils.PrintJSON(respBody)
-		os.Exit(1)
+	defer respBody.Close()
+	if err := utils.PrintJSONStream(respBody); err != nil {
+		utils.PrintErrorAndExit("API request failed", err)
 	}
 }
 
 // handleRetrieveSeriesRatingRainbow (GET /series/{id}/ratingrainbow)
-func handleRetrieveSeriesRatingRainbow(args []string) {
+func handleRetrieveSeriesRatingRainbow(ctx context.Context, args []string) {
 	fs := flag.NewFlagSet("retrieveSeriesRatingRainbow", flag.ContinueOnError)
 	seriesID := fs.Int64("id", 0, "Series ID (required).")
 
@@ -730,24 +577,19 @@ func handleRetrieveSeriesRatingRainbow(args []string) {
 		os.Exit(1)
 	}
 
-	path := fmt.Sprintf("/series/%d/ratingrainbow", *seriesID)
-	fullURL, _ := apiclient.BuildURL(path, nil)
-	respBody, statusCode, err := apiclient.DoRequest("GET", fullURL, nil)
+	respBody, err := mangaupdates.Default().RetrieveSeriesRatingRainbowRaw(ctx, *seriesID)
 
 	if err != nil {
 		utils.PrintErrorAndExit("API request failed", err)
 	}
-	if statusCode == http.StatusOK || statusCode == http.StatusNotFound {
-		utils.PrintJSON(respBody)
-	} else {
-		fmt.Fprintf(os.Stderr, "API request failed with status %d:\n", statusCode)
-		utils.PrintJSON(respBody)
-		os.Exit(1)
+	defer respBody.Close()
+	if err := utils.PrintJSONStream(respBody); err != nil {
+		utils.PrintErrorAndExit("API request failed", err)
 	}
 }
 
 // handleSeriesReleaseRssFeed (GET /series/{id}/rss)
-func handleSeriesReleaseRssFeed(args []string) {
+func handleSeriesReleaseRssFeed(ctx context.Context, args []string) {
 	fs := flag.NewFlagSet("seriesReleaseRssFeed", flag.ContinueOnError)
 	seriesID := fs.Int64("id", 0, "Series ID (required).")
 
@@ -769,18 +611,14 @@ func handleSeriesReleaseRssFeed(args []string) {
 		os.Exit(1)
 	}
 
-	path := fmt.Sprintf("/series/%d/rss", *seriesID)
-	fullURL, _ := apiclient.BuildURL(path, nil)
-	respBody, statusCode, err := apiclient.DoRequest("GET", fullURL, nil)
+	respBody, err := mangaupdates.Default().SeriesReleaseRSSFeedRaw(ctx, *seriesID)
 
 	if err != nil {
 		utils.PrintErrorAndExit("API request failed", err)
 	}
-	if statusCode == http.StatusOK {
-		fmt.Println(string(respBody)) // XML output
-	} else {
-		fmt.Fprintf(os.Stderr, "API request failed with status %d:\n", statusCode)
-		utils.PrintJSON(respBody)
-		os.Exit(1)
+	defer respBody.Close()
+	// XML output, which PrintJSONStream copies unchanged
+	if err := utils.PrintJSONStream(respBody); err != nil {
+		utils.PrintErrorAndExit("API request failed", err)
 	}
 }
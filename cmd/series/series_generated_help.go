@@ -0,0 +1,502 @@
+// Code generated by tools/helpcodegen/main.go; DO NOT EDIT.
+package series
+
+import "github.com/TheDucker1/mangaupdatescli/internal/utils"
+
+var helpRetrieveMySeriesCommentContent = utils.HelpContent{
+	Usage:       "mangaupdatescli series retrieveMySeriesComment --id <integer(int64)> [--unrenderedFields <boolean>] [REQUIRES AUTH]",
+	Description: "Retrieve your comment on a series",
+	Arguments: []utils.ArgHelp{
+		{
+			Name:        "id",
+			Type:        "integer(int64)",
+			Required:    true,
+			Description: "",
+			In:          "path",
+		},
+		{
+			Name:        "unrenderedFields",
+			Type:        "boolean",
+			Required:    false,
+			Description: "Output unrendered fields.",
+			Default:     "false",
+			In:          "query",
+		},
+	},
+	InputJSON:     "Path/Query Parameters",
+	OutputJSON:    utils.SchemaHelp{Label: "Schema (on 200)", Schema: &utils.Schema{Ref: "SeriesCommentModelV1"}},
+	ErrorExamples: map[string]string{"401": "Unauthorized", "404": "No comment on this series"},
+	AuthRequired:  true,
+}
+
+var helpRetrieveSeriesContent = utils.HelpContent{
+	Usage:       "mangaupdatescli series retrieveSeries --id <integer(int64)> [--unrenderedFields <boolean>]",
+	Description: "Retrieve a series",
+	Arguments: []utils.ArgHelp{
+		{
+			Name:        "id",
+			Type:        "integer(int64)",
+			Required:    true,
+			Description: "",
+			In:          "path",
+		},
+		{
+			Name:        "unrenderedFields",
+			Type:        "boolean",
+			Required:    false,
+			Description: "Output fields in unrendered form.",
+			Default:     "false",
+			In:          "query",
+		},
+	},
+	InputJSON:     "Path/Query Parameters",
+	OutputJSON:    utils.SchemaHelp{Label: "Schema (on 200)", Schema: &utils.Schema{Ref: "SeriesModelV1"}},
+	ErrorExamples: map[string]string{"404": "Series not found"},
+	AuthRequired:  false,
+}
+
+var helpRetrieveSeriesCategoryVotesContent = utils.HelpContent{
+	Usage:       "mangaupdatescli series retrieveSeriesCategoryVotes --id <integer(int64)>",
+	Description: "Retrieve the category votes of a series",
+	Arguments: []utils.ArgHelp{
+		{
+			Name:        "id",
+			Type:        "integer(int64)",
+			Required:    true,
+			Description: "",
+			In:          "path",
+		},
+	},
+	InputJSON:     "Path/Query Parameters",
+	OutputJSON:    utils.SchemaHelp{Label: "Schema (on 200)", Schema: &utils.Schema{Type: "array", Items: &utils.Schema{Ref: "SeriesCategoryV1"}}},
+	ErrorExamples: map[string]string{"404": "Series not found"},
+	AuthRequired:  false,
+}
+
+var helpRetrieveSeriesCommentContent = utils.HelpContent{
+	Usage:       "mangaupdatescli series retrieveSeriesComment --id <integer(int64)> --comment-id <integer(int64)> [--unrenderedFields <boolean>]",
+	Description: "Retrieve a comment on a series",
+	Arguments: []utils.ArgHelp{
+		{
+			Name:        "id",
+			Type:        "integer(int64)",
+			Required:    true,
+			Description: "",
+			In:          "path",
+		},
+		{
+			Name:        "comment_id",
+			Type:        "integer(int64)",
+			Required:    true,
+			Description: "",
+			In:          "path",
+		},
+		{
+			Name:        "unrenderedFields",
+			Type:        "boolean",
+			Required:    false,
+			Description: "Output unrendered fields.",
+			Default:     "false",
+			In:          "query",
+		},
+	},
+	InputJSON:     "Path/Query Parameters",
+	OutputJSON:    utils.SchemaHelp{Label: "Schema (on 200)", Schema: &utils.Schema{Ref: "SeriesCommentModelV1"}},
+	ErrorExamples: map[string]string{"404": "Comment not found"},
+	AuthRequired:  false,
+}
+
+var helpRetrieveSeriesCommentLocationContent = utils.HelpContent{
+	Usage:       "mangaupdatescli series retrieveSeriesCommentLocation --id <integer(int64)> --comment-id <integer(int64)>",
+	Description: "Retrieve where a comment appears in the comments of a series",
+	Arguments: []utils.ArgHelp{
+		{
+			Name:        "id",
+			Type:        "integer(int64)",
+			Required:    true,
+			Description: "",
+			In:          "path",
+		},
+		{
+			Name:        "comment_id",
+			Type:        "integer(int64)",
+			Required:    true,
+			Description: "",
+			In:          "path",
+		},
+	},
+	InputJSON:     "Path/Query Parameters",
+	OutputJSON:    utils.SchemaHelp{Label: "Schema (on 200)", Schema: &utils.Schema{Ref: "SeriesCommentLocationV1"}},
+	ErrorExamples: map[string]string{"404": "Comment not found"},
+	AuthRequired:  false,
+}
+
+var helpRetrieveSeriesGroupsContent = utils.HelpContent{
+	Usage:       "mangaupdatescli series retrieveSeriesGroups --id <integer(int64)>",
+	Description: "List the groups releasing a series",
+	Arguments: []utils.ArgHelp{
+		{
+			Name:        "id",
+			Type:        "integer(int64)",
+			Required:    true,
+			Description: "",
+			In:          "path",
+		},
+	},
+	InputJSON:     "Path/Query Parameters",
+	OutputJSON:    utils.SchemaHelp{Label: "Schema (on 200)", Schema: &utils.Schema{Ref: "SeriesGroupsResponseV1"}},
+	ErrorExamples: map[string]string{"404": "Series not found"},
+	AuthRequired:  false,
+}
+
+var helpRetrieveSeriesLocksContent = utils.HelpContent{
+	Usage:       "mangaupdatescli series retrieveSeriesLocks --id <integer(int64)>",
+	Description: "Retrieve the locked fields of a series",
+	Arguments: []utils.ArgHelp{
+		{
+			Name:        "id",
+			Type:        "integer(int64)",
+			Required:    true,
+			Description: "",
+			In:          "path",
+		},
+	},
+	InputJSON:     "Path/Query Parameters",
+	OutputJSON:    utils.SchemaHelp{Label: "Schema (on 200)", Schema: &utils.Schema{Ref: "LocksV1"}},
+	ErrorExamples: map[string]string{"404": "Series not found"},
+	AuthRequired:  false,
+}
+
+var helpRetrieveSeriesRankLocationContent = utils.HelpContent{
+	Usage:       "mangaupdatescli series retrieveSeriesRankLocation --id <integer(int64)> --type <string>",
+	Description: "Retrieve where a series ranks",
+	Arguments: []utils.ArgHelp{
+		{
+			Name:        "id",
+			Type:        "integer(int64)",
+			Required:    true,
+			Description: "",
+			In:          "path",
+		},
+		{
+			Name:        "type",
+			Type:        "string",
+			Required:    true,
+			Description: "Stat type to rank by.",
+			In:          "path",
+		},
+	},
+	InputJSON:     "Path/Query Parameters",
+	OutputJSON:    utils.SchemaHelp{Label: "Schema (on 200)", Schema: &utils.Schema{Ref: "SeriesRankLocationV1"}},
+	ErrorExamples: map[string]string{"404": "Series not found"},
+	AuthRequired:  false,
+}
+
+var helpRetrieveSeriesRatingRainbowContent = utils.HelpContent{
+	Usage:       "mangaupdatescli series retrieveSeriesRatingRainbow --id <integer(int64)>",
+	Description: "Retrieve the rating distribution of a series",
+	Arguments: []utils.ArgHelp{
+		{
+			Name:        "id",
+			Type:        "integer(int64)",
+			Required:    true,
+			Description: "",
+			In:          "path",
+		},
+	},
+	InputJSON:     "Path/Query Parameters",
+	OutputJSON:    utils.SchemaHelp{Label: "Schema (on 200)", Schema: &utils.Schema{Ref: "SeriesRatingRainbowV1"}},
+	ErrorExamples: map[string]string{"404": "Series not found"},
+	AuthRequired:  false,
+}
+
+var helpRetrieveUserSeriesRatingContent = utils.HelpContent{
+	Usage:       "mangaupdatescli series retrieveUserSeriesRating --id <integer(int64)> [REQUIRES AUTH]",
+	Description: "Retrieve your rating of a series",
+	Arguments: []utils.ArgHelp{
+		{
+			Name:        "id",
+			Type:        "integer(int64)",
+			Required:    true,
+			Description: "",
+			In:          "path",
+		},
+	},
+	InputJSON:     "Path/Query Parameters",
+	OutputJSON:    utils.SchemaHelp{Label: "Schema (on 200)", Schema: &utils.Schema{Ref: "SeriesRatingModelV1"}},
+	ErrorExamples: map[string]string{"401": "Unauthorized", "404": "Series not rated"},
+	AuthRequired:  true,
+}
+
+var helpSearchSeriesCommentsPostContent = utils.HelpContent{
+	Usage:       "mangaupdatescli series searchSeriesCommentsPost --id <integer(int64)> [--method <string>] [--added_by <integer(int64)>] [--page <integer>] [--perpage <integer>] [--body <JSON|YAML|@file|->]",
+	Description: "Search the comments on a series",
+	Arguments: []utils.ArgHelp{
+		{
+			Name:        "id",
+			Type:        "integer(int64)",
+			Required:    true,
+			Description: "",
+			In:          "path",
+		},
+		{
+			Name:        "method",
+			Type:        "string",
+			Required:    false,
+			Description: "Search method (useful, time_added).",
+			In:          "body",
+			Enum:        []string{"useful", "time_added"},
+		},
+		{
+			Name:        "added_by",
+			Type:        "integer(int64)",
+			Required:    false,
+			Description: "Filter by author user ID.",
+			In:          "body",
+		},
+		{
+			Name:        "page",
+			Type:        "integer",
+			Required:    false,
+			Description: "Page number.",
+			In:          "body",
+		},
+		{
+			Name:        "perpage",
+			Type:        "integer",
+			Required:    false,
+			Description: "Results per page.",
+			In:          "body",
+		},
+		{
+			Name:        "body",
+			Type:        "JSON|YAML",
+			Required:    false,
+			Description: "Request body as a JSON or YAML document, @file to read it from a file, or - for stdin. The flags of its fields override it.",
+		},
+	},
+	InputJSON:     utils.SchemaHelp{Label: "Request Body Schema", Schema: &utils.Schema{Ref: "SeriesCommentSearchRequestV1"}},
+	OutputJSON:    utils.SchemaHelp{Label: "Schema (on 200)", Schema: &utils.Schema{Ref: "SeriesCommentSearchResponseV1"}},
+	ErrorExamples: map[string]string{"404": "Series not found"},
+	AuthRequired:  false,
+}
+
+var helpSearchSeriesHistoryPostContent = utils.HelpContent{
+	Usage:       "mangaupdatescli series searchSeriesHistoryPost --id <integer(int64)> [--page <integer>] [--perpage <integer>] [--body <JSON|YAML|@file|->]",
+	Description: "Search the edit history of a series",
+	Arguments: []utils.ArgHelp{
+		{
+			Name:        "id",
+			Type:        "integer(int64)",
+			Required:    true,
+			Description: "",
+			In:          "path",
+		},
+		{
+			Name:        "page",
+			Type:        "integer",
+			Required:    false,
+			Description: "Page number.",
+			In:          "body",
+		},
+		{
+			Name:        "perpage",
+			Type:        "integer",
+			Required:    false,
+			Description: "Results per page.",
+			In:          "body",
+		},
+		{
+			Name:        "body",
+			Type:        "JSON|YAML",
+			Required:    false,
+			Description: "Request body as a JSON or YAML document, @file to read it from a file, or - for stdin. The flags of its fields override it.",
+		},
+	},
+	InputJSON:     utils.SchemaHelp{Label: "Request Body Schema", Schema: &utils.Schema{Ref: "PerPageSearchRequestV1"}},
+	OutputJSON:    utils.SchemaHelp{Label: "Schema (on 200)", Schema: &utils.Schema{Ref: "SeriesHistoryResponseV1"}},
+	ErrorExamples: map[string]string{"404": "Series not found"},
+	AuthRequired:  false,
+}
+
+var helpSearchSeriesPostContent = utils.HelpContent{
+	Usage:       "mangaupdatescli series searchSeriesPost [--search <string>] [--added_by <integer(int64)>] [--stype <string>] [--licensed <string>] [--type <[]string>] [--year <string>] [--filter_types <[]string>] [--category <[]string>] [--pubname <string>] [--filters <[]string>] [--list <string>] [--page <integer>] [--perpage <integer>] [--letter <string>] [--genre <[]string>] [--exclude_genre <[]string>] [--orderby <string>] [--pending <boolean>] [--include_rank_metadata <boolean>] [--exclude_filtered_genres <boolean>] [--body <JSON|YAML|@file|->]",
+	Description: "Search series",
+	Arguments: []utils.ArgHelp{
+		{
+			Name:        "search",
+			Type:        "string",
+			Required:    false,
+			Description: "Search term.",
+			In:          "body",
+		},
+		{
+			Name:        "added_by",
+			Type:        "integer(int64)",
+			Required:    false,
+			Description: "Filter by user ID who added the series.",
+			In:          "body",
+		},
+		{
+			Name:        "stype",
+			Type:        "string",
+			Required:    false,
+			Description: "Search type (title, description).",
+			In:          "body",
+			Enum:        []string{"title", "description"},
+		},
+		{
+			Name:        "licensed",
+			Type:        "string",
+			Required:    false,
+			Description: "Filter by licensed status (yes, no).",
+			In:          "body",
+			Enum:        []string{"yes", "no"},
+		},
+		{
+			Name:        "type",
+			Type:        "[]string",
+			Required:    false,
+			Description: "Series types (Manga, Manhwa, ...).",
+			In:          "body",
+			Enum:        []string{"Artbook", "Doujinshi", "Drama CD", "Filipino", "Indonesian", "Manga", "Manhwa", "Manhua", "Novel", "OEL", "Thai", "Vietnamese", "Malaysian", "Nordic", "French", "Spanish"},
+		},
+		{
+			Name:        "year",
+			Type:        "string",
+			Required:    false,
+			Description: "Filter by year.",
+			In:          "body",
+		},
+		{
+			Name:        "filter_types",
+			Type:        "[]string",
+			Required:    false,
+			Description: "Series types to filter out.",
+			In:          "body",
+			Enum:        []string{"Artbook", "Doujinshi", "Drama CD", "Filipino", "Indonesian", "Manga", "Manhwa", "Manhua", "Novel", "OEL", "Thai", "Vietnamese", "Malaysian", "Nordic", "French", "Spanish"},
+		},
+		{
+			Name:        "category",
+			Type:        "[]string",
+			Required:    false,
+			Description: "Categories.",
+			In:          "body",
+		},
+		{
+			Name:        "pubname",
+			Type:        "string",
+			Required:    false,
+			Description: "Filter by publication name.",
+			In:          "body",
+		},
+		{
+			Name:        "filters",
+			Type:        "[]string",
+			Required:    false,
+			Description: "Filters (scanlated, completed, ...).",
+			In:          "body",
+			Enum:        []string{"scanlated", "completed", "oneshots", "no_oneshots", "some_releases", "no_releases"},
+		},
+		{
+			Name:        "list",
+			Type:        "string",
+			Required:    false,
+			Description: "Filter by user list type.",
+			In:          "body",
+			Enum:        []string{"read", "wish", "complete", "unfinished", "hold"},
+		},
+		{
+			Name:        "page",
+			Type:        "integer",
+			Required:    false,
+			Description: "Page number.",
+			In:          "body",
+		},
+		{
+			Name:        "perpage",
+			Type:        "integer",
+			Required:    false,
+			Description: "Results per page.",
+			In:          "body",
+		},
+		{
+			Name:        "letter",
+			Type:        "string",
+			Required:    false,
+			Description: "Filter by starting letter.",
+			In:          "body",
+		},
+		{
+			Name:        "genre",
+			Type:        "[]string",
+			Required:    false,
+			Description: "Genres.",
+			In:          "body",
+		},
+		{
+			Name:        "exclude_genre",
+			Type:        "[]string",
+			Required:    false,
+			Description: "Genres to exclude.",
+			In:          "body",
+		},
+		{
+			Name:        "orderby",
+			Type:        "string",
+			Required:    false,
+			Description: "Order by (score, title, rank, etc.).",
+			In:          "body",
+			Enum:        []string{"score", "title", "rank", "rating", "year", "date_added", "week_pos", "month1_pos", "month3_pos", "month6_pos", "year_pos", "list_reading", "list_wish", "list_complete", "list_unfinished"},
+		},
+		{
+			Name:        "pending",
+			Type:        "boolean",
+			Required:    false,
+			Description: "Include pending series.",
+			In:          "body",
+		},
+		{
+			Name:        "include_rank_metadata",
+			Type:        "boolean",
+			Required:    false,
+			Description: "Include rank metadata.",
+			In:          "body",
+		},
+		{
+			Name:        "exclude_filtered_genres",
+			Type:        "boolean",
+			Required:    false,
+			Description: "Exclude filtered genres.",
+			In:          "body",
+		},
+		{
+			Name:        "body",
+			Type:        "JSON|YAML",
+			Required:    false,
+			Description: "Request body as a JSON or YAML document, @file to read it from a file, or - for stdin. The flags of its fields override it.",
+		},
+	},
+	InputJSON:     utils.SchemaHelp{Label: "Request Body Schema", Schema: &utils.Schema{Ref: "SeriesSearchRequestV1"}},
+	OutputJSON:    utils.SchemaHelp{Label: "Schema (on 200)", Schema: &utils.Schema{Ref: "SeriesSearchResponseV1"}},
+	ErrorExamples: map[string]string{"400": "Validation or Service Error"},
+	AuthRequired:  false,
+}
+
+var helpSeriesReleaseRssFeedContent = utils.HelpContent{
+	Usage:       "mangaupdatescli series seriesReleaseRssFeed --id <integer(int64)>",
+	Description: "RSS feed of the releases of a series",
+	Arguments: []utils.ArgHelp{
+		{
+			Name:        "id",
+			Type:        "integer(int64)",
+			Required:    true,
+			Description: "",
+			In:          "path",
+		},
+	},
+	InputJSON:     "Path/Query Parameters",
+	OutputJSON:    "Output (on 200): application/rss+xml",
+	ErrorExamples: map[string]string{"404": "Series not found"},
+	AuthRequired:  false,
+}
@@ -0,0 +1,223 @@
+// cmd/spec/spec.go
+package spec
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/TheDucker1/mangaupdatescli/internal/openapi"
+	"github.com/TheDucker1/mangaupdatescli/internal/utils"
+	"os"
+	"sort"
+)
+
+// CommandHandler defines the function signature for command handlers
+type CommandHandler func(ctx context.Context, args []string)
+
+// CommandInfo stores the handler and its associated help content
+type CommandInfo struct {
+	Handler CommandHandler
+	Help    utils.HelpContent
+}
+
+// specCommands maps the CLI command name to its handler and help
+var specCommands = make(map[string]CommandInfo)
+
+// init populates specCommands. The help variables live in spec_help.go.
+func init() {
+	specCommands["ops"] = CommandInfo{Handler: handleOps, Help: opsHelpContent}
+	specCommands["show"] = CommandInfo{Handler: handleShow, Help: showHelpContent}
+	specCommands["schema"] = CommandInfo{Handler: handleSchema, Help: schemaHelpContent}
+	specCommands["grep"] = CommandInfo{Handler: handleGrep, Help: grepHelpContent}
+}
+
+// HandleCommand dispatches to the correct spec command handler
+func HandleCommand(ctx context.Context, command string, args []string) {
+	cmdInfo, ok := specCommands[command]
+	if !ok {
+		isJsonHelp, _, _ := utils.CheckHelpFlags(args)
+		fmt.Fprintf(os.Stderr, "Error: Unknown spec command: %s\n\n", command)
+		PrintSpecSubprogramHelp(isJsonHelp)
+		os.Exit(1)
+	}
+	cmdInfo.Handler(ctx, args)
+}
+
+// PrintSpecSubprogramHelp prints help for the entire 'spec' subprogram
+func PrintSpecSubprogramHelp(jsonFormat bool) {
+	if jsonFormat {
+		type CommandHelpSummary struct {
+			Command     string `json:"command"`
+			Usage       string `json:"usage"`
+			Description string `json:"description"`
+		}
+		var summaries []CommandHelpSummary
+		var commandNames []string
+		for name := range specCommands {
+			commandNames = append(commandNames, name)
+		}
+		sort.Strings(commandNames)
+
+		for _, name := range commandNames {
+			cmdInfo := specCommands[name]
+			summaries = append(summaries, CommandHelpSummary{
+				Command:     name,
+				Usage:       cmdInfo.Help.Usage,
+				Description: cmdInfo.Help.Description,
+			})
+		}
+		outputData := map[string]interface{}{
+			"subprogram":  "spec",
+			"description": "Commands for exploring the OpenAPI spec embedded in the CLI, offline.",
+			"commands":    summaries,
+		}
+		jsonData, _ := json.MarshalIndent(outputData, "", "  ")
+		fmt.Println(string(jsonData))
+	} else {
+		fmt.Println("`spec` subprogram: Commands for exploring the OpenAPI spec embedded in the CLI, offline.")
+		fmt.Println("Available commands:")
+		var commandNames []string
+		for name := range specCommands {
+			commandNames = append(commandNames, name)
+		}
+		sort.Strings(commandNames)
+
+		for _, name := range commandNames {
+			cmdInfo := specCommands[name]
+			fmt.Printf("  %-30s %s\n", name, cmdInfo.Help.Description)
+		}
+		fmt.Println("\nUse 'mangaupdatescli spec <command> -hh' for more detailed help on a specific command.")
+	}
+}
+
+// --- Handler Functions ---
+
+// loadSpec returns the embedded spec, exiting if there is none.
+func loadSpec() *openapi.Spec {
+	s, err := openapi.Load()
+	if err != nil {
+		utils.PrintErrorAndExit("Failed to load the OpenAPI spec", err)
+	}
+	return s
+}
+
+// parseArgs parses the flags of a command, handling -h and -hh. It returns
+// false if help was printed.
+func parseArgs(fs *flag.FlagSet, args []string, help utils.HelpContent) bool {
+	isJsonHelp, isTextHelp, remainingArgs := utils.CheckHelpFlags(args)
+	if err := fs.Parse(remainingArgs); err != nil {
+		utils.PrintErrorAndExit(fmt.Sprintf("Failed to parse flags for '%s'", fs.Name()), err)
+	}
+	if isJsonHelp {
+		utils.PrintJSONHelp(help)
+		return false
+	}
+	if isTextHelp {
+		utils.PrintFormattedHelp(help)
+		return false
+	}
+	return true
+}
+
+// requiredArg returns the value of the flag named name, or the first
+// positional argument, exiting with usage help when neither is given.
+func requiredArg(fs *flag.FlagSet, value, name string, help utils.HelpContent) string {
+	if value == "" && fs.NArg() > 0 {
+		value = fs.Arg(0)
+		// Flags after the positional argument ("schema X --depth 3") are still flags.
+		if err := fs.Parse(fs.Args()[1:]); err != nil {
+			utils.PrintErrorAndExit(fmt.Sprintf("Failed to parse flags for '%s'", fs.Name()), err)
+		}
+	}
+	if value == "" {
+		fmt.Fprintf(os.Stderr, "Error: --%s is required for %s.\n", name, fs.Name())
+		utils.PrintFormattedHelp(help)
+		os.Exit(1)
+	}
+	return value
+}
+
+func printValue(v interface{}) {
+	jsonData, err := json.Marshal(v)
+	if err != nil {
+		utils.PrintErrorAndExit("Failed to encode output", err)
+	}
+	utils.PrintJSON(jsonData)
+}
+
+// handleOps lists the operations of the spec
+func handleOps(ctx context.Context, args []string) {
+	fs := flag.NewFlagSet("ops", flag.ContinueOnError)
+	tag := fs.String("tag", "", "Only list the operations of this tag.")
+	if !parseArgs(fs, args, opsHelpContent) {
+		return
+	}
+
+	ops := []openapi.Operation{}
+	for _, op := range loadSpec().Operations {
+		if *tag == "" || op.Tag == *tag {
+			ops = append(ops, op)
+		}
+	}
+	printValue(ops)
+}
+
+// handleShow prints an operation as the spec declares it
+func handleShow(ctx context.Context, args []string) {
+	fs := flag.NewFlagSet("show", flag.ContinueOnError)
+	idFlag := fs.String("id", "", "Operation ID.")
+	if !parseArgs(fs, args, showHelpContent) {
+		return
+	}
+	id := requiredArg(fs, *idFlag, "id", showHelpContent)
+
+	op, ok := loadSpec().Operation(id)
+	if !ok {
+		utils.PrintErrorAndExit(fmt.Sprintf("Unknown operation %q (see 'mangaupdatescli spec ops')", id), nil)
+	}
+	printValue(struct {
+		openapi.Operation
+		Details interface{} `json:"operation"`
+	}{op, op.Details()})
+}
+
+// handleSchema prints a component schema with its fields resolved
+func handleSchema(ctx context.Context, args []string) {
+	fs := flag.NewFlagSet("schema", flag.ContinueOnError)
+	nameFlag := fs.String("name", "", "Schema name.")
+	depth := fs.Int("depth", utils.HelpDepth, "Levels of nested fields to show.")
+	if !parseArgs(fs, args, schemaHelpContent) {
+		return
+	}
+	name := requiredArg(fs, *nameFlag, "name", schemaHelpContent)
+	if *depth < 0 {
+		utils.PrintErrorAndExit("--depth must be >= 0", nil)
+	}
+
+	// The schemas are generated from the same spec as the embedded copy.
+	loadSpec()
+	if _, ok := utils.Schemas[name]; !ok {
+		utils.PrintErrorAndExit(fmt.Sprintf("Unknown schema %q (see 'mangaupdatescli spec grep')", name), nil)
+	}
+	printValue(map[string]interface{}{
+		"name":   name,
+		"schema": utils.SchemaJSON(&utils.Schema{Ref: name}, *depth),
+	})
+}
+
+// handleGrep searches the spec for a text
+func handleGrep(ctx context.Context, args []string) {
+	fs := flag.NewFlagSet("grep", flag.ContinueOnError)
+	textFlag := fs.String("text", "", "Text to search for.")
+	if !parseArgs(fs, args, grepHelpContent) {
+		return
+	}
+	text := requiredArg(fs, *textFlag, "text", grepHelpContent)
+
+	matches := loadSpec().Grep(text)
+	if matches == nil {
+		matches = []openapi.Match{}
+	}
+	printValue(matches)
+}
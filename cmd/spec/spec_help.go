@@ -0,0 +1,53 @@
+// cmd/spec/spec_help.go
+package spec
+
+import "github.com/TheDucker1/mangaupdatescli/internal/utils"
+
+var opsHelpContent = utils.HelpContent{
+	Usage:       "mangaupdatescli spec ops [--tag <string>]",
+	Description: "List the operations of the embedded OpenAPI spec with their method, path and tag.",
+	Arguments: []utils.ArgHelp{
+		{Name: "tag", Type: "string", Description: "Only list the operations of this tag (subprogram)."},
+	},
+	InputJSON:     "None",
+	OutputJSON:    "Array of {operation_id, method, path, tag, summary, auth_required}",
+	ErrorExamples: map[string]string{"Generic": "The binary was built without a spec."},
+	AuthRequired:  false,
+}
+
+var showHelpContent = utils.HelpContent{
+	Usage:       "mangaupdatescli spec show --id <operationId>",
+	Description: "Show an operation of the embedded OpenAPI spec as the spec declares it. The ID may also be given as the first argument.",
+	Arguments: []utils.ArgHelp{
+		{Name: "id", Type: "string", Required: true, Description: "Operation ID, e.g. searchSeriesPost."},
+	},
+	InputJSON:     "None",
+	OutputJSON:    "Object with operation_id, method, path, tag, auth_required and the operation from the spec",
+	ErrorExamples: map[string]string{"Generic": "Unknown operation ID."},
+	AuthRequired:  false,
+}
+
+var schemaHelpContent = utils.HelpContent{
+	Usage:       "mangaupdatescli spec schema --name <string> [--depth <integer>]",
+	Description: "Show a component schema of the embedded OpenAPI spec with its fields, following references to other schemas. The name may also be given as the first argument.",
+	Arguments: []utils.ArgHelp{
+		{Name: "name", Type: "string", Required: true, Description: "Schema name, e.g. SeriesModelV1."},
+		{Name: "depth", Type: "integer", Description: "Levels of nested fields to show.", Default: "the --help-depth global flag"},
+	},
+	InputJSON:     "None",
+	OutputJSON:    "Object with name and schema: {type, description, enum, nullable, properties}",
+	ErrorExamples: map[string]string{"Generic": "Unknown schema name."},
+	AuthRequired:  false,
+}
+
+var grepHelpContent = utils.HelpContent{
+	Usage:       "mangaupdatescli spec grep --text <string>",
+	Description: "Search the keys and values of the embedded OpenAPI spec, ignoring case. The text may also be given as the first argument.",
+	Arguments: []utils.ArgHelp{
+		{Name: "text", Type: "string", Required: true, Description: "Text to search for."},
+	},
+	InputJSON:     "None",
+	OutputJSON:    "Array of {pointer, line, operation_id, schema, text}",
+	ErrorExamples: map[string]string{"Generic": "The binary was built without a spec."},
+	AuthRequired:  false,
+}
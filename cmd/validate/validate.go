@@ -0,0 +1,198 @@
+// cmd/validate/validate.go
+package validate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/TheDucker1/mangaupdatescli/internal/openapi"
+	"github.com/TheDucker1/mangaupdatescli/internal/utils"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// result is the report on one document.
+type result struct {
+	Source    string              `json:"source"`
+	Document  int                 `json:"document"` // 1-based, within Source
+	Operation string              `json:"operation,omitempty"`
+	Skipped   string              `json:"skipped,omitempty"`
+	Issues    []utils.SchemaIssue `json:"issues"`
+}
+
+// PrintValidateHelp prints help for the 'validate' subprogram
+func PrintValidateHelp(jsonFormat bool) {
+	if jsonFormat {
+		utils.PrintJSONHelp(validateHelpContent)
+	} else {
+		utils.PrintFormattedHelp(validateHelpContent)
+	}
+}
+
+// HandleCommand checks the saved responses that args name against the
+// success response schemas of the embedded OpenAPI spec.
+func HandleCommand(ctx context.Context, args []string) {
+	fs := flag.NewFlagSet("validate", flag.ContinueOnError)
+	opID := fs.String("op", "", "Operation ID whose response the documents are.")
+
+	isJsonHelp, isTextHelp, remainingArgs := utils.CheckHelpFlags(args)
+	if err := fs.Parse(remainingArgs); err != nil {
+		utils.PrintErrorAndExit("Failed to parse flags for 'validate'", err)
+	}
+	if isJsonHelp {
+		utils.PrintJSONHelp(validateHelpContent)
+		return
+	}
+	if isTextHelp {
+		utils.PrintFormattedHelp(validateHelpContent)
+		return
+	}
+	if fs.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "Error: name the files to validate, or - for stdin.")
+		utils.PrintFormattedHelp(validateHelpContent)
+		os.Exit(1)
+	}
+
+	spec, err := openapi.Load()
+	if err != nil {
+		utils.PrintErrorAndExit("Failed to load the OpenAPI spec", err)
+	}
+	if *opID != "" {
+		if _, ok := spec.Operation(*opID); !ok {
+			utils.PrintErrorAndExit(fmt.Sprintf("Unknown operation %q (see 'mangaupdatescli spec ops')", *opID), nil)
+		}
+		if _, ok := utils.ResponseSchemas[*opID]; !ok {
+			utils.PrintErrorAndExit(fmt.Sprintf("%s has no JSON response to validate", *opID), nil)
+		}
+	}
+
+	results := []result{}
+	mismatch := false
+	for _, name := range fs.Args() {
+		var data []byte
+		if name == "-" {
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			data, err = os.ReadFile(name)
+		}
+		if err != nil {
+			utils.PrintErrorAndExit("Failed to read "+name, err)
+		}
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		for n := 1; ; n++ {
+			var doc interface{}
+			if err := dec.Decode(&doc); err == io.EOF {
+				break
+			} else if err != nil {
+				utils.PrintErrorAndExit(fmt.Sprintf("%s: document %d is not JSON", name, n), err)
+			}
+			r := check(spec, *opID, doc)
+			r.Source, r.Document = name, n
+			mismatch = mismatch || len(r.Issues) > 0
+			results = append(results, r)
+		}
+	}
+
+	jsonData, err := json.Marshal(results)
+	if err != nil {
+		utils.PrintErrorAndExit("Failed to encode output", err)
+	}
+	utils.PrintJSON(jsonData)
+	if mismatch && utils.StrictValidation {
+		utils.PrintErrorAndExit("Some documents do not match their schema", utils.ErrSchemaMismatch)
+	}
+}
+
+// check validates one document: a cassette saved with --record, a line of
+// the NDJSON output of a command given several IDs, or a response of opID.
+func check(spec *openapi.Spec, opID string, doc interface{}) result {
+	r := result{Operation: opID, Issues: []utils.SchemaIssue{}}
+	obj, _ := doc.(map[string]interface{})
+	if c, ok := cassette(obj); ok {
+		if c.Status < 200 || c.Status > 299 {
+			r.Skipped = fmt.Sprintf("status %d", c.Status)
+			return r
+		}
+		op, ok := matchURL(spec, c.Method, c.URL)
+		if !ok {
+			r.Skipped = fmt.Sprintf("%s %s is not in the OpenAPI spec", c.Method, c.URL)
+			return r
+		}
+		r.Operation = op.ID
+		schema, ok := utils.ResponseSchemas[op.ID]
+		if !ok {
+			r.Skipped = "no JSON response schema"
+			return r
+		}
+		issues, err := utils.ValidateJSON(schema, []byte(c.Body))
+		if err != nil {
+			issues = []utils.SchemaIssue{{Problem: "type", Message: err.Error()}}
+		}
+		if issues != nil {
+			r.Issues = issues
+		}
+		return r
+	}
+
+	if opID == "" {
+		utils.PrintErrorAndExit("--op is required for documents that are not cassettes saved with --record", nil)
+	}
+	// {"id":...,"result":...} lines of a bulk retrieve hold the response in result.
+	if _, hasID := obj["id"]; hasID && len(obj) == 2 {
+		if res, ok := obj["result"]; ok {
+			doc = res
+		} else if _, ok := obj["error"]; ok {
+			r.Skipped = "failed request"
+			return r
+		}
+	}
+	if issues := utils.ValidateValue(utils.ResponseSchemas[opID], doc); issues != nil {
+		r.Issues = issues
+	}
+	return r
+}
+
+// savedCassette holds the fields of a cassette that check needs.
+type savedCassette struct {
+	Method string
+	URL    string
+	Status int
+	Body   string
+}
+
+// cassette returns obj as a cassette, if it is one.
+func cassette(obj map[string]interface{}) (savedCassette, bool) {
+	var c savedCassette
+	var ok [4]bool
+	c.Method, ok[0] = obj["method"].(string)
+	c.URL, ok[1] = obj["url"].(string)
+	c.Body, ok[2] = obj["body"].(string)
+	status, isNumber := obj["status"].(json.Number)
+	if isNumber {
+		n, err := status.Int64()
+		c.Status, ok[3] = int(n), err == nil
+	}
+	return c, ok == [4]bool{true, true, true, true}
+}
+
+// matchURL finds the operation of a full request URL. The API root is not
+// known, so leading path segments are dropped until the rest is a path of
+// the spec.
+func matchURL(spec *openapi.Spec, method, rawURL string) (openapi.Operation, bool) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return openapi.Operation{}, false
+	}
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i := range segments {
+		if op, _, ok := spec.Match(method, "/"+strings.Join(segments[i:], "/")); ok {
+			return op, true
+		}
+	}
+	return openapi.Operation{}, false
+}
@@ -0,0 +1,16 @@
+// cmd/validate/validate_help.go
+package validate
+
+import "github.com/TheDucker1/mangaupdatescli/internal/utils"
+
+var validateHelpContent = utils.HelpContent{
+	Usage:       "mangaupdatescli validate [--op <operationId>] <file | -> ...",
+	Description: "Check saved API responses against the success response schemas of the embedded OpenAPI spec, reporting unknown, missing and wrongly typed fields by JSON pointer. A file may hold several JSON documents (e.g. NDJSON). Cassettes saved with --record name their own operation; other documents need --op. With --strict, exits with code 10 if any document does not match.",
+	Arguments: []utils.ArgHelp{
+		{Name: "op", Type: "string", Description: "Operation ID whose responses the documents are, e.g. retrieveSeries. Lines of the NDJSON output of a command given several IDs are unwrapped."},
+	},
+	InputJSON:     "Files of saved responses or cassettes; - reads stdin",
+	OutputJSON:    "Array of {source, document, operation, skipped, issues: [{pointer, problem, message}]}; problem is unknown, missing, type or enum",
+	ErrorExamples: map[string]string{"Generic": "Unreadable file, a document that is not JSON, or an unknown operation."},
+	AuthRequired:  false,
+}
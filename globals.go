@@ -0,0 +1,158 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/TheDucker1/mangaupdatescli/internal/apiclient"
+	"github.com/TheDucker1/mangaupdatescli/internal/utils"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// globalOptions holds the flags accepted by every command. They may appear
+// anywhere on the command line, before or after the subprogram and command,
+// so no command flag may share their names; the generator checks that for
+// the flags it derives from the spec (globalFlags in tools/helpcodegen).
+type globalOptions struct {
+	profile      string
+	apiURL       string
+	retries      int
+	retryMaxWait time.Duration
+	rateLimit    float64
+	noCache      bool
+	refresh      bool
+	record       string
+	replay       string
+	trace        bool
+	har          string
+	validate     bool
+	strict       bool
+
+	noFollowTransactions bool
+	helpDepth            int
+
+	proxy              string
+	caFile             string
+	insecureSkipVerify bool
+	timeout            time.Duration
+	maxResponseSize    byteSize
+}
+
+var globalOpts = globalOptions{maxResponseSize: apiclient.DefaultMaxResponseSize, helpDepth: utils.HelpDepth}
+
+var globalFlags = newGlobalFlagSet(&globalOpts)
+
+func newGlobalFlagSet(opts *globalOptions) *flag.FlagSet {
+	fs := flag.NewFlagSet("mangaupdatescli", flag.ContinueOnError)
+	fs.StringVar(&opts.profile, "profile", "", "Credential profile to use for this command (default: current profile).")
+	fs.StringVar(&opts.apiURL, "api-url", "", "API base URL, e.g. a local stand-in server (default: $MANGAUPDATESCLI_API_URL, the profile's api_url, or production).")
+	fs.IntVar(&opts.retries, "retries", apiclient.DefaultRetryPolicy.MaxRetries, "Retries for failed read requests (transport errors, 408, 429, 502-504); 0 disables.")
+	fs.Float64Var(&opts.rateLimit, "rate-limit", apiclient.DefaultRequestsPerSecond, "Maximum API requests per second, shared by all running CLI processes; 0 disables.")
+	fs.BoolVar(&opts.noCache, "no-cache", false, "Neither read nor write the response cache.")
+	fs.BoolVar(&opts.refresh, "refresh", false, "Ignore cached responses but store the fresh ones.")
+	fs.StringVar(&opts.record, "record", "", "Save every API response as a cassette file in this directory (implies --no-cache).")
+	fs.StringVar(&opts.replay, "replay", "", "Answer API requests from cassettes in this directory instead of the network (implies --no-cache).")
+	fs.BoolVar(&opts.trace, "trace", false, "Log each request, response, and cache/retry decision to stderr (tokens and passwords redacted).")
+	fs.StringVar(&opts.har, "har", "", "Write all HTTP traffic of this command to an HTTP Archive (HAR) file.")
+	fs.BoolVar(&opts.validate, "validate", false, "Check every response against its schema in the OpenAPI spec and report differences on stderr.")
+	fs.BoolVar(&opts.strict, "strict", false, "With --validate or 'validate', fail with exit code 10 when a response does not match its schema.")
+	fs.StringVar(&opts.proxy, "proxy", "", "http, https or socks5 proxy URL for API requests (default: the profile's proxy, then HTTP(S)_PROXY).")
+	fs.StringVar(&opts.caFile, "ca-file", "", "PEM file of extra CA certificates to trust, e.g. for a TLS-intercepting proxy.")
+	fs.BoolVar(&opts.insecureSkipVerify, "insecure-skip-verify", false, "Disable TLS certificate verification. Unsafe; for debugging only.")
+	fs.DurationVar(&opts.timeout, "timeout", apiclient.DefaultTimeout, "Timeout of a single HTTP request (default: the profile's timeout, then 30s); 0 disables.")
+	fs.Var(&opts.maxResponseSize, "max-response-size", "Largest response body to accept, as a `size` such as 64MiB or 500KB; 0 disables the limit.")
+	fs.DurationVar(&opts.retryMaxWait, "retry-max-wait", apiclient.DefaultRetryPolicy.MaxWait, "Longest wait between retries; a longer Retry-After stops retrying.")
+	fs.BoolVar(&opts.noFollowTransactions, "no-follow-transactions", false, "Print the transaction ID of a slow write instead of waiting for it to finish.")
+	fs.IntVar(&opts.helpDepth, "help-depth", opts.helpDepth, "Levels of nested fields that -h and -hh show of request and response schemas.")
+	return fs
+}
+
+// byteSize is a flag.Value for sizes such as 512, 500KB or 64MiB. Both KB and
+// KiB mean 1024 bytes.
+type byteSize int64
+
+var byteSizeUnits = []struct {
+	suffix string
+	factor int64
+}{
+	{"KiB", 1 << 10}, {"MiB", 1 << 20}, {"GiB", 1 << 30},
+	{"KB", 1 << 10}, {"MB", 1 << 20}, {"GB", 1 << 30},
+	{"K", 1 << 10}, {"M", 1 << 20}, {"G", 1 << 30},
+	{"B", 1},
+}
+
+func (b *byteSize) String() string {
+	for _, unit := range byteSizeUnits[:3] {
+		if n := int64(*b); n != 0 && n%unit.factor == 0 && n/unit.factor < 1024 {
+			return fmt.Sprintf("%d%s", n/unit.factor, unit.suffix)
+		}
+	}
+	return strconv.FormatInt(int64(*b), 10)
+}
+
+func (b *byteSize) Set(s string) error {
+	factor := int64(1)
+	for _, unit := range byteSizeUnits {
+		if strings.HasSuffix(strings.ToUpper(s), strings.ToUpper(unit.suffix)) {
+			s, factor = strings.TrimSpace(s[:len(s)-len(unit.suffix)]), unit.factor
+			break
+		}
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n < 0 {
+		return fmt.Errorf("invalid size %q", s)
+	}
+	*b = byteSize(n * factor)
+	return nil
+}
+
+// extractGlobalFlags splits args into the flags registered on fs (with their
+// values) and everything else, preserving order. Scanning stops at "--".
+func extractGlobalFlags(fs *flag.FlagSet, args []string) (globalArgs []string, rest []string) {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			rest = append(rest, args[i:]...)
+			break
+		}
+		if !strings.HasPrefix(arg, "-") {
+			rest = append(rest, arg)
+			continue
+		}
+
+		name := strings.TrimLeft(arg, "-")
+		hasValue := false
+		if eq := strings.Index(name, "="); eq >= 0 {
+			name = name[:eq]
+			hasValue = true
+		}
+		f := fs.Lookup(name)
+		if f == nil {
+			rest = append(rest, arg)
+			continue
+		}
+
+		globalArgs = append(globalArgs, arg)
+		if bf, ok := f.Value.(interface{ IsBoolFlag() bool }); ok && bf.IsBoolFlag() {
+			continue
+		}
+		if !hasValue && i+1 < len(args) {
+			i++
+			globalArgs = append(globalArgs, args[i])
+		}
+	}
+	return globalArgs, rest
+}
+
+// printGlobalFlags lists the global flags in the same layout as command help.
+func printGlobalFlags() {
+	fmt.Println("\nGlobal flags (accepted by every command):")
+	globalFlags.VisitAll(func(f *flag.Flag) {
+		name, usage := flag.UnquoteUsage(f)
+		if name != "" {
+			name = " <" + name + ">"
+		}
+		fmt.Printf("  --%-24s %s\n", f.Name+name, usage)
+	})
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestExtractGlobalFlags(t *testing.T) {
+	tests := []struct {
+		args   []string
+		global []string
+		rest   []string
+	}{
+		{
+			args: []string{"series", "retrieveSeries", "--id", "1"},
+			rest: []string{"series", "retrieveSeries", "--id", "1"},
+		},
+		{
+			args:   []string{"--profile", "work", "series", "retrieveSeries", "--id", "1", "--no-cache"},
+			global: []string{"--profile", "work", "--no-cache"},
+			rest:   []string{"series", "retrieveSeries", "--id", "1"},
+		},
+		{
+			// Global flags may come after the command, with = or a separate value.
+			args:   []string{"series", "searchSeriesPost", "--timeout=5s", "--search", "x", "-retries", "0"},
+			global: []string{"--timeout=5s", "-retries", "0"},
+			rest:   []string{"series", "searchSeriesPost", "--search", "x"},
+		},
+		{
+			// Boolean flags never take the next argument as their value.
+			args:   []string{"--trace", "series", "--validate=false", "help"},
+			global: []string{"--trace", "--validate=false"},
+			rest:   []string{"series", "help"},
+		},
+		{
+			// Everything after -- belongs to the command.
+			args:   []string{"call", "--har", "x.har", "--", "--profile", "p"},
+			global: []string{"--har", "x.har"},
+			rest:   []string{"call", "--", "--profile", "p"},
+		},
+		{
+			// A value flag at the end has nothing to take.
+			args:   []string{"misc", "--profile"},
+			global: []string{"--profile"},
+			rest:   []string{"misc"},
+		},
+	}
+	for _, tt := range tests {
+		fs := newGlobalFlagSet(&globalOptions{})
+		global, rest := extractGlobalFlags(fs, tt.args)
+		if !slices.Equal(global, tt.global) || !slices.Equal(rest, tt.rest) {
+			t.Errorf("extractGlobalFlags(%q) = %q, %q; want %q, %q", tt.args, global, rest, tt.global, tt.rest)
+		}
+	}
+}
+
+func TestByteSizeSet(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    byteSize
+		wantErr bool
+	}{
+		{"0", 0, false},
+		{"512", 512, false},
+		{"50B", 50, false},
+		{"500KB", 500 << 10, false},
+		{"500kb", 500 << 10, false},
+		{"2K", 2 << 10, false},
+		{"64MiB", 64 << 20, false},
+		{"64 MB", 64 << 20, false},
+		{"1GiB", 1 << 30, false},
+		{"1.5MB", 0, true},
+		{"-1", 0, true},
+		{"MB", 0, true},
+		{"ten", 0, true},
+	}
+	for _, tt := range tests {
+		var b byteSize
+		err := b.Set(tt.in)
+		if (err != nil) != tt.wantErr || (!tt.wantErr && b != tt.want) {
+			t.Errorf("Set(%q) = %d, %v; want %d, error %v", tt.in, b, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestByteSizeString(t *testing.T) {
+	tests := []struct {
+		in   byteSize
+		want string
+	}{
+		{0, "0"},
+		{100, "100"},
+		{2048, "2KiB"},
+		{64 << 20, "64MiB"},
+		{1 << 30, "1GiB"},
+		{1500, "1500"},
+		{2048 << 30, "2199023255552"},
+	}
+	for _, tt := range tests {
+		if got := tt.in.String(); got != tt.want {
+			t.Errorf("byteSize(%d).String() = %q; want %q", int64(tt.in), got, tt.want)
+		}
+	}
+}
@@ -1,5 +1,10 @@
-module mangaupdatescli
+module github.com/TheDucker1/mangaupdatescli
 
 go 1.24.3
 
-require gopkg.in/yaml.v3 v3.0.1 // indirect
+require (
+	golang.org/x/term v0.34.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require golang.org/x/sys v0.35.0 // indirect
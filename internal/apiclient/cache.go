@@ -0,0 +1,208 @@
+package apiclient
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/TheDucker1/mangaupdatescli/internal/atomicfile"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// CacheMode selects how a Client uses the response cache.
+type CacheMode int
+
+const (
+	CacheUse     CacheMode = iota // serve fresh entries, store new responses
+	CacheRefresh                  // always fetch, but store the new response (--refresh)
+	CacheBypass                   // neither read nor write the cache (--no-cache)
+)
+
+// CacheTTLs maps an endpoint family (the first path segment after the API
+// base path) to how long its responses stay fresh. Families not listed, such
+// as misc and account, are never cached.
+var CacheTTLs = map[string]time.Duration{
+	"genres":     24 * time.Hour,
+	"categories": 24 * time.Hour,
+	"authors":    24 * time.Hour,
+	"publishers": 24 * time.Hour,
+	"groups":     12 * time.Hour,
+	"series":     6 * time.Hour,
+	"releases":   15 * time.Minute,
+}
+
+// Cache is an on-disk store of successful responses to read-only requests.
+// Entries are keyed by method, URL, a hash of the JSON body and a hash of
+// the session token, so profiles never see each other's responses.
+type Cache struct {
+	dir string
+}
+
+type cacheEntry struct {
+	Method     string    `json:"method"`
+	URL        string    `json:"url"`
+	Family     string    `json:"family"`
+	StatusCode int       `json:"status"`
+	StoredAt   time.Time `json:"stored_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Body       []byte    `json:"body"`
+}
+
+// CacheStats summarises the contents of a Cache.
+type CacheStats struct {
+	Dir      string         `json:"dir"`
+	Entries  int            `json:"entries"`
+	Expired  int            `json:"expired"`
+	Bytes    int64          `json:"bytes"`
+	Families map[string]int `json:"families"`
+}
+
+// NewCache returns a cache stored under dir. The directory is created on the
+// first write.
+func NewCache(dir string) *Cache {
+	return &Cache{dir: dir}
+}
+
+// DefaultCacheDir is the response cache directory inside the CLI cache dir.
+func DefaultCacheDir(cacheDir string) string {
+	return filepath.Join(cacheDir, "responses")
+}
+
+// endpointFamily returns the first path segment of u below the API base path.
+func endpointFamily(baseURL string, u *url.URL) string {
+	family, _, _ := strings.Cut(strings.TrimPrefix(relativePath(baseURL, u), "/"), "/")
+	return family
+}
+
+// relativePath returns the path of u below the API base path, such as
+// /series/123.
+func relativePath(baseURL string, u *url.URL) string {
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return u.Path
+	}
+	return strings.TrimPrefix(u.Path, strings.TrimSuffix(base.Path, "/"))
+}
+
+func (c *Cache) key(method, fullURL string, jsonData []byte, token string) string {
+	bodyHash := sha256.Sum256(jsonData)
+	tokenHash := sha256.Sum256([]byte(token))
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%s\n%x\n%x", method, fullURL, bodyHash, tokenHash)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, key[:2], key+".json")
+}
+
+// get returns a fresh entry for the request, if any.
+func (c *Cache) get(key string, now time.Time) (*cacheEntry, bool) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || now.After(entry.ExpiresAt) {
+		return nil, false
+	}
+	return &entry, true
+}
+
+// put stores an entry, replacing any previous one atomically.
+func (c *Cache) put(key string, entry *cacheEntry) error {
+	p := c.path(key)
+	if err := os.MkdirAll(filepath.Dir(p), 0700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return atomicfile.Write(p, data, 0600)
+}
+
+// walk calls fn for every entry file in the cache.
+func (c *Cache) walk(fn func(path string, info fs.FileInfo, entry *cacheEntry)) error {
+	err := filepath.WalkDir(c.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".json") {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		var entry cacheEntry
+		data, err := os.ReadFile(path)
+		if err != nil || json.Unmarshal(data, &entry) != nil {
+			entry = cacheEntry{} // unreadable entries count as expired
+		}
+		fn(path, info, &entry)
+		return nil
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// invalidate deletes every entry of family and returns how many were
+// removed. A successful write calls it, since any response of the family may
+// include what was written.
+func (c *Cache) invalidate(family string) (int, error) {
+	removed := 0
+	err := c.walk(func(path string, _ fs.FileInfo, entry *cacheEntry) {
+		if entry.Family == family && os.Remove(path) == nil {
+			removed++
+		}
+	})
+	return removed, err
+}
+
+// Stats reports the number and size of cached entries.
+func (c *Cache) Stats() (CacheStats, error) {
+	stats := CacheStats{Dir: c.dir, Families: make(map[string]int)}
+	now := time.Now()
+	err := c.walk(func(_ string, info fs.FileInfo, entry *cacheEntry) {
+		stats.Entries++
+		stats.Bytes += info.Size()
+		if now.After(entry.ExpiresAt) {
+			stats.Expired++
+		}
+		stats.Families[entry.Family]++
+	})
+	return stats, err
+}
+
+// Prune deletes expired entries and returns how many were removed.
+func (c *Cache) Prune() (int, error) {
+	removed := 0
+	now := time.Now()
+	err := c.walk(func(path string, _ fs.FileInfo, entry *cacheEntry) {
+		if now.After(entry.ExpiresAt) && os.Remove(path) == nil {
+			removed++
+		}
+	})
+	return removed, err
+}
+
+// Clear deletes every entry and returns how many were removed.
+func (c *Cache) Clear() (int, error) {
+	stats, err := c.Stats()
+	if err != nil {
+		return 0, err
+	}
+	if err := os.RemoveAll(c.dir); err != nil {
+		return 0, err
+	}
+	return stats.Entries, nil
+}
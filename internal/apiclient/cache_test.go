@@ -0,0 +1,102 @@
+package apiclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestWriteInvalidatesCachedFamily(t *testing.T) {
+	var mu sync.Mutex
+	fetched := make(map[string]int)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		fetched[r.Method+" "+r.URL.Path]++
+		mu.Unlock()
+		io.WriteString(w, `{}`)
+	}))
+	defer srv.Close()
+
+	client, err := New(
+		WithBaseURL(srv.URL),
+		WithCache(NewCache(t.TempDir()), CacheUse),
+		WithLogger(log.New(io.Discard, "", 0)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	for _, req := range []string{
+		"GET /series/1",
+		"GET /series/2",
+		"GET /genres",
+		"GET /series/1", // cached
+		"PUT /series/1/comments",
+		"GET /series/1", // fetched again after the write
+		"GET /series/2",
+		"GET /genres", // other families stay cached
+	} {
+		method, path, _ := strings.Cut(req, " ")
+		if _, _, err := client.Do(ctx, method, srv.URL+path, nil); err != nil {
+			t.Fatalf("%s: %v", req, err)
+		}
+	}
+
+	tests := []struct {
+		req  string
+		want int
+	}{
+		{"GET /series/1", 2},
+		{"GET /series/2", 2},
+		{"GET /genres", 1},
+	}
+	for _, tt := range tests {
+		if got := fetched[tt.req]; got != tt.want {
+			t.Errorf("%s fetched %d times; want %d", tt.req, got, tt.want)
+		}
+	}
+}
+
+func TestCacheHit(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprintf(w, `{"series_id":42,"request":%d}`, requests)
+	}))
+	defer srv.Close()
+	cache := NewCache(t.TempDir())
+	newClient := func(mode CacheMode) *Client {
+		c, err := New(WithBaseURL(srv.URL), WithLogger(log.New(io.Discard, "", 0)), WithCache(cache, mode))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+	get := func(c *Client) string {
+		t.Helper()
+		body, _, err := c.Do(context.Background(), http.MethodGet, srv.URL+"/series/42", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(body)
+	}
+
+	c := newClient(CacheUse)
+	first := get(c)
+	if second := get(c); second != first || requests != 1 {
+		t.Errorf("second GET = %s after %d requests; want the cached %s after 1", second, requests, first)
+	}
+	if refreshed := get(newClient(CacheRefresh)); refreshed == first || requests != 2 {
+		t.Errorf("refreshed GET = %s after %d requests; want a new response after 2", refreshed, requests)
+	}
+	// The refreshed response replaced the cached one.
+	if again := get(c); again == first || requests != 2 {
+		t.Errorf("GET after the refresh = %s after %d requests; want the refreshed response", again, requests)
+	}
+}
@@ -0,0 +1,188 @@
+package apiclient
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrCassetteNotFound is returned in replay mode for a request that was
+// never recorded.
+var ErrCassetteNotFound = errors.New("no recorded response for this request")
+
+// cassette is one recorded request/response pair. Request headers are not
+// stored and bodies are redacted, so cassettes never contain the session
+// token or password.
+type cassette struct {
+	Method      string      `json:"method"`
+	URL         string      `json:"url"`
+	RequestBody string      `json:"request_body,omitempty"`
+	StatusCode  int         `json:"status"`
+	Header      http.Header `json:"headers"`
+	Body        string      `json:"body"`
+}
+
+// cassetteName derives a readable, stable file name for a request. Only the
+// path and query take part, so a recording can be replayed against any base
+// URL host.
+func cassetteName(req *http.Request, body []byte) string {
+	target := req.URL.EscapedPath()
+	if req.URL.RawQuery != "" {
+		target += "?" + req.URL.RawQuery
+	}
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%s\n", req.Method, target)
+	h.Write(body)
+	sum := hex.EncodeToString(h.Sum(nil))
+
+	slug := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '_'
+	}, strings.Trim(req.URL.Path, "/"))
+	if len(slug) > 60 {
+		slug = slug[:60]
+	}
+	return fmt.Sprintf("%s-%s-%s.json", req.Method, slug, sum[:12])
+}
+
+// readRequestBody drains req.Body and puts an identical reader back.
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// readResponseBody drains resp.Body and puts an identical reader back. A
+// body longer than limit bytes (if limit > 0) is an ErrResponseTooLarge.
+func readResponseBody(resp *http.Response, limit int64) ([]byte, error) {
+	r := io.Reader(resp.Body)
+	if limit > 0 {
+		r = io.LimitReader(resp.Body, limit+1)
+	}
+	body, err := io.ReadAll(r)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	if limit > 0 && int64(len(body)) > limit {
+		return nil, fmt.Errorf("%w (%d bytes)", ErrResponseTooLarge, limit)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// Recorder is an http.RoundTripper that passes requests to another
+// transport and saves every response it gets back as a cassette in a
+// directory, overwriting an earlier recording of the same request.
+type Recorder struct {
+	dir   string
+	next  http.RoundTripper
+	limit int64 // bytes; 0 means unlimited
+}
+
+// NewRecorder records the traffic of next (http.DefaultTransport if nil)
+// into dir. Responses longer than maxResponseSize bytes are not recorded
+// and fail with ErrResponseTooLarge; 0 disables the limit.
+func NewRecorder(dir string, next http.RoundTripper, maxResponseSize int64) *Recorder {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Recorder{dir: dir, next: next, limit: maxResponseSize}
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := readResponseBody(resp, r.limit)
+	if err != nil {
+		return nil, err
+	}
+
+	c := cassette{
+		Method:      req.Method,
+		URL:         req.URL.String(),
+		RequestBody: string(redactJSON(reqBody)),
+		StatusCode:  resp.StatusCode,
+		Header:      resp.Header,
+		Body:        string(redactJSON(respBody)),
+	}
+	if err := r.save(cassetteName(req, reqBody), &c); err != nil {
+		return nil, fmt.Errorf("failed to record response: %w", err)
+	}
+	return resp, nil
+}
+
+func (r *Recorder) save(name string, c *cassette) error {
+	if err := os.MkdirAll(r.dir, 0700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(r.dir, name), append(data, '\n'), 0600)
+}
+
+// Replayer is an http.RoundTripper that answers requests from cassettes
+// written by a Recorder and never touches the network.
+type Replayer struct {
+	dir string
+}
+
+// NewReplayer serves the cassettes stored in dir.
+func NewReplayer(dir string) *Replayer {
+	return &Replayer{dir: dir}
+}
+
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	name := cassetteName(req, reqBody)
+	data, err := os.ReadFile(filepath.Join(r.dir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s %s (expected %s in %s)", ErrCassetteNotFound, req.Method, req.URL.RequestURI(), name, r.dir)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var c cassette
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("invalid cassette %s: %w", name, err)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", c.StatusCode, http.StatusText(c.StatusCode)),
+		StatusCode:    c.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        c.Header,
+		Body:          io.NopCloser(strings.NewReader(c.Body)),
+		ContentLength: int64(len(c.Body)),
+		Request:       req,
+	}, nil
+}
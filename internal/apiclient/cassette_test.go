@@ -0,0 +1,51 @@
+package apiclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestReplayMiss(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		io.WriteString(w, `{"series_id":42,"title":"Berserk"}`)
+	}))
+	defer srv.Close()
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	recorder, err := New(WithBaseURL(srv.URL+"/v1"), WithTransport(NewRecorder(dir, nil, 0)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := recorder.Do(ctx, http.MethodGet, srv.URL+"/v1/series/42", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// The replayer may answer from a different host.
+	replayer, err := New(
+		WithBaseURL("http://replay.invalid/v1"),
+		WithLogger(log.New(io.Discard, "", 0)),
+		WithTransport(NewReplayer(dir)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _, err := replayer.Do(ctx, http.MethodGet, "http://replay.invalid/v1/series/42", nil)
+	if err != nil || string(body) != `{"series_id":42,"title":"Berserk"}` {
+		t.Errorf("replaying a recorded request = %s, %v; want the recorded body", body, err)
+	}
+	_, _, err = replayer.Do(ctx, http.MethodGet, "http://replay.invalid/v1/series/43", nil)
+	if !errors.Is(err, ErrCassetteNotFound) || errors.Is(err, ErrNetwork) {
+		t.Errorf("replaying an unrecorded request: error = %v; want ErrCassetteNotFound", err)
+	}
+	if requests != 1 {
+		t.Errorf("server got %d requests; want only the recorded one", requests)
+	}
+}
@@ -2,20 +2,116 @@ package apiclient
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
 	"net/url"
+	"os"
+	"strings"
 	"time"
 )
 
-const BaseURL = "https://api.mangaupdates.com/v1/"
+// DefaultBaseURL is the production API. See WithBaseURL for overriding it.
+const DefaultBaseURL = "https://api.mangaupdates.com/v1/"
 
-var client = &http.Client{Timeout: 30 * time.Second}
+// DefaultTimeout bounds a single HTTP exchange, excluding retries.
+const DefaultTimeout = 30 * time.Second
 
-func BuildURL(path string, queryParams map[string]string) (string, error) {
-	baseURL, err := url.Parse(BaseURL)
+// DefaultUserAgent identifies the CLI to the API operators.
+const DefaultUserAgent = "mangaupdatescli (+https://github.com/TheDucker1/mangaupdatescli)"
+
+var (
+	ErrNotLoggedIn    = errors.New("this command requires authentication; run 'mangaupdatescli account login' first")
+	ErrSessionExpired = errors.New("the API rejected the stored session token (expired or revoked); run 'mangaupdatescli account login' again")
+)
+
+// Client sends requests to the MangaUpdates API. Build one with New; a
+// Client is safe for concurrent use and never modified after construction.
+type Client struct {
+	httpClient      *http.Client
+	baseURL         string
+	userAgent       string
+	logger          *log.Logger
+	tracer          *log.Logger // nil unless tracing is enabled
+	maxResponseSize int64       // bytes; 0 means unlimited
+	sessionToken    string      // sent as a bearer token when non-empty
+	retry           RetryPolicy
+	limiter         *RateLimiter
+	cache           *Cache
+	cacheMode       CacheMode
+
+	followTransactions bool // wait for slow transactions named by write responses
+	transactionWait    WaitOptions
+
+	check func(method, path string, body []byte) error // nil unless responses are checked
+}
+
+// New returns a Client talking to the production API with the default
+// timeout, retry policy and no cache, rate limit or session, adjusted by opts.
+// Slow transactions are followed by default; see WithTransactionWait.
+func New(opts ...Option) (*Client, error) {
+	c := &Client{
+		httpClient:      &http.Client{Timeout: DefaultTimeout},
+		baseURL:         DefaultBaseURL,
+		userAgent:       DefaultUserAgent,
+		logger:          log.New(os.Stderr, "", 0),
+		retry:           DefaultRetryPolicy,
+		maxResponseSize: DefaultMaxResponseSize,
+
+		followTransactions: true,
+	}
+	return c.With(opts...)
+}
+
+// With returns a copy of c with opts applied on top of its configuration.
+func (c *Client) With(opts ...Option) (*Client, error) {
+	clone := *c
+	httpClient := *c.httpClient
+	clone.httpClient = &httpClient
+	for _, opt := range opts {
+		if err := opt(&clone); err != nil {
+			return nil, err
+		}
+	}
+	return &clone, nil
+}
+
+// BaseURL returns the API root the client builds URLs from.
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// HasSessionToken reports whether the client sends a session token.
+func (c *Client) HasSessionToken() bool {
+	return c.sessionToken != ""
+}
+
+// NormalizeBaseURL checks that rawURL can serve as an API root and returns it
+// with a trailing slash. The URL must be absolute http(s).
+func NormalizeBaseURL(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid API base URL %q: %w", rawURL, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("invalid API base URL %q: must be an absolute http or https URL", rawURL)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("invalid API base URL %q: must not have a query or fragment", rawURL)
+	}
+	if !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+	}
+	return u.String(), nil
+}
+
+// BuildURL joins path to the client's base URL and encodes queryParams.
+func (c *Client) BuildURL(path string, queryParams map[string]string) (string, error) {
+	baseURL, err := url.Parse(c.baseURL)
 	if err != nil {
 		return "", fmt.Errorf("failed to parse base URL: %w", err)
 	}
@@ -33,39 +129,266 @@ func BuildURL(path string, queryParams map[string]string) (string, error) {
 	return finalURL.String(), nil
 }
 
-func DoRequest(method, fullURL string, bodyData interface{}) ([]byte, int, error) {
-	var reqBody io.Reader
-	var req *http.Request
-	var err error
+// Do sends a request and reads the whole response, attaching the session
+// token if the client has one. Any non-2xx response is returned as an
+// *APIError, which carries the body; a 401 on a request that carried a token
+// also matches ErrSessionExpired. Failing to reach the API at all matches
+// ErrNetwork. Cancelling ctx aborts the request, including any retry wait.
+func (c *Client) Do(ctx context.Context, method, fullURL string, bodyData interface{}) ([]byte, int, error) {
+	return c.do(ctx, method, fullURL, bodyData, false)
+}
 
+// DoAuth is Do for endpoints that only work for a logged-in user. It fails
+// with ErrNotLoggedIn without contacting the API when there is no token.
+func (c *Client) DoAuth(ctx context.Context, method, fullURL string, bodyData interface{}) ([]byte, int, error) {
+	return c.do(ctx, method, fullURL, bodyData, true)
+}
+
+// Stream is Do for large responses: it returns the successful response body
+// unread, so the caller can decode or forward it incrementally. The caller
+// must close it. Reading past the maximum response size fails with
+// ErrResponseTooLarge.
+func (c *Client) Stream(ctx context.Context, method, fullURL string, bodyData interface{}) (io.ReadCloser, int, error) {
+	return c.open(ctx, method, fullURL, bodyData, false)
+}
+
+// StreamAuth is Stream for endpoints that only work for a logged-in user.
+func (c *Client) StreamAuth(ctx context.Context, method, fullURL string, bodyData interface{}) (io.ReadCloser, int, error) {
+	return c.open(ctx, method, fullURL, bodyData, true)
+}
+
+func (c *Client) do(ctx context.Context, method, fullURL string, bodyData interface{}, requireAuth bool) ([]byte, int, error) {
+	body, statusCode, err := c.open(ctx, method, fullURL, bodyData, requireAuth)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	defer body.Close()
+	respBody, err := io.ReadAll(body)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return respBody, statusCode, nil
+}
+
+// open sends the request, retrying and consulting the cache as configured,
+// and returns the body of the first successful response.
+func (c *Client) open(ctx context.Context, method, fullURL string, bodyData interface{}, requireAuth bool) (io.ReadCloser, int, error) {
+	if requireAuth && c.sessionToken == "" {
+		return nil, 0, ErrNotLoggedIn
+	}
+
+	var jsonData []byte
 	if bodyData != nil {
-		jsonData, err := json.Marshal(bodyData)
+		var err error
+		jsonData, err = json.Marshal(bodyData)
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to marshall request body: %w", err)
 		}
-		reqBody = bytes.NewBuffer(jsonData)
 	}
 
-	req, err = http.NewRequest(method, fullURL, reqBody)
+	parsedURL, err := url.Parse(fullURL)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to create request: %w", err)
 	}
+	retryable := isRetryableRequest(method, parsedURL)
 
-	if bodyData != nil {
+	// Only read-only requests to families with a TTL are cached.
+	family := endpointFamily(c.baseURL, parsedURL)
+	ttl := CacheTTLs[family]
+	useCache := c.cache != nil && c.cacheMode != CacheBypass && retryable && ttl > 0
+	var store func(respBody []byte)
+	if useCache {
+		cacheKey := c.cache.key(method, fullURL, jsonData, c.sessionToken)
+		if c.cacheMode == CacheUse {
+			if entry, ok := c.cache.get(cacheKey, time.Now()); ok {
+				c.tracef("cache hit: %s %s (stored %s, expires in %s), %d bytes", method, fullURL,
+					entry.StoredAt.Format(time.RFC3339), time.Until(entry.ExpiresAt).Round(time.Second), len(entry.Body))
+				return c.checked(io.NopCloser(bytes.NewReader(entry.Body)), method, parsedURL), entry.StatusCode, nil
+			}
+			c.tracef("cache miss: %s %s", method, fullURL)
+		} else {
+			c.tracef("cache refresh: ignoring any cached response for %s %s", method, fullURL)
+		}
+		store = func(respBody []byte) {
+			now := time.Now()
+			entry := &cacheEntry{
+				Method:     method,
+				URL:        fullURL,
+				Family:     family,
+				StatusCode: http.StatusOK,
+				StoredAt:   now,
+				ExpiresAt:  now.Add(ttl),
+				Body:       respBody,
+			}
+			if cacheErr := c.cache.put(cacheKey, entry); cacheErr != nil {
+				c.logger.Printf("Warning: failed to cache response: %v", cacheErr)
+			} else {
+				c.tracef("cache store: %s family, fresh for %s", family, ttl)
+			}
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, start, err := c.send(ctx, method, fullURL, jsonData)
+		var statusCode int
+		var errBody []byte
+		var retryAfter string
+		if err == nil {
+			statusCode = resp.StatusCode
+			if statusCode >= 200 && statusCode <= 299 {
+				if !retryable && c.cache != nil && ttl > 0 {
+					c.invalidateCache(family)
+				}
+				body := newResponseBody(ctx, c, resp, start, c.maxResponseSize)
+				if statusCode == http.StatusOK {
+					body.store = store
+				}
+				// A write the API finishes asynchronously answers with a
+				// transaction ID instead of the result. Only small JSON
+				// responses can carry one.
+				if c.followTransactions && (!retryable || statusCode == http.StatusAccepted) &&
+					isJSONResponse(resp) && resp.ContentLength <= maxErrorBodySize {
+					id, peeked, err := peekTransactionID(body)
+					if err != nil {
+						return nil, statusCode, err
+					}
+					if id == "" {
+						return c.checked(peeked, method, parsedURL), statusCode, nil
+					}
+					followed, err := c.followTransaction(ctx, id)
+					if err != nil {
+						return nil, statusCode, err
+					}
+					return c.checked(followed, method, parsedURL), statusCode, nil
+				}
+				return c.checked(body, method, parsedURL), statusCode, nil
+			}
+			errBody, err = readErrorBody(resp)
+			c.tracef("<- %s in %s, %d bytes", resp.Status, time.Since(start).Round(time.Millisecond), len(errBody))
+			retryAfter = resp.Header.Get("Retry-After")
+		}
+
+		canRetry := retryable && attempt < c.retry.MaxRetries && ctx.Err() == nil &&
+			(errors.Is(err, ErrNetwork) || isRetryableStatus(statusCode))
+		if !canRetry {
+			return nil, statusCode, c.failure(method, fullURL, errBody, statusCode, err)
+		}
+
+		wait, hasRetryAfter := parseRetryAfter(retryAfter, time.Now())
+		if !hasRetryAfter {
+			wait = c.retry.backoff(attempt)
+		} else if wait > c.retry.MaxWait {
+			// Waiting less than the server asked for would only be rejected again.
+			c.tracef("not retrying: Retry-After %s exceeds the maximum retry wait %s", wait, c.retry.MaxWait)
+			return nil, statusCode, c.failure(method, fullURL, errBody, statusCode, err)
+		}
+
+		reason := fmt.Sprintf("status %d", statusCode)
+		if err != nil {
+			reason = err.Error()
+		}
+		c.logger.Printf("Warning: %s %s failed (%s); retrying in %s (%d/%d)",
+			method, parsedURL.Path, reason, wait.Round(time.Millisecond), attempt+1, c.retry.MaxRetries)
+		if err := sleepContext(ctx, wait); err != nil {
+			return nil, 0, fmt.Errorf("failed to execute request: %w", err)
+		}
+	}
+}
+
+// invalidateCache drops the cached responses of family after a successful
+// write to it, so that the next read sees the change.
+func (c *Client) invalidateCache(family string) {
+	removed, err := c.cache.invalidate(family)
+	if err != nil {
+		c.logger.Printf("Warning: failed to invalidate cached %s responses: %v", family, err)
+		return
+	}
+	c.tracef("cache invalidate: %s family, %d entries", family, removed)
+}
+
+// checked returns body with the client's response check, if any, attached.
+func (c *Client) checked(body io.ReadCloser, method string, u *url.URL) io.ReadCloser {
+	if c.check == nil {
+		return body
+	}
+	path := relativePath(c.baseURL, u)
+	return &checkedBody{ReadCloser: body, check: func(data []byte) error {
+		return c.check(method, path, data)
+	}}
+}
+
+// failure turns an error status into an *APIError; transport errors pass
+// through unchanged.
+func (c *Client) failure(method, fullURL string, errBody []byte, statusCode int, err error) error {
+	if err != nil {
+		return err
+	}
+	return newAPIError(method, fullURL, statusCode, errBody, c.sessionToken != "")
+}
+
+// send performs a single HTTP exchange and returns the response with its
+// body unread, and when the request went out, after any rate limit wait,
+// for timing the response.
+func (c *Client) send(ctx context.Context, method, fullURL string, jsonData []byte) (*http.Response, time.Time, error) {
+	var reqBody io.Reader
+	if jsonData != nil {
+		reqBody = bytes.NewReader(jsonData)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, fullURL, reqBody)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if jsonData != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
 	req.Header.Set("Accept", "application/json, application/xml")
+	req.Header.Set("User-Agent", c.userAgent)
+	if c.sessionToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.sessionToken)
+	}
 
-	resp, err := client.Do(req)
+	if err := c.limiter.wait(ctx, c.logger); err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to execute request: %w", err)
+	}
+	if c.tracer != nil {
+		auth := ""
+		if c.sessionToken != "" {
+			auth = " (Authorization: " + redactHeader("Authorization", c.sessionToken) + ")"
+		}
+		c.tracef("-> %s %s%s", method, fullURL, auth)
+		if jsonData != nil {
+			c.tracef("   body: %s", redactJSON(jsonData))
+		}
+	}
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to execute request: %w", err)
+		c.tracef("<- failed after %s: %v", time.Since(start).Round(time.Millisecond), err)
+		if ctx.Err() == nil && !errors.Is(err, ErrCassetteNotFound) && !errors.Is(err, ErrResponseTooLarge) {
+			err = &networkError{err}
+		}
+		return nil, start, fmt.Errorf("failed to execute request: %w", err)
 	}
-	defer resp.Body.Close()
+	return resp, start, nil
+}
 
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, resp.StatusCode, fmt.Errorf("failed to read response body: %w", err)
+// tracef logs to the tracer, if tracing is enabled.
+func (c *Client) tracef(format string, args ...any) {
+	if c.tracer != nil {
+		c.tracer.Printf(format, args...)
 	}
+}
 
-	return respBody, resp.StatusCode, nil
+// sleepContext waits for d or until ctx is done, whichever comes first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
@@ -0,0 +1,199 @@
+package apiclient
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestTraceLatencyExcludesRateLimitWait(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{}`)
+	}))
+	defer srv.Close()
+
+	var trace bytes.Buffer
+	// One request every 300ms: the second request waits for the limiter.
+	client, err := New(
+		WithBaseURL(srv.URL),
+		WithRateLimiter(NewRateLimiter(1/0.3, 1, "")),
+		WithTrace(log.New(&trace, "", 0)),
+		WithLogger(log.New(io.Discard, "", 0)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, _, err := client.Do(context.Background(), http.MethodGet, srv.URL+"/genres", nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	matches := regexp.MustCompile(`<- 200 OK in (\S+),`).FindAllStringSubmatch(trace.String(), -1)
+	if len(matches) != 2 {
+		t.Fatalf("trace has %d responses; want 2:\n%s", len(matches), trace.String())
+	}
+	for _, m := range matches {
+		d, err := time.ParseDuration(m[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d >= 200*time.Millisecond {
+			t.Errorf("traced latency %s includes the rate limit wait", d)
+		}
+	}
+}
+
+func TestDoAuthSessionErrors(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusUnauthorized)
+		io.WriteString(w, `{"status":"exception","reason":"You must be logged in."}`)
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name         string
+		token        string
+		auth         bool
+		wantErr      error
+		wantExpired  bool
+		wantRequests int
+	}{
+		{name: "no token", auth: true, wantErr: ErrNotLoggedIn, wantRequests: 0},
+		{name: "rejected token", token: "OLD", auth: true, wantErr: ErrUnauthorized, wantExpired: true, wantRequests: 1},
+		// A 401 without a token is not an expired session.
+		{name: "401 without a token", auth: false, wantErr: ErrUnauthorized, wantRequests: 1},
+	}
+	for _, tt := range tests {
+		requests = 0
+		client, err := New(WithBaseURL(srv.URL), WithSessionToken(tt.token), WithLogger(log.New(io.Discard, "", 0)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		do := client.Do
+		if tt.auth {
+			do = client.DoAuth
+		}
+		_, _, err = do(context.Background(), http.MethodGet, srv.URL+"/account/profile", nil)
+		if !errors.Is(err, tt.wantErr) || errors.Is(err, ErrSessionExpired) != tt.wantExpired {
+			t.Errorf("%s: err = %v; want %v, session expired %v", tt.name, err, tt.wantErr, tt.wantExpired)
+		}
+		if requests != tt.wantRequests {
+			t.Errorf("%s: sent %d requests; want %d", tt.name, requests, tt.wantRequests)
+		}
+	}
+}
+
+func TestCancelAbortsRequest(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+	client, err := New(WithBaseURL(srv.URL), WithLogger(log.New(io.Discard, "", 0)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	start := time.Now()
+	_, _, err = client.Do(ctx, http.MethodGet, srv.URL+"/genres", nil)
+	if !errors.Is(err, context.Canceled) || errors.Is(err, ErrNetwork) {
+		t.Errorf("Do after cancel = %v; want context.Canceled, not a network error", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Do returned %s after cancel; want at once", elapsed)
+	}
+}
+
+func TestCancelAbortsRetryWait(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Retry-After", "10")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+	client, err := New(
+		WithBaseURL(srv.URL),
+		WithRetryPolicy(RetryPolicy{MaxRetries: 2, BaseDelay: 10 * time.Second, MaxWait: time.Minute}),
+		WithLogger(log.New(io.Discard, "", 0)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	start := time.Now()
+	_, _, err = client.Do(ctx, http.MethodGet, srv.URL+"/genres", nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Do after cancel = %v; want context.Canceled", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Do returned %s after cancel; want before the 10s retry wait ends", elapsed)
+	}
+	if requests != 1 {
+		t.Errorf("sent %d requests; want 1", requests)
+	}
+}
+
+func TestClientsDoNotShareState(t *testing.T) {
+	type received struct{ path, auth, userAgent string }
+	var got []received
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, received{r.URL.Path, r.Header.Get("Authorization"), r.Header.Get("User-Agent")})
+		io.WriteString(w, `{}`)
+	}))
+	defer srv.Close()
+
+	logger := WithLogger(log.New(io.Discard, "", 0))
+	a, err := New(WithBaseURL(srv.URL+"/a"), WithSessionToken("A"), WithUserAgent("agent-a"), WithTimeout(time.Second), logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := New(WithBaseURL(srv.URL+"/b"), WithSessionToken("B"), WithUserAgent("agent-b"), WithTimeout(2*time.Second), logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A derived client changes only itself.
+	derived, err := a.With(WithSessionToken(""), WithUserAgent("agent-derived"), WithTimeout(3*time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []*Client{a, b, derived, a} {
+		if _, _, err := c.Do(context.Background(), http.MethodGet, c.BaseURL()+"genres", nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := []received{
+		{"/a/genres", "Bearer A", "agent-a"},
+		{"/b/genres", "Bearer B", "agent-b"},
+		{"/a/genres", "", "agent-derived"},
+		{"/a/genres", "Bearer A", "agent-a"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("server received %d requests; want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("request %d = %+v; want %+v", i, got[i], want[i])
+		}
+	}
+	if a.httpClient.Timeout != time.Second || b.httpClient.Timeout != 2*time.Second || derived.httpClient.Timeout != 3*time.Second {
+		t.Errorf("timeouts = %s, %s, %s; want 1s, 2s, 3s", a.httpClient.Timeout, b.httpClient.Timeout, derived.httpClient.Timeout)
+	}
+	if !a.HasSessionToken() || derived.HasSessionToken() {
+		t.Errorf("HasSessionToken = %v, %v; want the original to keep its token", a.HasSessionToken(), derived.HasSessionToken())
+	}
+}
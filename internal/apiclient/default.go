@@ -0,0 +1,45 @@
+package apiclient
+
+import (
+	"context"
+	"io"
+)
+
+// defaultClient backs the package-level helpers used by the CLI handlers.
+var defaultClient, _ = New()
+
+// Default returns the client used by the package-level helpers.
+func Default() *Client {
+	return defaultClient
+}
+
+// SetDefault replaces the client used by the package-level helpers. The CLI
+// calls it once at startup with the client built from flags and profile.
+func SetDefault(c *Client) {
+	defaultClient = c
+}
+
+// BuildURL is Default().BuildURL.
+func BuildURL(path string, queryParams map[string]string) (string, error) {
+	return defaultClient.BuildURL(path, queryParams)
+}
+
+// DoRequest is Default().Do.
+func DoRequest(ctx context.Context, method, fullURL string, bodyData interface{}) ([]byte, int, error) {
+	return defaultClient.Do(ctx, method, fullURL, bodyData)
+}
+
+// DoAuthRequest is Default().DoAuth.
+func DoAuthRequest(ctx context.Context, method, fullURL string, bodyData interface{}) ([]byte, int, error) {
+	return defaultClient.DoAuth(ctx, method, fullURL, bodyData)
+}
+
+// StreamRequest is Default().Stream.
+func StreamRequest(ctx context.Context, method, fullURL string, bodyData interface{}) (io.ReadCloser, int, error) {
+	return defaultClient.Stream(ctx, method, fullURL, bodyData)
+}
+
+// StreamAuthRequest is Default().StreamAuth.
+func StreamAuthRequest(ctx context.Context, method, fullURL string, bodyData interface{}) (io.ReadCloser, int, error) {
+	return defaultClient.StreamAuth(ctx, method, fullURL, bodyData)
+}
@@ -0,0 +1,106 @@
+package apiclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Error kinds returned by Client.Do, to be matched with errors.Is.
+var (
+	ErrNotFound     = errors.New("not found")           // 404
+	ErrUnauthorized = errors.New("not authorized")      // 401, 403
+	ErrValidation   = errors.New("invalid request")     // 400, 422
+	ErrRateLimited  = errors.New("rate limited")        // 429
+	ErrServer       = errors.New("API server error")    // 5xx
+	ErrNetwork      = errors.New("could not reach API") // no response at all
+)
+
+// APIError is returned by Client.Do for any non-2xx response. Status, Reason
+// and Context come from the API's standard error body (ApiResponseV1) when
+// it has one; Body always holds the raw response.
+type APIError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Status     string         // "status" field of the body, e.g. "exception"
+	Reason     string         // human-readable explanation from the API
+	Context    map[string]any // extra detail, e.g. per-field validation errors
+	Body       []byte
+
+	sessionExpired bool
+}
+
+// newAPIError decodes an error response. Bodies that are not ApiResponseV1
+// JSON fall back to the HTTP status text as the reason.
+func newAPIError(method, fullURL string, statusCode int, body []byte, sentToken bool) *APIError {
+	e := &APIError{Method: method, URL: fullURL, StatusCode: statusCode, Body: body}
+	var apiResp struct {
+		Status  string         `json:"status"`
+		Reason  string         `json:"reason"`
+		Context map[string]any `json:"context"`
+	}
+	if json.Unmarshal(body, &apiResp) == nil {
+		e.Status, e.Reason, e.Context = apiResp.Status, apiResp.Reason, apiResp.Context
+	}
+	if e.Reason == "" {
+		e.Reason = http.StatusText(statusCode)
+	}
+	e.sessionExpired = statusCode == http.StatusUnauthorized && sentToken
+	return e
+}
+
+func (e *APIError) Error() string {
+	target := e.URL
+	if u, err := url.Parse(e.URL); err == nil {
+		target = u.Path
+	}
+	msg := fmt.Sprintf("%s %s returned %d: %s", e.Method, target, e.StatusCode, strings.TrimSuffix(e.Reason, "."))
+	if e.sessionExpired {
+		msg += " (" + ErrSessionExpired.Error() + ")"
+	}
+	return msg
+}
+
+// Kind returns the sentinel matching the status code, or nil for statuses
+// outside the documented kinds.
+func (e *APIError) Kind() error {
+	switch {
+	case e.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden:
+		return ErrUnauthorized
+	case e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity:
+		return ErrValidation
+	case e.StatusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case e.StatusCode >= 500:
+		return ErrServer
+	}
+	return nil
+}
+
+// Unwrap exposes the kind, and ErrSessionExpired for a 401 on a request that
+// carried a session token.
+func (e *APIError) Unwrap() []error {
+	var errs []error
+	if kind := e.Kind(); kind != nil {
+		errs = append(errs, kind)
+	}
+	if e.sessionExpired {
+		errs = append(errs, ErrSessionExpired)
+	}
+	return errs
+}
+
+// networkError marks a failure to get any response, so callers can tell an
+// unreachable API from one that answered with an error.
+type networkError struct {
+	err error
+}
+
+func (e *networkError) Error() string   { return e.err.Error() }
+func (e *networkError) Unwrap() []error { return []error{ErrNetwork, e.err} }
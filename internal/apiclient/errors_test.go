@@ -0,0 +1,44 @@
+package apiclient
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAPIErrorKind(t *testing.T) {
+	tests := []struct {
+		status     int
+		body       string
+		sentToken  bool
+		want       error // nil for no kind
+		wantReason string
+	}{
+		{400, `{"status":"exception","reason":"Bad page."}`, false, ErrValidation, "Bad page."},
+		{401, ``, false, ErrUnauthorized, "Unauthorized"},
+		{403, `not json`, true, ErrUnauthorized, "Forbidden"},
+		{404, `{"reason":"Series not found."}`, false, ErrNotFound, "Series not found."},
+		{409, ``, false, nil, "Conflict"},
+		{422, ``, false, ErrValidation, "Unprocessable Entity"},
+		{429, ``, false, ErrRateLimited, "Too Many Requests"},
+		{500, ``, false, ErrServer, "Internal Server Error"},
+		{503, ``, false, ErrServer, "Service Unavailable"},
+	}
+	kinds := []error{ErrNotFound, ErrUnauthorized, ErrValidation, ErrRateLimited, ErrServer, ErrNetwork}
+	for _, tt := range tests {
+		e := newAPIError("GET", "https://api.example.com/v1/series/1", tt.status, []byte(tt.body), tt.sentToken)
+		if e.Kind() != tt.want || e.Reason != tt.wantReason {
+			t.Errorf("status %d: Kind, Reason = %v, %q; want %v, %q", tt.status, e.Kind(), e.Reason, tt.want, tt.wantReason)
+		}
+		// An error matches its own kind and no other.
+		for _, kind := range kinds {
+			if got := errors.Is(e, kind); got != (kind == tt.want) {
+				t.Errorf("status %d: errors.Is(err, %v) = %v", tt.status, kind, got)
+			}
+		}
+		// Only a 401 to a request with a token means the session expired.
+		want := tt.status == 401 && tt.sentToken
+		if got := errors.Is(e, ErrSessionExpired); got != want {
+			t.Errorf("status %d, token %v: errors.Is(err, ErrSessionExpired) = %v; want %v", tt.status, tt.sentToken, got, want)
+		}
+	}
+}
@@ -0,0 +1,258 @@
+package apiclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// HAR 1.2 (http://www.softwareishard.com/blog/har-12-spec/), limited to the
+// fields browser devtools need to show a request log.
+type harLog struct {
+	Version string     `json:"version"`
+	Creator harCreator `json:"creator"`
+	Entries []harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime time.Time   `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// HARRecorder is an http.RoundTripper that logs every exchange passing
+// through it to an HTTP Archive file, which browser devtools can import.
+// Each entry is appended in place of the closing brackets, which are
+// written again after it, so the file is complete even if the process exits
+// abruptly. Tokens and passwords are redacted.
+type HARRecorder struct {
+	path  string
+	next  http.RoundTripper
+	limit int64 // bytes; 0 means unlimited
+
+	mu      sync.Mutex
+	log     harLog
+	file    *os.File // nil until the first entry is written
+	end     int64    // offset of the closing brackets in file
+	entries int
+	closed  bool
+	warned  bool
+}
+
+// harTrailer closes the entries array and the log and root objects.
+const harTrailer = "\n]}}\n"
+
+// NewHARRecorder archives the traffic of next (http.DefaultTransport if nil)
+// to path. Responses longer than maxResponseSize bytes are not archived and
+// fail with ErrResponseTooLarge; 0 disables the limit.
+func NewHARRecorder(path string, next http.RoundTripper, maxResponseSize int64) *HARRecorder {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &HARRecorder{
+		path:  path,
+		next:  next,
+		limit: maxResponseSize,
+		log: harLog{
+			Version: "1.2",
+			Creator: harCreator{Name: "mangaupdatescli", Version: "1"},
+			Entries: []harEntry{},
+		},
+	}
+}
+
+func (h *HARRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	resp, err := h.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := readResponseBody(resp, h.limit)
+	if err != nil {
+		return nil, err
+	}
+	elapsed := float64(time.Since(start).Microseconds()) / 1000
+
+	entry := harEntry{
+		StartedDateTime: start,
+		Time:            elapsed,
+		Request: harRequest{
+			Method:      req.Method,
+			URL:         req.URL.String(),
+			HTTPVersion: req.Proto,
+			Cookies:     []harNameValue{},
+			Headers:     harHeaders(req.Header),
+			QueryString: []harNameValue{},
+			HeadersSize: -1,
+			BodySize:    len(reqBody),
+		},
+		Response: harResponse{
+			Status:      resp.StatusCode,
+			StatusText:  http.StatusText(resp.StatusCode),
+			HTTPVersion: resp.Proto,
+			Cookies:     []harNameValue{},
+			Headers:     harHeaders(resp.Header),
+			Content: harContent{
+				Size:     len(respBody),
+				MimeType: resp.Header.Get("Content-Type"),
+				Text:     string(redactJSON(respBody)),
+			},
+			RedirectURL: resp.Header.Get("Location"),
+			HeadersSize: -1,
+			BodySize:    len(respBody),
+		},
+		Timings: harTimings{Wait: elapsed},
+	}
+	if entry.Request.HTTPVersion == "" {
+		entry.Request.HTTPVersion = "HTTP/1.1"
+	}
+	for name, values := range req.URL.Query() {
+		for _, v := range values {
+			entry.Request.QueryString = append(entry.Request.QueryString, harNameValue{name, v})
+		}
+	}
+	if reqBody != nil {
+		entry.Request.PostData = &harPostData{
+			MimeType: req.Header.Get("Content-Type"),
+			Text:     string(redactJSON(reqBody)),
+		}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if err := h.append(&entry); err != nil && !h.warned {
+		// The response is fine; only the archive is incomplete.
+		h.warned = true
+		fmt.Fprintf(os.Stderr, "Warning: failed to write HAR file %s: %v\n", h.path, err)
+	}
+	return resp, nil
+}
+
+// append adds entry to the archive, creating the file on the first call.
+func (h *HARRecorder) append(entry *harEntry) error {
+	if h.closed {
+		return os.ErrClosed
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	file := h.file
+	if file == nil {
+		head, err := json.Marshal(&h.log)
+		if err != nil {
+			return err
+		}
+		if file, err = os.OpenFile(h.path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600); err != nil {
+			return err
+		}
+		// Cut `]}` off the empty entries array so entries can follow.
+		buf.WriteString(`{"log":`)
+		buf.Write(head[:len(head)-2])
+	}
+	if h.entries > 0 {
+		buf.WriteByte(',')
+	}
+	buf.WriteByte('\n')
+	buf.Write(data)
+	n := int64(buf.Len())
+	buf.WriteString(harTrailer)
+	if _, err := file.WriteAt(buf.Bytes(), h.end); err != nil {
+		// Without its header the file is no archive; the next entry
+		// creates it again.
+		if h.file == nil {
+			file.Close()
+		}
+		return err
+	}
+	h.file = file
+	h.end += n
+	h.entries++
+	return nil
+}
+
+// Close closes the archive file; later exchanges are not recorded. The file
+// is complete without it.
+func (h *HARRecorder) Close() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed || h.file == nil {
+		h.closed = true
+		return nil
+	}
+	h.closed = true
+	return h.file.Close()
+}
+
+func harHeaders(header http.Header) []harNameValue {
+	headers := []harNameValue{}
+	for name, values := range header {
+		for _, v := range values {
+			headers = append(headers, harNameValue{name, redactHeader(name, v)})
+		}
+	}
+	return headers
+}
@@ -0,0 +1,114 @@
+package apiclient
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestHARRecorderKeepsFileComplete(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"path":"`+r.URL.Path+`","session_token":"secret"}`)
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "out.har")
+	rec := NewHARRecorder(path, nil, 0)
+	defer rec.Close()
+	client := &http.Client{Transport: rec}
+
+	for i, p := range []string{"/a", "/b", "/c"} {
+		resp, err := client.Get(srv.URL + p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var har struct {
+			Log harLog `json:"log"`
+		}
+		if err := json.Unmarshal(data, &har); err != nil {
+			t.Fatalf("after %d requests the archive is not valid JSON: %v\n%s", i+1, err, data)
+		}
+		if n := len(har.Log.Entries); n != i+1 {
+			t.Fatalf("after %d requests the archive has %d entries", i+1, n)
+		}
+		last := har.Log.Entries[i]
+		if !strings.HasSuffix(last.Request.URL, p) || strings.Contains(last.Response.Content.Text, "secret") {
+			t.Errorf("entry %d = %s %s; want %s with the token redacted", i, last.Request.URL, last.Response.Content.Text, p)
+		}
+	}
+}
+
+func TestHARRecorderWriteFailure(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "ok")
+	}))
+	defer srv.Close()
+
+	// The response must reach the caller even if it cannot be archived.
+	rec := NewHARRecorder(filepath.Join(t.TempDir(), "missing", "out.har"), nil, 0)
+	rec.warned = true // keep the test output quiet
+	resp, err := (&http.Client{Transport: rec}).Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if body, _ := io.ReadAll(resp.Body); string(body) != "ok" {
+		t.Errorf("body = %q; want ok", body)
+	}
+}
+
+func TestHARRecorderRetriesHeaderAfterFailedWrite(t *testing.T) {
+	if _, err := os.Stat("/dev/full"); err != nil {
+		t.Skip("needs /dev/full, where every write fails")
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "ok")
+	}))
+	defer srv.Close()
+
+	rec := NewHARRecorder("/dev/full", nil, 0)
+	rec.warned = true // keep the test output quiet
+	defer rec.Close()
+	client := &http.Client{Transport: rec}
+	get := func() {
+		t.Helper()
+		resp, err := client.Get(srv.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}
+
+	get()
+	if rec.file != nil || rec.end != 0 || rec.entries != 0 {
+		t.Fatalf("after a failed first write file, end, entries = %v, %d, %d; want nil, 0, 0", rec.file, rec.end, rec.entries)
+	}
+	// Once writing works, the archive starts with its header.
+	path := filepath.Join(t.TempDir(), "out.har")
+	rec.path = path
+	get()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var har struct {
+		Log harLog `json:"log"`
+	}
+	if err := json.Unmarshal(data, &har); err != nil || len(har.Log.Entries) != 1 {
+		t.Errorf("archive after a failed first write = %v, %d entries; want valid JSON with 1 entry\n%s", err, len(har.Log.Entries), data)
+	}
+}
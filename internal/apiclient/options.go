@@ -0,0 +1,144 @@
+package apiclient
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+)
+
+// Option configures a Client; see New and Client.With.
+type Option func(*Client) error
+
+// WithTimeout bounds each HTTP exchange; zero means no timeout.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) error {
+		if d < 0 {
+			return errors.New("timeout must not be negative")
+		}
+		c.httpClient.Timeout = d
+		return nil
+	}
+}
+
+// WithTransport replaces the HTTP transport, e.g. to stub the API in tests.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *Client) error {
+		c.httpClient.Transport = rt
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(ua string) Option {
+	return func(c *Client) error {
+		c.userAgent = ua
+		return nil
+	}
+}
+
+// WithLogger sets where retry, cache and rate limiter warnings are written.
+func WithLogger(l *log.Logger) Option {
+	return func(c *Client) error {
+		c.logger = l
+		return nil
+	}
+}
+
+// WithTrace logs every request and response, and the cache and retry
+// decisions behind them, to l. Passwords and tokens are redacted.
+func WithTrace(l *log.Logger) Option {
+	return func(c *Client) error {
+		c.tracer = l
+		return nil
+	}
+}
+
+// WithMaxResponseSize bounds the size of a single response body in bytes;
+// zero means unlimited.
+func WithMaxResponseSize(n int64) Option {
+	return func(c *Client) error {
+		if n < 0 {
+			return errors.New("maximum response size must not be negative")
+		}
+		c.maxResponseSize = n
+		return nil
+	}
+}
+
+// WithBaseURL points the client at another API root, such as a staging or
+// local stand-in server.
+func WithBaseURL(rawURL string) Option {
+	return func(c *Client) error {
+		baseURL, err := NormalizeBaseURL(rawURL)
+		if err != nil {
+			return err
+		}
+		c.baseURL = baseURL
+		return nil
+	}
+}
+
+// WithSessionToken sets the bearer token sent with every request. An empty
+// token sends none.
+func WithSessionToken(token string) Option {
+	return func(c *Client) error {
+		c.sessionToken = token
+		return nil
+	}
+}
+
+// WithRetryPolicy replaces the retry policy.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *Client) error {
+		if p.MaxRetries < 0 || p.MaxWait <= 0 {
+			return errors.New("retries must be >= 0 and the maximum retry wait must be positive")
+		}
+		c.retry = p
+		return nil
+	}
+}
+
+// WithRateLimiter throttles requests through l; nil disables limiting.
+func WithRateLimiter(l *RateLimiter) Option {
+	return func(c *Client) error {
+		c.limiter = l
+		return nil
+	}
+}
+
+// WithCache stores responses in cache according to mode; a nil cache
+// disables caching.
+func WithCache(cache *Cache, mode CacheMode) Option {
+	return func(c *Client) error {
+		c.cache = cache
+		c.cacheMode = mode
+		return nil
+	}
+}
+
+// WithTransactionWait controls following slow transactions: when follow is
+// true, a write response that names a transaction is replaced by the final
+// status of that transaction, polled as described by opts.
+func WithTransactionWait(follow bool, opts WaitOptions) Option {
+	return func(c *Client) error {
+		if opts.Timeout < 0 {
+			return errors.New("transaction wait timeout must not be negative")
+		}
+		c.followTransactions = follow
+		c.transactionWait = opts
+		return nil
+	}
+}
+
+// WithResponseCheck has check inspect the body of every successful
+// response, fresh or cached, once it has been read completely; path is
+// relative to the API root, such as /series/123. An error from check is
+// returned by the last Read of the body. Bodies are held in memory while
+// they are read, so the maximum response size still bounds them.
+func WithResponseCheck(check func(method, path string, body []byte) error) Option {
+	return func(c *Client) error {
+		c.check = check
+		return nil
+	}
+}
@@ -0,0 +1,219 @@
+package apiclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/TheDucker1/mangaupdatescli/internal/atomicfile"
+	"log"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DefaultRequestsPerSecond keeps bulk scripts within the API's acceptable
+// use policy while leaving interactive use unaffected.
+const DefaultRequestsPerSecond = 2.0
+
+// RateLimiter is a token bucket shared by all goroutines of a process and,
+// when it has a state file, by every process using the same file. Tokens may
+// go negative: each caller reserves the next free slot and sleeps until it.
+type RateLimiter struct {
+	mu        sync.Mutex
+	rate      float64 // tokens added per second
+	burst     float64 // bucket capacity
+	statePath string  // shared state file; empty means this process only
+	state     limiterState
+	warned    bool
+}
+
+type limiterState struct {
+	Tokens  float64 `json:"tokens"`
+	Updated int64   `json:"updated"` // unix nanoseconds
+}
+
+// staleLockAge is how old a lock file must be before it is assumed to belong
+// to a crashed process. The lock is only held for a read and a write.
+const staleLockAge = 5 * time.Second
+
+// NewRateLimiter returns a limiter allowing rps requests per second with
+// bursts of up to burst requests. If statePath is non-empty the budget is
+// shared with other processes through that file.
+func NewRateLimiter(rps float64, burst int, statePath string) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:      rps,
+		burst:     float64(burst),
+		statePath: statePath,
+		state:     limiterState{Tokens: float64(burst), Updated: time.Now().UnixNano()},
+	}
+}
+
+// DefaultRateLimiterStatePath is the state file shared by all CLI processes
+// of the current user.
+func DefaultRateLimiterStatePath(cacheDir string) string {
+	return filepath.Join(cacheDir, "ratelimit.json")
+}
+
+// Wait blocks until the caller may send one request or ctx is done. A nil
+// limiter never blocks. A failure to share the budget with other processes
+// is reported once on stderr.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	return l.wait(ctx, nil)
+}
+
+// wait is Wait reporting to logger, or to stderr if logger is nil. Clients
+// pass the logger set with WithLogger.
+func (l *RateLimiter) wait(ctx context.Context, logger *log.Logger) error {
+	if l == nil || l.rate <= 0 {
+		return nil
+	}
+	delay, err := l.reserve(time.Now())
+	if err != nil {
+		if logger == nil {
+			logger = log.New(os.Stderr, "", 0)
+		}
+		logger.Printf("Warning: rate limit is not shared with other processes: %v", err)
+	}
+	return sleepContext(ctx, delay)
+}
+
+// reserve takes one token and returns how long to wait before using it.
+// warning is the reason the limiter fell back to per-process limiting, the
+// first time that happens.
+func (l *RateLimiter) reserve(now time.Time) (delay time.Duration, warning error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.statePath == "" {
+		return l.take(&l.state, now), nil
+	}
+
+	unlock, err := lockFile(l.statePath + ".lock")
+	if err != nil {
+		return l.take(&l.state, now), l.warnOnce(err)
+	}
+	defer unlock()
+
+	state := l.readState(now)
+	delay = l.take(&state, now)
+	if err := l.writeState(state); err != nil {
+		return delay, l.warnOnce(err)
+	}
+	return delay, nil
+}
+
+func (l *RateLimiter) take(s *limiterState, now time.Time) time.Duration {
+	elapsed := now.Sub(time.Unix(0, s.Updated)).Seconds()
+	if elapsed > 0 {
+		s.Tokens = min(l.burst, s.Tokens+elapsed*l.rate)
+		s.Updated = now.UnixNano()
+	}
+	s.Tokens--
+	if s.Tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-s.Tokens / l.rate * float64(time.Second))
+}
+
+func (l *RateLimiter) readState(now time.Time) limiterState {
+	fresh := limiterState{Tokens: l.burst, Updated: now.UnixNano()}
+	data, err := os.ReadFile(l.statePath)
+	if err != nil {
+		return fresh
+	}
+	var s limiterState
+	if json.Unmarshal(data, &s) != nil || s.Updated == 0 {
+		return fresh
+	}
+	return s
+}
+
+// writeState replaces the state file atomically, so that a process that
+// breaks a stale lock never reads a half-written state.
+func (l *RateLimiter) writeState(s limiterState) error {
+	data, _ := json.Marshal(s)
+	return atomicfile.Write(l.statePath, data, 0600)
+}
+
+// warnOnce returns err the first time the limiter falls back to
+// per-process limiting, and nil afterwards.
+func (l *RateLimiter) warnOnce(err error) error {
+	if l.warned {
+		return nil
+	}
+	l.warned = true
+	return err
+}
+
+// lockFile takes an exclusive lock by creating path, breaking locks left
+// behind by crashed processes. It works on every platform without flock.
+// The lock file holds a token unique to its holder, so that neither an
+// unlock nor a break ever removes a lock someone else has taken since.
+func lockFile(path string) (unlock func(), err error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+	token := lockToken()
+	deadline := time.Now().Add(2 * staleLockAge)
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			_, err = f.WriteString(token)
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				os.Remove(path)
+				return nil, err
+			}
+			return func() { releaseLock(path, token) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, err
+		}
+		if info, statErr := os.Stat(path); statErr == nil && time.Since(info.ModTime()) > staleLockAge {
+			if holder, readErr := os.ReadFile(path); readErr == nil {
+				breakStaleLock(path, string(holder))
+			}
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for lock %s", path)
+		}
+		time.Sleep(2 * time.Millisecond)
+	}
+}
+
+// lockToken returns a token identifying one holder of a lock file.
+func lockToken() string {
+	return fmt.Sprintf("%d-%016x", os.Getpid(), rand.Uint64())
+}
+
+// releaseLock removes the lock file at path if it still holds token. A lock
+// that was broken as stale and taken by another process is left alone.
+func releaseLock(path, token string) {
+	if holder, err := os.ReadFile(path); err == nil && string(holder) == token {
+		os.Remove(path)
+	}
+}
+
+// breakStaleLock removes the lock file at path if it still belongs to the
+// stale holder. The lock is first moved aside, which only one process can
+// do, and checked there; a lock taken meanwhile by someone else is moved
+// back unless yet another process has locked path since.
+func breakStaleLock(path, holder string) {
+	aside := path + "." + lockToken() + ".stale"
+	if os.Rename(path, aside) != nil {
+		return
+	}
+	defer os.Remove(aside)
+	if moved, err := os.ReadFile(aside); err == nil && string(moved) != holder {
+		os.Link(aside, path)
+	}
+}
@@ -0,0 +1,175 @@
+package apiclient
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterTake(t *testing.T) {
+	start := time.Unix(1000, 0)
+	tests := []struct {
+		name   string
+		rate   float64
+		burst  int
+		tokens float64       // in the bucket at start
+		after  time.Duration // since start when the token is taken
+		wait   time.Duration
+		left   float64
+	}{
+		{"full bucket", 2, 4, 4, 0, 0, 3},
+		{"last token", 2, 4, 1, 0, 0, 0},
+		{"empty bucket", 2, 4, 0, 0, 500 * time.Millisecond, -1},
+		{"already reserved", 2, 4, -1, 0, time.Second, -2},
+		{"refilled meanwhile", 2, 4, 0, time.Second, 0, 1},
+		{"refill capped at burst", 2, 4, 0, time.Hour, 0, 3},
+		{"partial refill", 4, 1, -1, 250 * time.Millisecond, 250 * time.Millisecond, -1},
+	}
+	for _, tt := range tests {
+		l := NewRateLimiter(tt.rate, tt.burst, "")
+		s := limiterState{Tokens: tt.tokens, Updated: start.UnixNano()}
+		wait := l.take(&s, start.Add(tt.after))
+		if wait != tt.wait || s.Tokens != tt.left {
+			t.Errorf("%s: take = %s with %g tokens left; want %s with %g", tt.name, wait, s.Tokens, tt.wait, tt.left)
+		}
+	}
+}
+
+func TestRateLimiterReserveSpacesRequests(t *testing.T) {
+	l := NewRateLimiter(10, 2, "")
+	now := time.Now()
+	l.state.Updated = now.UnixNano()
+	var waits []time.Duration
+	for range 4 {
+		wait, warning := l.reserve(now)
+		if warning != nil {
+			t.Fatal(warning)
+		}
+		waits = append(waits, wait)
+	}
+	want := []time.Duration{0, 0, 100 * time.Millisecond, 200 * time.Millisecond}
+	for i := range want {
+		if waits[i] != want[i] {
+			t.Fatalf("waits = %v; want %v", waits, want)
+		}
+	}
+}
+
+func TestLockFile(t *testing.T) {
+	old := time.Now().Add(-2 * staleLockAge)
+	tests := []struct {
+		name   string
+		locked bool   // by a crashed process
+		holder string // its token
+	}{
+		{"free", false, ""},
+		{"stale", true, "crashed"},
+		{"stale and empty", true, ""},
+	}
+	for _, tt := range tests {
+		path := filepath.Join(t.TempDir(), "ratelimit.json.lock")
+		if tt.locked {
+			if err := os.WriteFile(path, []byte(tt.holder), 0600); err != nil {
+				t.Fatal(err)
+			}
+			os.Chtimes(path, old, old)
+		}
+		unlock, err := lockFile(path)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("%s: lock file missing while held: %v", tt.name, err)
+		}
+		unlock()
+		entries, _ := os.ReadDir(filepath.Dir(path))
+		if len(entries) != 0 {
+			t.Errorf("%s: %d files left after unlock", tt.name, len(entries))
+		}
+	}
+}
+
+func TestLockFileOwnership(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ratelimit.json.lock")
+	unlock, err := lockFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Another process broke our lock as stale and took it.
+	if err := os.WriteFile(path, []byte("other"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	unlock()
+	if data, err := os.ReadFile(path); err != nil || string(data) != "other" {
+		t.Errorf("unlock removed a lock held by another process (%q, %v)", data, err)
+	}
+
+	// A break racing with that process must not remove its lock either.
+	breakStaleLock(path, "crashed")
+	if data, err := os.ReadFile(path); err != nil || string(data) != "other" {
+		t.Errorf("breakStaleLock removed a live lock (%q, %v)", data, err)
+	}
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("%d files left; want only the lock", len(entries))
+	}
+}
+
+func TestLockFileExclusiveAfterStaleBreak(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ratelimit.json.lock")
+	if err := os.WriteFile(path, []byte("crashed"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-2 * staleLockAge)
+	os.Chtimes(path, old, old)
+
+	var holders atomic.Int32
+	var overlapped atomic.Bool
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 20 {
+				unlock, err := lockFile(path)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if holders.Add(1) > 1 {
+					overlapped.Store(true)
+				}
+				holders.Add(-1)
+				unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if overlapped.Load() {
+		t.Error("several goroutines held the lock at once")
+	}
+}
+
+func TestRateLimiterSharesStateFile(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "ratelimit.json")
+	now := time.Now()
+	a := NewRateLimiter(10, 1, statePath)
+	b := NewRateLimiter(10, 1, statePath)
+	for i, l := range []*RateLimiter{a, b, a} {
+		wait, warning := l.reserve(now)
+		if warning != nil {
+			t.Fatal(warning)
+		}
+		if want := time.Duration(i) * 100 * time.Millisecond; wait != want {
+			t.Errorf("request %d waits %s; want %s", i, wait, want)
+		}
+	}
+	entries, _ := os.ReadDir(filepath.Dir(statePath))
+	if len(entries) != 1 {
+		t.Errorf("%d files in the state directory; want only the state file", len(entries))
+	}
+}
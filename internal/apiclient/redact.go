@@ -0,0 +1,60 @@
+package apiclient
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// redactedKeys are JSON object keys whose values never appear in traces or
+// HAR files: the login password and the session token the API returns.
+var redactedKeys = map[string]bool{
+	"password":      true,
+	"session_token": true,
+}
+
+const redacted = "[REDACTED]"
+
+// redactJSON returns data with the values of redactedKeys replaced. Data that
+// is not JSON, or contains nothing to redact, is returned unchanged.
+func redactJSON(data []byte) []byte {
+	var v any
+	if len(data) == 0 || json.Unmarshal(data, &v) != nil || !redactValue(v) {
+		return data
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		return data
+	}
+	return out
+}
+
+// redactValue redacts v in place and reports whether it changed anything.
+func redactValue(v any) bool {
+	changed := false
+	switch v := v.(type) {
+	case map[string]any:
+		for k, child := range v {
+			if redactedKeys[k] {
+				v[k] = redacted
+				changed = true
+			} else if redactValue(child) {
+				changed = true
+			}
+		}
+	case []any:
+		for _, child := range v {
+			if redactValue(child) {
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+// redactHeader returns the value to log for a header.
+func redactHeader(name, value string) string {
+	if http.CanonicalHeaderKey(name) == "Authorization" {
+		return "Bearer " + redacted
+	}
+	return value
+}
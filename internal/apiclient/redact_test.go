@@ -0,0 +1,32 @@
+package apiclient
+
+import "testing"
+
+func TestRedactJSON(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{``, ``},
+		{`not json`, `not json`},
+		{`{"username":"u","password":"hunter2"}`, `{"password":"[REDACTED]","username":"u"}`},
+		{`{"status":"success","context":{"session_token":"abc","uid":1}}`, `{"context":{"session_token":"[REDACTED]","uid":1},"status":"success"}`},
+		{`[{"password":1},{"password":null}]`, `[{"password":"[REDACTED]"},{"password":"[REDACTED]"}]`},
+		// Nothing to redact: the input is kept byte for byte.
+		{`{ "b": 1, "a": "password" }`, `{ "b": 1, "a": "password" }`},
+		{`"password"`, `"password"`},
+	}
+	for _, tt := range tests {
+		if got := string(redactJSON([]byte(tt.in))); got != tt.want {
+			t.Errorf("redactJSON(%s) = %s; want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestRedactHeader(t *testing.T) {
+	if got := redactHeader("authorization", "Bearer secret"); got != "Bearer [REDACTED]" {
+		t.Errorf("Authorization redacted to %q", got)
+	}
+	if got := redactHeader("Content-Type", "application/json"); got != "application/json" {
+		t.Errorf("Content-Type changed to %q", got)
+	}
+}
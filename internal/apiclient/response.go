@@ -0,0 +1,120 @@
+package apiclient
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// DefaultMaxResponseSize bounds how much of a single response body a Client
+// reads. See WithMaxResponseSize.
+const DefaultMaxResponseSize = 64 << 20
+
+// maxCachedResponseSize is the largest body a streamed response may have and
+// still be stored in the cache; larger ones are forwarded but not kept, so
+// bulk exports run in constant memory.
+const maxCachedResponseSize = 4 << 20
+
+// maxErrorBodySize bounds how much of an error response is read into an
+// APIError.
+const maxErrorBodySize = 1 << 20
+
+// ErrResponseTooLarge is returned when a response body exceeds the client's
+// maximum response size.
+var ErrResponseTooLarge = errors.New("response exceeds the maximum response size")
+
+// responseBody is the body of a successful response as handed to callers. It
+// enforces the size limit, copies small responses into the cache once they
+// have been read completely, and traces the transfer when closed.
+type responseBody struct {
+	c      *Client
+	ctx    context.Context
+	resp   *http.Response
+	body   io.Reader
+	start  time.Time
+	limit  int64
+	read   int64
+	store  func(respBody []byte) // nil unless the response may be cached
+	buf    *bytes.Buffer         // copy of the body for store; nil once too large
+	closed bool
+}
+
+func newResponseBody(ctx context.Context, c *Client, resp *http.Response, start time.Time, limit int64) *responseBody {
+	b := &responseBody{c: c, ctx: ctx, resp: resp, body: resp.Body, start: start, limit: limit, buf: &bytes.Buffer{}}
+	if limit > 0 {
+		// One byte past the limit tells an oversized body from one that fits.
+		b.body = io.LimitReader(resp.Body, limit+1)
+	}
+	return b
+}
+
+func (b *responseBody) Read(p []byte) (int, error) {
+	n, err := b.body.Read(p)
+	b.read += int64(n)
+	if b.limit > 0 && b.read > b.limit {
+		n -= int(b.read - b.limit)
+		b.read = b.limit
+		b.buf = nil
+		return n, fmt.Errorf("%w (%d bytes)", ErrResponseTooLarge, b.limit)
+	}
+	if b.buf != nil {
+		b.buf.Write(p[:n])
+		if b.buf.Len() > maxCachedResponseSize {
+			b.buf = nil
+		}
+	}
+	switch {
+	case err == io.EOF:
+		if b.store != nil && b.buf != nil {
+			b.store(b.buf.Bytes())
+			b.store = nil
+		}
+	case err != nil && b.ctx.Err() == nil:
+		err = fmt.Errorf("failed to read response body: %w", &networkError{err})
+	}
+	return n, err
+}
+
+func (b *responseBody) Close() error {
+	if b.closed {
+		return nil
+	}
+	b.closed = true
+	b.c.tracef("<- %s in %s, %d bytes", b.resp.Status, time.Since(b.start).Round(time.Millisecond), b.read)
+	return b.resp.Body.Close()
+}
+
+// checkedBody is a response body that is handed to a check once it has
+// been read completely. An error from the check replaces io.EOF.
+type checkedBody struct {
+	io.ReadCloser
+	check func(body []byte) error // nil once called
+	buf   bytes.Buffer
+}
+
+func (b *checkedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.buf.Write(p[:n])
+	if err == io.EOF && b.check != nil {
+		check := b.check
+		b.check = nil
+		if checkErr := check(b.buf.Bytes()); checkErr != nil {
+			return n, checkErr
+		}
+	}
+	return n, err
+}
+
+// readErrorBody reads and closes the body of an error response.
+func readErrorBody(resp *http.Response) ([]byte, error) {
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", &networkError{err})
+	}
+	return body, nil
+}
@@ -0,0 +1,32 @@
+package apiclient
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecordersLimitResponseSize(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, strings.Repeat("x", 100))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	for _, rt := range []http.RoundTripper{
+		NewRecorder(filepath.Join(dir, "cassettes"), nil, 50),
+		NewHARRecorder(filepath.Join(dir, "out.har"), nil, 50),
+	} {
+		_, err := (&http.Client{Transport: rt}).Get(srv.URL)
+		if err == nil || !strings.Contains(err.Error(), ErrResponseTooLarge.Error()) {
+			t.Errorf("%T: error = %v; want ErrResponseTooLarge", rt, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "cassettes")); !os.IsNotExist(err) {
+		t.Errorf("an oversized response was recorded")
+	}
+}
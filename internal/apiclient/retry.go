@@ -0,0 +1,88 @@
+package apiclient
+
+import (
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryPolicy controls how failed requests are retried. Only requests that
+// are safe to repeat are retried (see isRetryableRequest).
+type RetryPolicy struct {
+	MaxRetries int           // retries after the first attempt; 0 disables retrying
+	BaseDelay  time.Duration // delay before the first retry, doubled on each retry
+	MaxWait    time.Duration // upper bound for a single wait, including Retry-After
+}
+
+// DefaultRetryPolicy retries twice, waiting at most 30s between attempts.
+var DefaultRetryPolicy = RetryPolicy{MaxRetries: 2, BaseDelay: 500 * time.Millisecond, MaxWait: 30 * time.Second}
+
+// readOnlyPOSTSuffixes lists POST endpoints that only read data. The API uses
+// POST for these because they take a JSON query, so repeating them is safe.
+var readOnlyPOSTSuffixes = []string{"/search", "/findByPrefix", "/findByExact", "/history"}
+
+// isRetryableRequest reports whether a request can be sent again without
+// side effects.
+func isRetryableRequest(method string, u *url.URL) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	case http.MethodPost:
+		for _, suffix := range readOnlyPOSTSuffixes {
+			if strings.HasSuffix(u.Path, suffix) {
+				return true
+			}
+		}
+		// POST /authors/{id}/series lists an author's series.
+		parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+		n := len(parts)
+		return n >= 3 && parts[n-3] == "authors" && parts[n-1] == "series"
+	}
+	return false
+}
+
+// isRetryableStatus reports whether a response status is worth retrying.
+func isRetryableStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests,
+		http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// backoff returns the jittered delay before retry number attempt (0-based).
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay << attempt
+	if delay <= 0 || delay > p.MaxWait {
+		delay = p.MaxWait
+	}
+	// Equal jitter: wait at least delay/2 and at most delay, so concurrent
+	// clients spread out without ever retrying right away.
+	return delay/2 + time.Duration(rand.Int64N(int64(delay/2)+1))
+}
+
+// parseRetryAfter decodes a Retry-After header given either in seconds or as
+// an HTTP date. ok is false when the header is absent or malformed.
+func parseRetryAfter(value string, now time.Time) (wait time.Duration, ok bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if wait := at.Sub(now); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
@@ -0,0 +1,56 @@
+package apiclient
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value  string
+		wait   time.Duration
+		wantOK bool
+	}{
+		{"", 0, false},
+		{"   ", 0, false},
+		{"0", 0, true},
+		{"5", 5 * time.Second, true},
+		{" 120 ", 2 * time.Minute, true},
+		{"-1", 0, false},
+		{"1.5", 0, false},
+		{"soon", 0, false},
+		{now.Add(90 * time.Second).Format(http.TimeFormat), 90 * time.Second, true},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0, true},
+	}
+	for _, tt := range tests {
+		wait, ok := parseRetryAfter(tt.value, now)
+		if wait != tt.wait || ok != tt.wantOK {
+			t.Errorf("parseRetryAfter(%q) = %s, %v; want %s, %v", tt.value, wait, ok, tt.wait, tt.wantOK)
+		}
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	p := RetryPolicy{MaxRetries: 10, BaseDelay: 100 * time.Millisecond, MaxWait: time.Second}
+	tests := []struct {
+		attempt int
+		delay   time.Duration // the wait is in [delay/2, delay]
+	}{
+		{0, 100 * time.Millisecond},
+		{1, 200 * time.Millisecond},
+		{3, 800 * time.Millisecond},
+		{4, time.Second}, // capped by MaxWait
+		{40, time.Second},
+		{70, time.Second}, // the shift overflows
+	}
+	for _, tt := range tests {
+		for range 50 {
+			wait := p.backoff(tt.attempt)
+			if wait < tt.delay/2 || wait > tt.delay {
+				t.Fatalf("backoff(%d) = %s; want between %s and %s", tt.attempt, wait, tt.delay/2, tt.delay)
+			}
+		}
+	}
+}
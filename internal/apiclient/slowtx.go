@@ -0,0 +1,175 @@
+package apiclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultTransactionWaitTimeout bounds how long a Client follows a slow
+// transaction before giving up.
+const DefaultTransactionWaitTimeout = 5 * time.Minute
+
+var (
+	// ErrTransactionTimeout is returned when a slow transaction is still
+	// pending after the wait timeout.
+	ErrTransactionTimeout = errors.New("timed out waiting for slow transaction")
+	// ErrTransactionFailed is returned when a slow transaction finished with
+	// an error status.
+	ErrTransactionFailed = errors.New("slow transaction failed")
+)
+
+// finalTransactionStatuses are the "status" values of a slow transaction
+// that has finished. The spec leaves the status of an ApiResponseV1 free
+// form; these are the ones the API answers finished requests with, and the
+// status endpoint reports any other value (or 202 Accepted) while the
+// transaction is still running. An unknown status is therefore waited on,
+// up to the timeout, rather than taken as the result.
+var finalTransactionStatuses = map[string]bool{
+	"success":   true,
+	"error":     true,
+	"exception": true,
+}
+
+// WaitOptions controls how a slow transaction is polled.
+type WaitOptions struct {
+	// Timeout bounds the whole wait; zero means DefaultTransactionWaitTimeout.
+	Timeout time.Duration
+	// Progress, if set, is called after every poll that found the
+	// transaction still pending.
+	Progress func(id, status, reason string, elapsed time.Duration)
+}
+
+// slowTransactionID returns the transaction ID a write response hands back
+// when the API finishes the work asynchronously, or "" if there is none. The
+// ID is looked for in context.transaction_id and at the top level.
+func slowTransactionID(body []byte) string {
+	if !bytes.HasPrefix(bytes.TrimSpace(body), []byte("{")) {
+		return ""
+	}
+	var resp struct {
+		TransactionID string `json:"transaction_id"`
+		Context       struct {
+			TransactionID string `json:"transaction_id"`
+		} `json:"context"`
+	}
+	if json.Unmarshal(body, &resp) != nil {
+		return ""
+	}
+	if resp.Context.TransactionID != "" {
+		return resp.Context.TransactionID
+	}
+	return resp.TransactionID
+}
+
+// WaitForTransaction polls the status of slow transaction id with backoff
+// until it reaches a terminal state, and returns the final status response.
+// A transaction that finishes with status "error" or "exception" is reported
+// as ErrTransactionFailed alongside the body.
+func (c *Client) WaitForTransaction(ctx context.Context, id string, opts WaitOptions) ([]byte, error) {
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTransactionWaitTimeout
+	}
+	fullURL, err := c.BuildURL("/misc/slow-transaction-status/"+url.PathEscape(id), nil)
+	if err != nil {
+		return nil, err
+	}
+	// Polling must never trigger another follow.
+	poller := *c
+	poller.followTransactions = false
+
+	start := time.Now()
+	deadline := start.Add(opts.Timeout)
+	delay := time.Second
+	for {
+		respBody, statusCode, err := poller.Do(ctx, "GET", fullURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		var status struct {
+			Status string `json:"status"`
+			Reason string `json:"reason"`
+		}
+		if err := json.Unmarshal(respBody, &status); err != nil && statusCode != http.StatusAccepted {
+			return respBody, fmt.Errorf("failed to decode the status of slow transaction %s: %w", id, err)
+		}
+		pending := statusCode == http.StatusAccepted || !finalTransactionStatuses[strings.ToLower(status.Status)]
+		if !pending {
+			if s := strings.ToLower(status.Status); s == "error" || s == "exception" {
+				return respBody, fmt.Errorf("%w: %s: %s", ErrTransactionFailed, id, status.Reason)
+			}
+			return respBody, nil
+		}
+
+		elapsed := time.Since(start)
+		if opts.Progress != nil {
+			opts.Progress(id, status.Status, status.Reason, elapsed)
+		}
+		// The last sleep is cut short so that the final poll happens at
+		// the deadline.
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return respBody, fmt.Errorf("%w %s after %s", ErrTransactionTimeout, id, elapsed.Round(time.Second))
+		}
+		if err := sleepContext(ctx, min(delay, remaining)); err != nil {
+			return nil, err
+		}
+		delay = min(delay*3/2, 10*time.Second)
+	}
+}
+
+// isJSONResponse reports whether resp may hold a JSON document: it says so
+// in its Content-Type, or has none.
+func isJSONResponse(resp *http.Response) bool {
+	contentType := resp.Header.Get("Content-Type")
+	if contentType == "" {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && (mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"))
+}
+
+// peekTransactionID reads a successful write response far enough to find
+// the slow transaction ID it carries, if any, and returns the ID with the
+// body rewound. Only small bodies are examined; anything larger cannot be a
+// transaction handle and is passed through untouched.
+func peekTransactionID(body io.ReadCloser) (string, io.ReadCloser, error) {
+	head, err := io.ReadAll(io.LimitReader(body, maxErrorBodySize+1))
+	if err != nil {
+		body.Close()
+		return "", nil, err
+	}
+	if len(head) > maxErrorBodySize {
+		return "", struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(head), body), body}, nil
+	}
+	body.Close()
+	return slowTransactionID(head), io.NopCloser(bytes.NewReader(head)), nil
+}
+
+// followTransaction waits for the slow transaction id named by a write
+// response and returns its final status in place of the response.
+func (c *Client) followTransaction(ctx context.Context, id string) (io.ReadCloser, error) {
+	c.tracef("following slow transaction %s", id)
+	opts := c.transactionWait
+	if opts.Progress == nil {
+		opts.Progress = func(id, status, _ string, elapsed time.Duration) {
+			c.logger.Printf("Waiting for slow transaction %s (%s, %s elapsed)...", id, status, elapsed.Round(time.Second))
+		}
+	}
+	final, err := c.WaitForTransaction(ctx, id, opts)
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(final)), nil
+}
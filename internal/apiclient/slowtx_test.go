@@ -0,0 +1,104 @@
+package apiclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTransactionServer answers the nth poll of a slow transaction status
+// with statuses[n], repeating the last one, and write requests with write.
+func newTransactionServer(t *testing.T, write string, statuses ...string) (*Client, *atomic.Int32) {
+	t.Helper()
+	var polls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Content-Type", "application/json")
+			io.WriteString(w, write)
+			return
+		}
+		n := int(polls.Add(1)) - 1
+		body := statuses[min(n, len(statuses)-1)]
+		if strings.HasPrefix(body, "{") {
+			w.Header().Set("Content-Type", "application/json")
+		}
+		io.WriteString(w, body)
+	}))
+	t.Cleanup(srv.Close)
+	client, err := New(WithBaseURL(srv.URL), WithLogger(log.New(io.Discard, "", 0)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, &polls
+}
+
+func TestWaitForTransaction(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []string
+		timeout  time.Duration
+		wantErr  error
+		wantPoll int32
+	}{
+		{"success", []string{`{"status":"success","reason":"done"}`}, time.Minute, nil, 1},
+		{"failed", []string{`{"status":"exception","reason":"boom"}`}, time.Minute, ErrTransactionFailed, 1},
+		{"unknown status is pending", []string{`{"status":"queued"}`, `{"status":"Success"}`}, time.Minute, nil, 2},
+		{"not JSON", []string{`<html>oops</html>`}, time.Minute, errors.New("failed to decode"), 1},
+		// The second sleep is cut to the deadline and followed by a last poll.
+		{"final poll at deadline", []string{`{"status":"pending"}`, `{"status":"pending"}`, `{"status":"success"}`}, 1500 * time.Millisecond, nil, 3},
+		{"timeout", []string{`{"status":"pending"}`}, 500 * time.Millisecond, ErrTransactionTimeout, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, polls := newTransactionServer(t, "", tt.statuses...)
+			_, err := client.WaitForTransaction(context.Background(), "tx1", WaitOptions{
+				Timeout:  tt.timeout,
+				Progress: func(string, string, string, time.Duration) {},
+			})
+			switch {
+			case tt.wantErr == nil && err != nil:
+				t.Errorf("err = %v; want nil", err)
+			case tt.wantErr != nil && (err == nil || !errors.Is(err, tt.wantErr) && !strings.Contains(err.Error(), tt.wantErr.Error())):
+				t.Errorf("err = %v; want %v", err, tt.wantErr)
+			}
+			if got := polls.Load(); got != tt.wantPoll {
+				t.Errorf("polled %d times; want %d", got, tt.wantPoll)
+			}
+		})
+	}
+}
+
+func TestFollowTransactionOnlyWithID(t *testing.T) {
+	tests := []struct {
+		name      string
+		write     string
+		want      string
+		wantPolls int32
+	}{
+		{"no transaction", `{"status":"success","reason":"Added"}`, `{"status":"success","reason":"Added"}`, 0},
+		{"context ID", `{"status":"success","context":{"transaction_id":"tx1"}}`, `{"status":"success","reason":"finished"}`, 1},
+		{"top-level ID", `{"transaction_id":"tx1"}`, `{"status":"success","reason":"finished"}`, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, polls := newTransactionServer(t, tt.write, `{"status":"success","reason":"finished"}`)
+			body, _, err := client.Do(context.Background(), http.MethodPost, client.BaseURL()+"/lists/series", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(body) != tt.want {
+				t.Errorf("body = %s; want %s", body, tt.want)
+			}
+			if got := polls.Load(); got != tt.wantPolls {
+				t.Errorf("polled %d times; want %d", got, tt.wantPolls)
+			}
+		})
+	}
+}
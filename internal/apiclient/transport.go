@@ -0,0 +1,92 @@
+package apiclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// TransportConfig describes how to reach the API from restricted networks.
+// The zero value behaves like http.DefaultTransport.
+type TransportConfig struct {
+	// Proxy is an http, https or socks5 proxy URL. Empty means the
+	// HTTP_PROXY/HTTPS_PROXY/NO_PROXY environment variables apply.
+	Proxy string
+	// CAFile is a PEM bundle trusted in addition to the system roots.
+	CAFile string
+	// InsecureSkipVerify disables TLS certificate verification entirely.
+	InsecureSkipVerify bool
+}
+
+// ValidateProxyURL checks that rawURL is a proxy URL the transport supports.
+func ValidateProxyURL(rawURL string) (*url.URL, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL %q: %w", rawURL, err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("invalid proxy URL %q: scheme must be http, https, socks5 or socks5h", rawURL)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL %q: missing host", rawURL)
+	}
+	return u, nil
+}
+
+// LoadCAFile reads a PEM bundle and returns the system roots plus its
+// certificates, along with how many certificates it added.
+func LoadCAFile(path string) (*x509.CertPool, int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read CA file: %w", err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	added := 0
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid certificate in CA file %s: %w", path, err)
+		}
+		pool.AddCert(cert)
+		added++
+	}
+	if added == 0 {
+		return nil, 0, fmt.Errorf("CA file %s contains no PEM certificates", path)
+	}
+	return pool, added, nil
+}
+
+// NewTransport returns a copy of http.DefaultTransport configured by cfg.
+func NewTransport(cfg TransportConfig) (*http.Transport, error) {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	if cfg.Proxy != "" {
+		proxyURL, err := ValidateProxyURL(cfg.Proxy)
+		if err != nil {
+			return nil, err
+		}
+		t.Proxy = http.ProxyURL(proxyURL)
+	}
+	if cfg.CAFile != "" || cfg.InsecureSkipVerify {
+		t.TLSClientConfig = &tls.Config{InsecureSkipVerify: cfg.InsecureSkipVerify}
+		if cfg.CAFile != "" {
+			pool, _, err := LoadCAFile(cfg.CAFile)
+			if err != nil {
+				return nil, err
+			}
+			t.TLSClientConfig.RootCAs = pool
+		}
+	}
+	return t, nil
+}
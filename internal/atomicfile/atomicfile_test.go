@@ -0,0 +1,33 @@
+package atomicfile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWrite(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state.json")
+	for _, content := range []string{"first", "second"} {
+		if err := Write(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil || string(data) != content {
+			t.Errorf("after Write(%q) the file holds %q, %v", content, data, err)
+		}
+	}
+	info, err := os.Stat(path)
+	if err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("file mode = %v, %v; want 0600", info.Mode(), err)
+	}
+	// No temporary file is left behind.
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("directory holds %d files; want 1", len(entries))
+	}
+
+	if err := Write(filepath.Join(dir, "missing", "state.json"), nil, 0600); err == nil {
+		t.Error("Write into a missing directory succeeded")
+	}
+}
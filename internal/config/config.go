@@ -0,0 +1,187 @@
+// internal/config/config.go
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/TheDucker1/mangaupdatescli/internal/atomicfile"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// configFileName is the file inside Dir() that holds the persisted CLI state.
+const configFileName = "config.json"
+
+// DefaultProfile is the profile used when none has been selected.
+const DefaultProfile = "default"
+
+// Config is the persisted CLI state. Profiles contain session tokens, so it
+// is always written with owner-only permissions.
+type Config struct {
+	CurrentProfile string              `json:"current_profile,omitempty"`
+	Profiles       map[string]*Profile `json:"profiles,omitempty"`
+
+	// Username and SessionToken are the pre-profile layout. Load moves them
+	// into the default profile; they are never written back.
+	Username     string `json:"username,omitempty"`
+	SessionToken string `json:"session_token,omitempty"`
+}
+
+// Profile holds the credentials and settings of one named profile.
+type Profile struct {
+	Username     string `json:"username,omitempty"`
+	SessionToken string `json:"session_token,omitempty"`
+	// SessionAPIURL is the API root that issued SessionToken; the token is
+	// only sent there. "" is the production API, for sessions stored
+	// before it was recorded.
+	SessionAPIURL string `json:"session_api_url,omitempty"`
+	APIURL        string `json:"api_url,omitempty"` // overrides the production API root
+
+	// Outbound network settings; the matching global flags take precedence.
+	Proxy              string `json:"proxy,omitempty"`
+	CAFile             string `json:"ca_file,omitempty"`
+	InsecureSkipVerify bool   `json:"insecure_skip_verify,omitempty"`
+	Timeout            string `json:"timeout,omitempty"` // a time.Duration string
+}
+
+// selectedProfile is the per-process override set by --profile.
+var selectedProfile string
+
+// SelectProfile makes name the active profile for this process without
+// changing the stored current profile.
+func SelectProfile(name string) {
+	selectedProfile = name
+}
+
+// ActiveProfileName returns the profile used by this process: the --profile
+// override, then $MANGAUPDATESCLI_PROFILE, then the stored current profile.
+func (c *Config) ActiveProfileName() string {
+	if selectedProfile != "" {
+		return selectedProfile
+	}
+	if env := os.Getenv("MANGAUPDATESCLI_PROFILE"); env != "" {
+		return env
+	}
+	if c.CurrentProfile != "" {
+		return c.CurrentProfile
+	}
+	return DefaultProfile
+}
+
+// ActiveProfile returns the active profile, adding it to c if it does not
+// exist yet. Callers that modify it must Save the config.
+func (c *Config) ActiveProfile() *Profile {
+	name := c.ActiveProfileName()
+	if p, ok := c.Profiles[name]; ok {
+		return p
+	}
+	if c.Profiles == nil {
+		c.Profiles = make(map[string]*Profile)
+	}
+	p := &Profile{}
+	c.Profiles[name] = p
+	return p
+}
+
+// ProfileNames returns the stored profile names in sorted order.
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidProfileName reports whether name can be used as a profile name.
+func ValidProfileName(name string) bool {
+	if name == "" || len(name) > 64 {
+		return false
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.') {
+			return false
+		}
+	}
+	return true
+}
+
+// Dir returns the per-user configuration directory. MANGAUPDATESCLI_CONFIG_DIR
+// overrides the platform default (e.g. ~/.config/mangaupdatescli).
+func Dir() (string, error) {
+	if dir := os.Getenv("MANGAUPDATESCLI_CONFIG_DIR"); dir != "" {
+		return dir, nil
+	}
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate user config directory: %w", err)
+	}
+	return filepath.Join(base, "mangaupdatescli"), nil
+}
+
+// CacheDir returns the per-user directory for disposable state such as the
+// shared rate limiter. MANGAUPDATESCLI_CACHE_DIR overrides the platform
+// default (e.g. ~/.cache/mangaupdatescli).
+func CacheDir() (string, error) {
+	if dir := os.Getenv("MANGAUPDATESCLI_CACHE_DIR"); dir != "" {
+		return dir, nil
+	}
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate user cache directory: %w", err)
+	}
+	return filepath.Join(base, "mangaupdatescli"), nil
+}
+
+// Load reads the config file. A missing file yields an empty Config.
+func Load() (*Config, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, configFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Config{}, nil
+		}
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+	if cfg.SessionToken != "" || cfg.Username != "" {
+		if cfg.Profiles == nil {
+			cfg.Profiles = make(map[string]*Profile)
+		}
+		if _, exists := cfg.Profiles[DefaultProfile]; !exists {
+			cfg.Profiles[DefaultProfile] = &Profile{Username: cfg.Username, SessionToken: cfg.SessionToken}
+		}
+		cfg.Username, cfg.SessionToken = "", ""
+	}
+	return &cfg, nil
+}
+
+// Save writes the config file atomically, creating the directory if needed.
+func Save(cfg *Config) error {
+	dir, err := Dir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	if err := atomicfile.Write(filepath.Join(dir, configFileName), data, 0600); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
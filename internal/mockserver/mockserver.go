@@ -0,0 +1,241 @@
+// Package mockserver serves the API as an OpenAPI spec describes it, so that the
+// CLI and scripts using it can be developed and tested without the network.
+package mockserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/TheDucker1/mangaupdatescli/internal/openapi"
+	"log"
+	"math/rand/v2"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Rule is an injected error or delay. It applies to the requests of
+// Operation, or of every operation if that is empty, with probability Rate.
+type Rule struct {
+	Operation string
+	Status    int           // of an error rule
+	Latency   time.Duration // of a latency rule
+	Rate      float64       // 0 < Rate <= 1
+}
+
+// ParseErrorRule parses an error rule written as [operationId:]status[=rate],
+// e.g. 503, 503=0.25 or retrieveSeries:404.
+func ParseErrorRule(s string) (Rule, error) {
+	return parseRule(s, func(r *Rule, value string) error {
+		status, err := strconv.Atoi(value)
+		if err != nil || status < 400 || status > 599 {
+			return fmt.Errorf("%q is not an error status (400-599)", value)
+		}
+		r.Status = status
+		return nil
+	})
+}
+
+// ParseLatencyRule parses a latency rule written as
+// [operationId:]duration[=rate], e.g. 300ms or retrieveSeries:2s=0.1.
+func ParseLatencyRule(s string) (Rule, error) {
+	return parseRule(s, func(r *Rule, value string) error {
+		d, err := time.ParseDuration(value)
+		if err != nil || d < 0 {
+			return fmt.Errorf("%q is not a duration", value)
+		}
+		r.Latency = d
+		return nil
+	})
+}
+
+func parseRule(s string, setValue func(r *Rule, value string) error) (Rule, error) {
+	r := Rule{Rate: 1}
+	if op, rest, ok := strings.Cut(s, ":"); ok {
+		r.Operation, s = op, rest
+	}
+	value, rate, hasRate := strings.Cut(s, "=")
+	if err := setValue(&r, value); err != nil {
+		return Rule{}, err
+	}
+	if hasRate {
+		x, err := strconv.ParseFloat(rate, 64)
+		if err != nil || x <= 0 || x > 1 {
+			return Rule{}, fmt.Errorf("rate %q must be a number in (0, 1]", rate)
+		}
+		r.Rate = x
+	}
+	return r, nil
+}
+
+// Server is an http.Handler that answers every operation of Spec. A
+// response comes from a fixture file if there is one, and otherwise from
+// the example the spec gives or data synthesized from the response schema.
+//
+// Fixtures are looked up in the Fixtures directory as
+// <operationId>/<path parameters joined by _>.json, then <operationId>.json.
+// A fixture holds the response body, or a cassette as saved by --record,
+// which also sets the status and headers.
+type Server struct {
+	Spec     *openapi.Spec
+	Fixtures string // "" for none
+	Errors   []Rule // the first one that fires answers with its status
+	Latency  []Rule // the first one that fires delays the response
+	Logger   *log.Logger
+
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+// NewServer returns a Server for spec whose rules fire at random, seeded by
+// seed; the same seed makes the same requests fail.
+func NewServer(spec *openapi.Spec, seed uint64) *Server {
+	return &Server{
+		Spec:   spec,
+		Logger: log.New(os.Stderr, "", log.Ltime),
+		rand:   rand.New(rand.NewPCG(seed, seed)),
+	}
+}
+
+// fixture is a response read from the fixtures directory.
+type fixture struct {
+	Status  int         `json:"status"`
+	Headers http.Header `json:"headers"`
+	Body    *string     `json:"body"`
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	path := r.URL.Path
+	if rest, ok := strings.CutPrefix(path, s.Spec.BasePath); ok && (rest == "" || rest[0] == '/') {
+		path = rest
+	}
+	op, values, ok := s.Spec.Match(r.Method, path)
+	if !ok {
+		s.writeError(w, http.StatusNotFound, fmt.Sprintf("%s %s is not in the OpenAPI spec", r.Method, path))
+		s.Logger.Printf("%s %s -> 404 (not in the spec)", r.Method, r.URL.Path)
+		return
+	}
+
+	if rule, ok := s.fire(s.Latency, op.ID); ok {
+		select {
+		case <-time.After(rule.Latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	source := "example"
+	var resp openapi.Response
+	switch rule, injected := s.fire(s.Errors, op.ID); {
+	case injected:
+		resp, source = s.errorResponse(op, rule.Status, "injected error"), "injected"
+	case op.Auth && !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer "):
+		resp, source = s.errorResponse(op, http.StatusUnauthorized, "this operation requires a session token"), "no session"
+	default:
+		name, f, err := s.fixture(op, values)
+		switch {
+		case err != nil:
+			resp, source = s.errorResponse(op, http.StatusInternalServerError, err.Error()), "bad fixture"
+		case name != "":
+			resp, source = openapi.Response{Status: f.Status, ContentType: "application/json", Body: []byte(*f.Body)}, name
+			if t := f.Headers.Get("Content-Type"); t != "" {
+				resp.ContentType = t
+			}
+		default:
+			resp, _ = s.Spec.Response(op, 0)
+			if resp.Status == 0 {
+				resp.Status = http.StatusOK
+			}
+		}
+	}
+
+	if resp.ContentType != "" {
+		w.Header().Set("Content-Type", resp.ContentType)
+	}
+	if resp.Status == http.StatusTooManyRequests || resp.Status == http.StatusServiceUnavailable {
+		w.Header().Set("Retry-After", "1")
+	}
+	w.WriteHeader(resp.Status)
+	w.Write(resp.Body)
+	s.Logger.Printf("%s %s -> %s %d (%s) in %s", r.Method, r.URL.Path, op.ID, resp.Status, source, time.Since(start).Round(time.Millisecond))
+}
+
+// fire returns the first of rules for the operation opID that fires.
+func (s *Server) fire(rules []Rule, opID string) (Rule, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, rule := range rules {
+		if rule.Operation != "" && rule.Operation != opID {
+			continue
+		}
+		if rule.Rate >= 1 || s.rand.Float64() < rule.Rate {
+			return rule, true
+		}
+	}
+	return Rule{}, false
+}
+
+// errorResponse returns the response op declares for status, or an
+// ApiResponseV1-style body giving reason if it declares none.
+func (s *Server) errorResponse(op openapi.Operation, status int, reason string) openapi.Response {
+	if resp, ok := s.Spec.Response(op, status); ok && len(resp.Body) > 0 {
+		return resp
+	}
+	return openapi.Response{Status: status, ContentType: "application/json", Body: errorBody(status, reason)}
+}
+
+func (s *Server) writeError(w http.ResponseWriter, status int, reason string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(errorBody(status, reason))
+}
+
+func errorBody(status int, reason string) []byte {
+	body, _ := json.Marshal(map[string]interface{}{
+		"status":  "exception",
+		"reason":  fmt.Sprintf("%s: %s", http.StatusText(status), reason),
+		"context": map[string]interface{}{},
+	})
+	return body
+}
+
+// fixture reads the fixture for a request of op with the given path
+// parameter values. name is "" if there is none.
+func (s *Server) fixture(op openapi.Operation, values map[string]string) (name string, f fixture, err error) {
+	if s.Fixtures == "" {
+		return "", fixture{}, nil
+	}
+	var candidates []string
+	var params []string
+	for _, segment := range strings.Split(op.Path, "/") {
+		if p, ok := strings.CutPrefix(segment, "{"); ok {
+			params = append(params, values[strings.TrimSuffix(p, "}")])
+		}
+	}
+	if len(params) > 0 {
+		candidates = append(candidates, filepath.Join(s.Fixtures, op.ID, strings.Join(params, "_")+".json"))
+	}
+	candidates = append(candidates, filepath.Join(s.Fixtures, op.ID+".json"))
+
+	for _, name := range candidates {
+		data, err := os.ReadFile(name)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return "", fixture{}, err
+		}
+		// A cassette sets the status and headers; anything else is the body.
+		if json.Unmarshal(data, &f) == nil && f.Status > 0 && f.Body != nil {
+			return name, f, nil
+		}
+		body := string(data)
+		return name, fixture{Status: http.StatusOK, Body: &body}, nil
+	}
+	return "", fixture{}, nil
+}
@@ -0,0 +1,339 @@
+package mockserver
+
+import (
+	"context"
+	"errors"
+	"github.com/TheDucker1/mangaupdatescli/internal/openapi"
+	"github.com/TheDucker1/mangaupdatescli/internal/utils"
+	"github.com/TheDucker1/mangaupdatescli/mangaupdates"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testSpec = `
+openapi: 3.0.3
+info: {title: Test API, version: "1.0"}
+servers: [{url: "https://api.example.com/v1"}]
+paths:
+  /series/{id}:
+    get:
+      tags: [series]
+      operationId: retrieveSeries
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: integer, format: int64}}
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema: {$ref: "#/components/schemas/SeriesModelV1"}
+        "404":
+          description: not found
+          content:
+            application/json:
+              schema: {$ref: "#/components/schemas/ApiResponseV1"}
+components:
+  schemas:
+    SeriesModelV1:
+      type: object
+      properties:
+        series_id: {type: integer, format: int64, example: 42}
+        title: {type: string, example: Berserk}
+        completed: {type: boolean}
+    ApiResponseV1:
+      type: object
+      properties:
+        status: {type: string}
+        reason: {type: string}
+`
+
+// testServer serves the test spec and counts the requests it gets. Its
+// Server is only touched while mu is held, so tests can change the rules
+// between requests.
+type testServer struct {
+	*httptest.Server
+
+	mu           sync.Mutex
+	mock         *Server
+	requests     int
+	afterRequest func(s *Server) // if set, runs after each request
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "openapi.yaml")
+	if err := os.WriteFile(path, []byte(testSpec), 0600); err != nil {
+		t.Fatal(err)
+	}
+	spec, err := openapi.LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := &testServer{mock: NewServer(spec, 1)}
+	ts.mock.Logger = log.New(io.Discard, "", 0)
+	ts.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ts.mu.Lock()
+		defer ts.mu.Unlock()
+		ts.requests++
+		ts.mock.ServeHTTP(w, r)
+		if ts.afterRequest != nil {
+			ts.afterRequest(ts.mock)
+		}
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+// update runs fn with the server locked, between requests.
+func (ts *testServer) update(fn func(ts *testServer)) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	fn(ts)
+}
+
+func (ts *testServer) requestCount() int {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.requests
+}
+
+var quietLogger = log.New(io.Discard, "", 0)
+
+func TestRetryOnInjectedError(t *testing.T) {
+	ts := newTestServer(t)
+	// The first request fails with 503 and Retry-After: 1, later ones succeed.
+	ts.update(func(ts *testServer) {
+		ts.mock.Errors = []Rule{{Operation: "retrieveSeries", Status: 503, Rate: 1}}
+		ts.afterRequest = func(s *Server) { s.Errors = nil }
+	})
+
+	client, err := mangaupdates.New(
+		mangaupdates.WithBaseURL(ts.URL+"/v1"),
+		mangaupdates.WithLogger(quietLogger),
+		mangaupdates.WithRetryPolicy(mangaupdates.RetryPolicy{MaxRetries: 2, BaseDelay: time.Millisecond, MaxWait: 2 * time.Second}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	series, err := client.RetrieveSeries(context.Background(), 42, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if series.SeriesID != 42 || series.Title != "Berserk" {
+		t.Errorf("RetrieveSeries = %+v; want the example series", series)
+	}
+	if n := ts.requestCount(); n != 2 {
+		t.Errorf("server got %d requests; want 2", n)
+	}
+}
+
+func TestInjectedErrorWithoutRetries(t *testing.T) {
+	ts := newTestServer(t)
+	ts.update(func(ts *testServer) { ts.mock.Errors = []Rule{{Status: 503, Rate: 1}} })
+
+	client, err := mangaupdates.New(
+		mangaupdates.WithBaseURL(ts.URL+"/v1"),
+		mangaupdates.WithLogger(quietLogger),
+		mangaupdates.WithRetryPolicy(mangaupdates.RetryPolicy{MaxWait: time.Second}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.RetrieveSeries(context.Background(), 42, nil)
+	var apiErr *mangaupdates.APIError
+	if !errors.Is(err, mangaupdates.ErrServer) || !errors.As(err, &apiErr) || apiErr.StatusCode != 503 {
+		t.Fatalf("RetrieveSeries error = %v; want a 503 APIError", err)
+	}
+	if n := ts.requestCount(); n != 1 {
+		t.Errorf("server got %d requests; want 1", n)
+	}
+}
+
+// serve sends a request for method and path to s and returns the status
+// and body of its response.
+func serve(s *Server, method, path string) (int, string) {
+	r := httptest.NewRequest(method, path, nil)
+	r.Header.Set("Authorization", "Bearer token")
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, r)
+	return w.Code, w.Body.String()
+}
+
+func TestParseRules(t *testing.T) {
+	tests := []struct {
+		s       string
+		latency bool
+		want    Rule
+		wantErr string
+	}{
+		{s: "503", want: Rule{Status: 503, Rate: 1}},
+		{s: "503=0.25", want: Rule{Status: 503, Rate: 0.25}},
+		{s: "retrieveSeries:404", want: Rule{Operation: "retrieveSeries", Status: 404, Rate: 1}},
+		{s: "retrieveSeries:429=1", want: Rule{Operation: "retrieveSeries", Status: 429, Rate: 1}},
+		{s: "200", wantErr: "not an error status"},
+		{s: "600", wantErr: "not an error status"},
+		{s: "abc", wantErr: "not an error status"},
+		{s: "retrieveSeries:", wantErr: "not an error status"},
+		{s: "503=0", wantErr: "must be a number in (0, 1]"},
+		{s: "503=1.5", wantErr: "must be a number in (0, 1]"},
+		{s: "503=half", wantErr: "must be a number in (0, 1]"},
+		{s: "300ms", latency: true, want: Rule{Latency: 300 * time.Millisecond, Rate: 1}},
+		{s: "retrieveSeries:2s=0.1", latency: true, want: Rule{Operation: "retrieveSeries", Latency: 2 * time.Second, Rate: 0.1}},
+		{s: "0s", latency: true, want: Rule{Rate: 1}},
+		{s: "300", latency: true, wantErr: "not a duration"},
+		{s: "-1s", latency: true, wantErr: "not a duration"},
+		{s: "1s=-0.5", latency: true, wantErr: "must be a number in (0, 1]"},
+	}
+	for _, tt := range tests {
+		parse, name := ParseErrorRule, "ParseErrorRule"
+		if tt.latency {
+			parse, name = ParseLatencyRule, "ParseLatencyRule"
+		}
+		got, err := parse(tt.s)
+		switch {
+		case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+			t.Errorf("%s(%q) error = %v; want one containing %q", name, tt.s, err, tt.wantErr)
+		case tt.wantErr == "" && (err != nil || got != tt.want):
+			t.Errorf("%s(%q) = %+v, %v; want %+v", name, tt.s, got, err, tt.want)
+		}
+	}
+}
+
+func TestFixtureLookupOrder(t *testing.T) {
+	ts := newTestServer(t)
+	dir := t.TempDir()
+	files := map[string]string{
+		// A body for one series.
+		"retrieveSeries/7.json": `{"series_id":7,"title":"Seven"}`,
+		// A cassette, as saved by --record, for the others.
+		"retrieveSeries.json": `{"status":404,"headers":{"Content-Type":["application/problem+json"]},"body":"{\"reason\":\"recorded\"}"}`,
+		// Not a cassette, so the whole file is the body.
+		"retrieveGenres.json": `{"status":"success"}`,
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		os.MkdirAll(filepath.Dir(path), 0700)
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		path       string
+		fixtures   string
+		wantStatus int
+		wantBody   string
+	}{
+		{"/v1/series/7", dir, 200, `{"series_id":7,"title":"Seven"}`},
+		{"/v1/series/8", dir, 404, `{"reason":"recorded"}`},
+		// Without fixtures, the example of the spec.
+		{"/v1/series/7", "", 200, `{"completed":true,"series_id":42,"title":"Berserk"}`},
+	}
+	for _, tt := range tests {
+		ts.mock.Fixtures = tt.fixtures
+		status, body := serve(ts.mock, "GET", tt.path)
+		if status != tt.wantStatus || strings.TrimSpace(body) != tt.wantBody {
+			t.Errorf("GET %s with fixtures %q = %d %s; want %d %s", tt.path, tt.fixtures, status, body, tt.wantStatus, tt.wantBody)
+		}
+	}
+}
+
+func TestLatencyRules(t *testing.T) {
+	ts := newTestServer(t)
+	tests := []struct {
+		rules   []Rule
+		slowest time.Duration // 0 means no delay
+	}{
+		{nil, 0},
+		{[]Rule{{Latency: 100 * time.Millisecond, Rate: 1}}, 100 * time.Millisecond},
+		{[]Rule{{Operation: "retrieveSeries", Latency: 100 * time.Millisecond, Rate: 1}}, 100 * time.Millisecond},
+		{[]Rule{{Operation: "retrieveGenres", Latency: time.Second, Rate: 1}}, 0},
+		// The first rule that fires wins.
+		{[]Rule{{Latency: 50 * time.Millisecond, Rate: 1}, {Latency: time.Second, Rate: 1}}, 50 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		ts.mock.Latency = tt.rules
+		start := time.Now()
+		status, _ := serve(ts.mock, "GET", "/v1/series/1")
+		elapsed := time.Since(start)
+		if status != 200 || elapsed < tt.slowest || elapsed > tt.slowest+500*time.Millisecond {
+			t.Errorf("latency rules %+v: status %d after %s; want 200 after %s", tt.rules, status, elapsed, tt.slowest)
+		}
+	}
+
+	// A request that is given up on is not answered.
+	ts.mock.Latency = []Rule{{Latency: time.Minute, Rate: 1}}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	w := httptest.NewRecorder()
+	ts.mock.ServeHTTP(w, httptest.NewRequest("GET", "/v1/series/1", nil).WithContext(ctx))
+	if w.Body.Len() != 0 {
+		t.Errorf("a cancelled request was answered with %s", w.Body)
+	}
+}
+
+func TestRateIsDeterministic(t *testing.T) {
+	spec := newTestServer(t).mock.Spec
+	statuses := func(seed uint64) []int {
+		s := NewServer(spec, seed)
+		s.Logger = quietLogger
+		s.Errors = []Rule{{Status: 503, Rate: 0.5}}
+		var got []int
+		for range 40 {
+			status, _ := serve(s, "GET", "/v1/series/1")
+			got = append(got, status)
+		}
+		return got
+	}
+	first, again, other := statuses(1), statuses(1), statuses(2)
+	if !slices.Equal(first, again) {
+		t.Errorf("seed 1 failed different requests:\n%v\n%v", first, again)
+	}
+	if slices.Equal(first, other) {
+		t.Errorf("seeds 1 and 2 failed the same requests: %v", first)
+	}
+	if !slices.Contains(first, 503) || !slices.Contains(first, 200) {
+		t.Errorf("rate 0.5 answered %v; want some 503s and some 200s", first)
+	}
+}
+
+func TestResponsesMatchTheirSchemas(t *testing.T) {
+	spec, err := openapi.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := NewServer(spec, 1)
+	s.Logger = quietLogger
+	for _, op := range spec.Operations {
+		schema, ok := utils.ResponseSchemas[op.ID]
+		if !ok {
+			continue
+		}
+		path := spec.BasePath + op.Path
+		for _, p := range op.Params {
+			value := "1"
+			if len(p.Enum) > 0 {
+				value = p.Enum[0]
+			}
+			path = strings.ReplaceAll(path, "{"+p.Name+"}", value)
+		}
+		status, body := serve(s, op.Method, path)
+		if status < 200 || status > 299 {
+			t.Errorf("%s %s (%s) = %d %s", op.Method, path, op.ID, status, body)
+			continue
+		}
+		issues, err := utils.ValidateJSON(schema, []byte(body))
+		if err != nil || len(issues) > 0 {
+			t.Errorf("%s: the generated response %s does not match its schema: %v %v", op.ID, body, err, issues)
+		}
+	}
+}
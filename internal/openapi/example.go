@@ -0,0 +1,222 @@
+package openapi
+
+import (
+	"encoding/json"
+	"maps"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// maxExampleDepth bounds how deep synthesized examples nest, so that
+// recursive schemas terminate. Deeper objects and arrays are empty.
+const maxExampleDepth = 6
+
+// Response is a response that an operation declares, with an example body.
+type Response struct {
+	Status      int
+	ContentType string // "" if the response has no body
+	Body        []byte
+}
+
+// Response returns a response of op with the given status, or its success
+// response if status is 0. The body is the example the spec gives for it,
+// or else one synthesized from its schema. ok is false if op declares no
+// response for status, not even a default one.
+func (s *Spec) Response(op Operation, status int) (resp Response, ok bool) {
+	responses := mappingValue(op.node, "responses")
+	if responses == nil {
+		return Response{}, false
+	}
+	var codes []string
+	for i := 0; i+1 < len(responses.Content); i += 2 {
+		codes = append(codes, responses.Content[i].Value)
+	}
+
+	key := ""
+	if status == 0 {
+		for _, code := range []string{"200", "201", "202", "204"} {
+			if mappingValue(responses, code) != nil {
+				key = code
+				break
+			}
+		}
+		for _, code := range codes {
+			if key == "" && strings.HasPrefix(code, "2") {
+				key = code
+			}
+		}
+	} else {
+		code := strconv.Itoa(status)
+		for _, candidate := range []string{code, code[:1] + "XX", "default"} {
+			if mappingValue(responses, candidate) != nil {
+				key = candidate
+				break
+			}
+		}
+	}
+	if key == "" {
+		if status != 0 || mappingValue(responses, "default") == nil {
+			return Response{}, false
+		}
+		key = "default"
+	}
+
+	resp.Status = status
+	if resp.Status == 0 {
+		resp.Status = http.StatusOK
+		if n, err := strconv.Atoi(key); err == nil {
+			resp.Status = n
+		}
+	}
+	content := mappingValue(s.resolve(mappingValue(responses, key)), "content")
+	if content == nil || len(content.Content) == 0 {
+		return resp, true
+	}
+	resp.ContentType = content.Content[0].Value
+	if mappingValue(content, "application/json") != nil {
+		resp.ContentType = "application/json"
+	}
+	media := s.resolve(mappingValue(content, resp.ContentType))
+
+	var example interface{}
+	if ex := mappingValue(media, "example"); ex != nil {
+		example = jsonValue(ex)
+	} else if examples := mappingValue(media, "examples"); examples != nil && len(examples.Content) > 1 {
+		example = jsonValue(mappingValue(s.resolve(examples.Content[1]), "value"))
+	} else {
+		example = s.example(mappingValue(media, "schema"), 0)
+	}
+
+	if resp.ContentType == "application/json" {
+		resp.Body, _ = json.Marshal(example)
+	} else if text, ok := example.(string); ok {
+		resp.Body = []byte(text)
+	}
+	return resp, true
+}
+
+// example returns a value that matches the schema n: its example, default
+// or first enum value if it has one, and otherwise made-up data of the
+// right type, with every field of objects and one item in arrays.
+func (s *Spec) example(n *yaml.Node, depth int) interface{} {
+	n = s.resolve(n)
+	if n == nil {
+		return nil
+	}
+	for _, key := range []string{"example", "default"} {
+		if v := mappingValue(n, key); v != nil {
+			return jsonValue(v)
+		}
+	}
+	if enum := mappingValue(n, "enum"); enum != nil && len(enum.Content) > 0 {
+		return jsonValue(enum.Content[0])
+	}
+	for _, key := range []string{"oneOf", "anyOf"} {
+		if alts := mappingValue(n, key); alts != nil && len(alts.Content) > 0 {
+			return s.example(alts.Content[0], depth)
+		}
+	}
+	if all := mappingValue(n, "allOf"); all != nil {
+		merged := make(map[string]interface{})
+		for _, part := range all.Content {
+			if obj, ok := s.example(part, depth).(map[string]interface{}); ok {
+				maps.Copy(merged, obj)
+			}
+		}
+		return merged
+	}
+
+	typ := schemaType(n)
+	items := mappingValue(n, "items")
+	props := mappingValue(n, "properties")
+	values := mappingValue(n, "additionalProperties")
+	switch {
+	case typ == "string":
+		switch format := scalar(mappingValue(n, "format")); format {
+		case "date":
+			return "2024-01-01"
+		case "date-time":
+			return "2024-01-01T00:00:00Z"
+		case "uri", "url":
+			return "https://example.com/"
+		case "email":
+			return "user@example.com"
+		}
+		return "string"
+	case typ == "integer":
+		return 1
+	case typ == "number":
+		return 1.5
+	case typ == "boolean":
+		return true
+	case typ == "array" || items != nil:
+		if depth >= maxExampleDepth || items == nil {
+			return []interface{}{}
+		}
+		return []interface{}{s.example(items, depth+1)}
+	case typ == "object" || props != nil || values != nil:
+		obj := make(map[string]interface{})
+		if depth >= maxExampleDepth {
+			return obj
+		}
+		if props != nil {
+			for i := 0; i+1 < len(props.Content); i += 2 {
+				obj[props.Content[i].Value] = s.example(props.Content[i+1], depth+1)
+			}
+		} else if values != nil && values.Kind == yaml.MappingNode {
+			obj["key"] = s.example(values, depth+1)
+		}
+		return obj
+	}
+	return nil
+}
+
+// schemaType returns the type of a schema node. Of a list of types, as in
+// OpenAPI 3.1, the first one but "null" is taken.
+func schemaType(n *yaml.Node) string {
+	t := mappingValue(n, "type")
+	if t != nil && t.Kind == yaml.SequenceNode {
+		for _, item := range t.Content {
+			if item.Value != "null" {
+				return item.Value
+			}
+		}
+		return ""
+	}
+	return scalar(t)
+}
+
+func scalar(n *yaml.Node) string {
+	if n == nil || n.Kind != yaml.ScalarNode {
+		return ""
+	}
+	return n.Value
+}
+
+// resolve follows a $ref such as #/components/schemas/SeriesModelV1 in n,
+// if it has one. References outside the spec resolve to nil.
+func (s *Spec) resolve(n *yaml.Node) *yaml.Node {
+	for hops := 0; n != nil && hops < 32; hops++ {
+		if n.Kind == yaml.AliasNode {
+			n = n.Alias
+			continue
+		}
+		ref := mappingValue(n, "$ref")
+		if ref == nil {
+			return n
+		}
+		pointer, ok := strings.CutPrefix(ref.Value, "#/")
+		if !ok {
+			return nil
+		}
+		keys := strings.Split(pointer, "/")
+		for i, key := range keys {
+			keys[i] = strings.ReplaceAll(strings.ReplaceAll(key, "~1", "/"), "~0", "~")
+		}
+		n = s.lookup(keys...)
+	}
+	return n
+}
@@ -0,0 +1,365 @@
+// Package openapi gives offline access to the OpenAPI spec of the API, which
+// is embedded in the binary.
+package openapi
+
+import (
+	_ "embed"
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// raw is the spec as YAML: the pinned copy that 'go generate' generates the
+// checked-in sources from.
+//
+//go:embed openapi.yaml
+var raw []byte
+
+// Spec is the parsed spec.
+type Spec struct {
+	Title      string
+	Version    string
+	BasePath   string      // path of the first server URL, e.g. /v1; "" if none
+	Operations []Operation // sorted by tag, then path and method
+	root       *yaml.Node
+}
+
+// Operation is an operation of the spec.
+type Operation struct {
+	ID      string `json:"operation_id"`
+	Method  string `json:"method"`
+	Path    string `json:"path"`
+	Tag     string `json:"tag"`
+	Summary string `json:"summary,omitempty"`
+	Auth    bool   `json:"auth_required"`
+
+	Params       []Param  `json:"-"`
+	BodyContent  []string `json:"-"` // media types of the request body, if it takes one
+	BodyRequired bool     `json:"-"`
+	node         *yaml.Node
+}
+
+// Param is a parameter of an Operation.
+type Param struct {
+	Name     string
+	In       string // "path", "query", "header" or "cookie"
+	Required bool
+	Type     string // OpenAPI type with its format, e.g. "integer(int64)"
+	Enum     []string
+}
+
+// Match is a place in the spec where Grep found its text.
+type Match struct {
+	Pointer   string `json:"pointer"` // JSON pointer, e.g. /paths/~1series~1search/post/summary
+	Line      int    `json:"line"`
+	Operation string `json:"operation_id,omitempty"`
+	Schema    string `json:"schema,omitempty"`
+	Text      string `json:"text"`
+}
+
+// document is the part of the spec that Load decodes into Go values.
+type document struct {
+	Info struct {
+		Title   string `yaml:"title"`
+		Version string `yaml:"version"`
+	} `yaml:"info"`
+	Servers []struct {
+		URL string `yaml:"url"`
+	} `yaml:"servers"`
+	Security []map[string][]string `yaml:"security"`
+	Paths    map[string]map[string]struct {
+		Tags        []string              `yaml:"tags"`
+		Summary     string                `yaml:"summary"`
+		OperationID string                `yaml:"operationId"`
+		Security    []map[string][]string `yaml:"security"`
+		Parameters  []struct {
+			Name     string `yaml:"name"`
+			In       string `yaml:"in"`
+			Required bool   `yaml:"required"`
+			Schema   struct {
+				Type   string   `yaml:"type"`
+				Format string   `yaml:"format"`
+				Enum   []string `yaml:"enum"`
+			} `yaml:"schema"`
+		} `yaml:"parameters"`
+		RequestBody *struct {
+			Required bool                   `yaml:"required"`
+			Content  map[string]interface{} `yaml:"content"`
+		} `yaml:"requestBody"`
+	} `yaml:"paths"`
+}
+
+var (
+	loadOnce sync.Once
+	loaded   *Spec
+	loadErr  error
+)
+
+// Load parses the embedded spec. It is parsed once; later calls return the
+// same Spec.
+func Load() (*Spec, error) {
+	loadOnce.Do(func() {
+		loaded, loadErr = parse("embedded spec", raw)
+	})
+	return loaded, loadErr
+}
+
+// LoadFile parses the spec in the file at path, such as openapi.yaml.
+func LoadFile(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parse(path, data)
+}
+
+// parse parses data, naming the spec name in errors.
+func parse(name string, data []byte) (*Spec, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	var doc document
+	if err := root.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	s := &Spec{Title: doc.Info.Title, Version: doc.Info.Version, root: &root}
+	if len(doc.Servers) > 0 {
+		if u, err := url.Parse(doc.Servers[0].URL); err == nil {
+			s.BasePath = strings.TrimSuffix(u.Path, "/")
+		}
+	}
+	for path, item := range doc.Paths {
+		for method, op := range item {
+			if op.OperationID == "" {
+				continue
+			}
+			security := op.Security
+			if security == nil {
+				security = doc.Security
+			}
+			o := Operation{
+				ID:      op.OperationID,
+				Method:  strings.ToUpper(method),
+				Path:    path,
+				Summary: op.Summary,
+				Auth:    authRequired(security),
+				node:    s.lookup("paths", path, method),
+			}
+			if len(op.Tags) > 0 {
+				o.Tag = op.Tags[0]
+			}
+			for _, p := range op.Parameters {
+				typ := p.Schema.Type
+				if p.Schema.Format != "" {
+					typ += "(" + p.Schema.Format + ")"
+				}
+				o.Params = append(o.Params, Param{Name: p.Name, In: p.In, Required: p.Required, Type: typ, Enum: p.Schema.Enum})
+			}
+			if rb := op.RequestBody; rb != nil {
+				for mediaType := range rb.Content {
+					o.BodyContent = append(o.BodyContent, mediaType)
+				}
+				sort.Strings(o.BodyContent)
+				o.BodyRequired = rb.Required
+			}
+			s.Operations = append(s.Operations, o)
+		}
+	}
+	sort.Slice(s.Operations, func(i, j int) bool {
+		a, b := s.Operations[i], s.Operations[j]
+		if a.Tag != b.Tag {
+			return a.Tag < b.Tag
+		}
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		return a.Method < b.Method
+	})
+	return s, nil
+}
+
+// authRequired reports whether security requires a session; an empty
+// requirement in the list makes it optional.
+func authRequired(security []map[string][]string) bool {
+	for _, requirement := range security {
+		if len(requirement) == 0 {
+			return false
+		}
+	}
+	return len(security) > 0
+}
+
+// lookup returns the node at the given keys of nested mappings, or nil.
+func (s *Spec) lookup(keys ...string) *yaml.Node {
+	n := s.root
+	if n.Kind == yaml.DocumentNode && len(n.Content) > 0 {
+		n = n.Content[0]
+	}
+	for _, key := range keys {
+		n = mappingValue(n, key)
+		if n == nil {
+			return nil
+		}
+	}
+	return n
+}
+
+func mappingValue(n *yaml.Node, key string) *yaml.Node {
+	for n != nil && n.Kind == yaml.AliasNode {
+		n = n.Alias
+	}
+	if n == nil || n.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// Operation returns the operation with the given operationId.
+func (s *Spec) Operation(id string) (Operation, bool) {
+	for _, op := range s.Operations {
+		if op.ID == id {
+			return op, true
+		}
+	}
+	return Operation{}, false
+}
+
+// Match returns the operation for method and a concrete path such as
+// /series/123, and the values of the path parameters in it. Literal
+// segments win over parameters, so /series/search is not /series/{id}.
+func (s *Spec) Match(method, path string) (Operation, map[string]string, bool) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	var best Operation
+	var bestValues map[string]string
+	for _, op := range s.Operations {
+		if op.Method != strings.ToUpper(method) {
+			continue
+		}
+		template := strings.Split(strings.Trim(op.Path, "/"), "/")
+		if len(template) != len(segments) {
+			continue
+		}
+		values := make(map[string]string)
+		for i, t := range template {
+			if strings.HasPrefix(t, "{") && strings.HasSuffix(t, "}") && segments[i] != "" {
+				values[t[1:len(t)-1]] = segments[i]
+			} else if t != segments[i] {
+				values = nil
+				break
+			}
+		}
+		if values != nil && (bestValues == nil || len(values) < len(bestValues)) {
+			best, bestValues = op, values
+		}
+	}
+	return best, bestValues, bestValues != nil
+}
+
+// Details returns the operation as the spec declares it, as a value that
+// encoding/json can marshal. References to component schemas are kept.
+func (op Operation) Details() interface{} {
+	return jsonValue(op.node)
+}
+
+// jsonValue converts a YAML node to maps, slices and scalars. Mapping keys
+// become strings, so that response codes such as 200 can be marshaled.
+func jsonValue(n *yaml.Node) interface{} {
+	if n == nil {
+		return nil
+	}
+	switch n.Kind {
+	case yaml.DocumentNode:
+		if len(n.Content) == 0 {
+			return nil
+		}
+		return jsonValue(n.Content[0])
+	case yaml.AliasNode:
+		return jsonValue(n.Alias)
+	case yaml.MappingNode:
+		m := make(map[string]interface{}, len(n.Content)/2)
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			m[n.Content[i].Value] = jsonValue(n.Content[i+1])
+		}
+		return m
+	case yaml.SequenceNode:
+		list := make([]interface{}, len(n.Content))
+		for i, item := range n.Content {
+			list[i] = jsonValue(item)
+		}
+		return list
+	}
+	var v interface{}
+	if err := n.Decode(&v); err != nil {
+		return n.Value
+	}
+	return v
+}
+
+// Grep returns the keys and scalar values of the spec that contain text,
+// ignoring case, in the order they appear in the spec.
+func (s *Spec) Grep(text string) []Match {
+	text = strings.ToLower(text)
+	var matches []Match
+	var walk func(n *yaml.Node, path []string)
+	walk = func(n *yaml.Node, path []string) {
+		switch n.Kind {
+		case yaml.DocumentNode:
+			for _, c := range n.Content {
+				walk(c, path)
+			}
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(n.Content); i += 2 {
+				key := n.Content[i]
+				child := append(path[:len(path):len(path)], key.Value)
+				if strings.Contains(strings.ToLower(key.Value), text) {
+					matches = append(matches, s.match(child, key.Line, key.Value))
+				}
+				walk(n.Content[i+1], child)
+			}
+		case yaml.SequenceNode:
+			for i, c := range n.Content {
+				walk(c, append(path[:len(path):len(path)], fmt.Sprint(i)))
+			}
+		case yaml.ScalarNode:
+			if strings.Contains(strings.ToLower(n.Value), text) {
+				matches = append(matches, s.match(path, n.Line, n.Value))
+			}
+		}
+	}
+	walk(s.root, nil)
+	return matches
+}
+
+// match describes a hit at path, naming the operation or component schema
+// it belongs to.
+func (s *Spec) match(path []string, line int, text string) Match {
+	m := Match{Line: line, Text: text}
+	var b strings.Builder
+	for _, p := range path {
+		b.WriteString("/" + strings.NewReplacer("~", "~0", "/", "~1").Replace(p))
+	}
+	m.Pointer = b.String()
+	switch {
+	case len(path) >= 3 && path[0] == "paths":
+		for _, op := range s.Operations {
+			if op.Path == path[1] && op.Method == strings.ToUpper(path[2]) {
+				m.Operation = op.ID
+			}
+		}
+	case len(path) >= 3 && path[0] == "components" && path[1] == "schemas":
+		m.Schema = path[2]
+	}
+	return m
+}
@@ -0,0 +1,205 @@
+package openapi
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const testSpec = `
+openapi: 3.0.0
+info: {title: Test API, version: "1.2"}
+servers:
+  - url: https://api.example.com/v1/
+security:
+  - bearerAuth: []
+paths:
+  /series/{id}:
+    get:
+      operationId: retrieveSeries
+      tags: [series]
+      security: [{}]
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: integer, format: int64}}
+        - {name: unrenderedFields, in: query, schema: {type: boolean}}
+    post:
+      operationId: updateSeries
+      tags: [series]
+      requestBody:
+        required: true
+        content:
+          application/json: {schema: {type: object}}
+          application/yaml: {schema: {type: object}}
+  /series/search:
+    post:
+      operationId: searchSeriesPost
+      tags: [series]
+      security: []
+  /series/{id}/comments/{comment_id}/location:
+    get:
+      operationId: retrieveSeriesCommentLocation
+      tags: [series]
+  /genres:
+    get:
+      operationId: retrieveGenres
+      tags: [genre]
+      summary: Retrieve all genres
+  /untagged:
+    get:
+      summary: No operation ID, so not an operation
+`
+
+func loadTestSpec(t *testing.T) *Spec {
+	t.Helper()
+	s, err := parse("test spec", []byte(testSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestParse(t *testing.T) {
+	s := loadTestSpec(t)
+	if s.Title != "Test API" || s.Version != "1.2" || s.BasePath != "/v1" {
+		t.Errorf("Title, Version, BasePath = %q, %q, %q; want Test API, 1.2, /v1", s.Title, s.Version, s.BasePath)
+	}
+	var ids []string
+	for _, op := range s.Operations {
+		ids = append(ids, op.ID)
+	}
+	// Sorted by tag, then path and method.
+	want := []string{"retrieveGenres", "searchSeriesPost", "retrieveSeries", "updateSeries", "retrieveSeriesCommentLocation"}
+	if !slices.Equal(ids, want) {
+		t.Errorf("operations = %q; want %q", ids, want)
+	}
+
+	tests := []struct {
+		id           string
+		auth         bool
+		params       []Param
+		bodyContent  []string
+		bodyRequired bool
+	}{
+		{id: "retrieveSeries", auth: false, params: []Param{
+			{Name: "id", In: "path", Required: true, Type: "integer(int64)"},
+			{Name: "unrenderedFields", In: "query", Type: "boolean"},
+		}},
+		{id: "updateSeries", auth: true, bodyContent: []string{"application/json", "application/yaml"}, bodyRequired: true},
+		// An empty list of requirements turns off the spec-wide one.
+		{id: "searchSeriesPost", auth: false},
+	}
+	for _, tt := range tests {
+		op, ok := s.Operation(tt.id)
+		if !ok {
+			t.Errorf("Operation(%q) not found", tt.id)
+			continue
+		}
+		if op.Auth != tt.auth || op.BodyRequired != tt.bodyRequired ||
+			!slices.Equal(op.BodyContent, tt.bodyContent) ||
+			!slices.EqualFunc(op.Params, tt.params, func(a, b Param) bool {
+				return a.Name == b.Name && a.In == b.In && a.Required == b.Required && a.Type == b.Type
+			}) {
+			t.Errorf("Operation(%q) = %+v; want auth %v, params %+v, body %q (required %v)",
+				tt.id, op, tt.auth, tt.params, tt.bodyContent, tt.bodyRequired)
+		}
+	}
+	if _, ok := s.Operation("missing"); ok {
+		t.Error("Operation(missing) found an operation")
+	}
+}
+
+func TestMatch(t *testing.T) {
+	s := loadTestSpec(t)
+	tests := []struct {
+		method, path string
+		wantID       string // "" means no match
+		wantValues   map[string]string
+	}{
+		{"GET", "/series/123", "retrieveSeries", map[string]string{"id": "123"}},
+		{"get", "series/123/", "retrieveSeries", map[string]string{"id": "123"}},
+		{"POST", "/series/123", "updateSeries", map[string]string{"id": "123"}},
+		// A literal segment wins over a parameter.
+		{"POST", "/series/search", "searchSeriesPost", map[string]string{}},
+		{"GET", "/series/search", "retrieveSeries", map[string]string{"id": "search"}},
+		{"GET", "/series/1/comments/2/location", "retrieveSeriesCommentLocation", map[string]string{"id": "1", "comment_id": "2"}},
+		{"GET", "/genres", "retrieveGenres", map[string]string{}},
+		{"DELETE", "/genres", "", nil},
+		{"GET", "/series", "", nil},
+		{"GET", "/series/1/comments", "", nil},
+		// A parameter never matches an empty segment.
+		{"GET", "/series//comments/2/location", "", nil},
+		{"GET", "/untagged", "", nil},
+	}
+	for _, tt := range tests {
+		op, values, ok := s.Match(tt.method, tt.path)
+		if ok != (tt.wantID != "") || op.ID != tt.wantID || !maps.Equal(values, tt.wantValues) {
+			t.Errorf("Match(%s, %s) = %q, %v, %v; want %q, %v", tt.method, tt.path, op.ID, values, ok, tt.wantID, tt.wantValues)
+		}
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		content string // "" means the file does not exist
+		wantErr string
+	}{
+		{"valid.yaml", testSpec, ""},
+		{"missing.yaml", "", "no such file"},
+		{"broken.yaml", "paths: [unclosed", "broken.yaml"},
+		{"wrong.yaml", "paths: [1, 2]", "wrong.yaml"},
+	}
+	for _, tt := range tests {
+		path := filepath.Join(dir, tt.name)
+		if tt.content != "" {
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		s, err := LoadFile(path)
+		switch {
+		case tt.wantErr == "" && (err != nil || len(s.Operations) != 5):
+			t.Errorf("LoadFile(%s) = %v; want 5 operations", tt.name, err)
+		case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+			t.Errorf("LoadFile(%s) error = %v; want one mentioning %q", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestLoadEmbedded(t *testing.T) {
+	embedded, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, _ := Load()
+	if again != embedded {
+		t.Error("Load parsed the spec again")
+	}
+	if len(embedded.Operations) == 0 || embedded.BasePath != "/v1" {
+		t.Errorf("the embedded spec has %d operations and base path %q; want some and /v1", len(embedded.Operations), embedded.BasePath)
+	}
+	if op, values, ok := embedded.Match("GET", "/series/15180124327"); !ok || op.ID != "retrieveSeries" || values["id"] != "15180124327" {
+		t.Errorf("Match(GET, /series/15180124327) = %q, %v, %v; want retrieveSeries", op.ID, values, ok)
+	}
+}
+
+func TestGrep(t *testing.T) {
+	s := loadTestSpec(t)
+	matches := s.Grep("GENRES")
+	var got []string
+	for _, m := range matches {
+		got = append(got, m.Pointer+" "+m.Operation)
+	}
+	want := []string{
+		"/paths/~1genres ",
+		"/paths/~1genres/get/operationId retrieveGenres",
+		"/paths/~1genres/get/summary retrieveGenres",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("Grep(GENRES) = %q; want %q", got, want)
+	}
+}
@@ -0,0 +1,103 @@
+// Package testutil holds the helpers shared by the tests of the command
+// packages: a test server behind the default API client, stdin
+// redirection, and running handlers that exit the process in a child.
+package testutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/TheDucker1/mangaupdatescli/internal/apiclient"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// Request is a request that the server of UseServer received.
+type Request struct {
+	Method, Path, Query, Body, Authorization string
+}
+
+// UseServer makes the default client send its requests, with session token
+// "token", to a server that answers each with {"ok":true}. It returns the
+// requests the server received.
+func UseServer(t *testing.T) *[]Request {
+	t.Helper()
+	var sent []Request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		sent = append(sent, Request{r.Method, r.URL.Path, r.URL.RawQuery, string(body), r.Header.Get("Authorization")})
+		io.WriteString(w, `{"ok":true}`)
+	}))
+	t.Cleanup(srv.Close)
+	client, err := apiclient.New(
+		apiclient.WithBaseURL(srv.URL),
+		apiclient.WithSessionToken("token"),
+		apiclient.WithLogger(log.New(io.Discard, "", 0)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved := apiclient.Default()
+	apiclient.SetDefault(client)
+	t.Cleanup(func() { apiclient.SetDefault(saved) })
+	return &sent
+}
+
+// WithStdin runs fn with os.Stdin reading input.
+func WithStdin(t *testing.T, input string, fn func()) {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "stdin")
+	if err := os.WriteFile(file, []byte(input), 0600); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	saved := os.Stdin
+	os.Stdin = f
+	defer func() { os.Stdin = saved }()
+	fn()
+}
+
+// exitingArgs is set in the child process of RunExiting.
+const exitingArgs = "MANGAUPDATESCLI_TEST_ARGS"
+
+// ExitingArgs returns the arguments that RunExiting passed to the child
+// process it runs the current test in. ok is false in the test itself.
+func ExitingArgs() (args []string, ok bool) {
+	encoded := os.Getenv(exitingArgs)
+	if encoded == "" {
+		return nil, false
+	}
+	json.Unmarshal([]byte(encoded), &args)
+	return args, true
+}
+
+// RunExiting runs the test called test again in a child process, which
+// gets args from ExitingArgs, for the handler calls that exit the process.
+// It returns what the child wrote to stderr and its exit code.
+func RunExiting(t *testing.T, test string, args []string) (string, int) {
+	t.Helper()
+	encoded, _ := json.Marshal(args)
+	cmd := exec.Command(os.Args[0], "-test.run=^"+test+"$")
+	cmd.Env = append(os.Environ(), exitingArgs+"="+string(encoded))
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return stderr.String(), exitErr.ExitCode()
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return stderr.String(), 0
+}
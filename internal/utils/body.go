@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ReadBody returns the request body that a --body argument gives as JSON: a
+// JSON or YAML document, @file to read one from a file, or - to read one
+// from stdin. A YAML document must be a mapping or a sequence.
+func ReadBody(arg string) (json.RawMessage, error) {
+	data := []byte(arg)
+	var err error
+	switch {
+	case arg == "-":
+		data, err = io.ReadAll(os.Stdin)
+	case strings.HasPrefix(arg, "@"):
+		data, err = os.ReadFile(arg[1:])
+	}
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimSpace(data)
+	if json.Valid(data) {
+		return json.RawMessage(data), nil
+	}
+
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("not a JSON or YAML document: %w", err)
+	}
+	switch doc.(type) {
+	case map[string]interface{}, []interface{}:
+	default:
+		return nil, errors.New("not a JSON document, or a YAML mapping or sequence")
+	}
+	data, err = json.Marshal(jsonValue(doc))
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(data), nil
+}
+
+// jsonValue converts a value decoded from YAML to one encoding/json can
+// marshal, turning the keys of nested mappings into strings.
+func jsonValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, x := range v {
+			v[k] = jsonValue(x)
+		}
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, x := range v {
+			m[fmt.Sprint(k)] = jsonValue(x)
+		}
+		return m
+	case []interface{}:
+		for i, x := range v {
+			v[i] = jsonValue(x)
+		}
+	}
+	return v
+}
@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"github.com/TheDucker1/mangaupdatescli/internal/testutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadBody(t *testing.T) {
+	dir := t.TempDir()
+	yamlFile := filepath.Join(dir, "body.yaml")
+	if err := os.WriteFile(yamlFile, []byte("search: berserk\nperpage: 5\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	jsonFile := filepath.Join(dir, "body.json")
+	if err := os.WriteFile(jsonFile, []byte("\n  {\"search\": \"berserk\"}\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		arg     string
+		stdin   string
+		want    string
+		wantErr string
+	}{
+		{arg: `{"search":"x","page":1}`, want: `{"search":"x","page":1}`},
+		{arg: `[1, 2]`, want: `[1, 2]`},
+		{arg: "search: x\npage: 1", want: `{"page":1,"search":"x"}`},
+		{arg: "- a\n- b", want: `["a","b"]`},
+		{arg: "filter:\n  1: one\n  true: yes", want: `{"filter":{"1":"one","true":"yes"}}`},
+		{arg: "@" + yamlFile, want: `{"perpage":5,"search":"berserk"}`},
+		{arg: "@" + jsonFile, want: `{"search": "berserk"}`},
+		{arg: "-", stdin: "search: from stdin\n", want: `{"search":"from stdin"}`},
+		{arg: "-", stdin: `{"search":"from stdin"}`, want: `{"search":"from stdin"}`},
+		{arg: "@" + filepath.Join(dir, "missing.yaml"), wantErr: "no such file"},
+		{arg: "just text", wantErr: "not a JSON document, or a YAML mapping or sequence"},
+		{arg: "-", stdin: "", wantErr: "not a JSON document, or a YAML mapping or sequence"},
+		{arg: "search: [unclosed", wantErr: "not a JSON or YAML document"},
+	}
+	for _, tt := range tests {
+		var got string
+		var err error
+		testutil.WithStdin(t, tt.stdin, func() {
+			var data []byte
+			data, err = ReadBody(tt.arg)
+			got = string(data)
+		})
+		switch {
+		case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+			t.Errorf("ReadBody(%q) error = %v; want one containing %q", tt.arg, err, tt.wantErr)
+		case tt.wantErr == "" && (err != nil || got != tt.want):
+			t.Errorf("ReadBody(%q) = %s, %v; want %s", tt.arg, got, err, tt.want)
+		}
+	}
+}
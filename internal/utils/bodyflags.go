@@ -0,0 +1,191 @@
+package utils
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// BodyFlags are flags for the fields of a JSON request body, declared from
+// the body arguments of a command's help. Each field is a flag of the same
+// name, with nested objects as dotted names such as --filter.type. Values
+// are checked against the field's type and enum as they are parsed, and
+// array fields take repeated flags, comma-separated lists or both. The
+// whole body can also be given with --body (see ReadBody), and the field
+// flags then override its fields.
+type BodyFlags struct {
+	doc    *string
+	values []*bodyValue
+}
+
+// bodyValue is the flag.Value of one body field.
+type bodyValue struct {
+	arg   ArgHelp
+	set   bool
+	value interface{} // a scalar, or []interface{} for an array field
+}
+
+func (v *bodyValue) String() string {
+	if v == nil || !v.set {
+		return ""
+	}
+	data, _ := json.Marshal(v.value)
+	return string(data)
+}
+
+func (v *bodyValue) Set(s string) error {
+	itemType, isArray := strings.CutPrefix(v.arg.Type, "[]")
+	if !isArray {
+		x, err := parseArgValue(s, v.arg.Type, v.arg.Enum)
+		if err != nil {
+			return err
+		}
+		v.value, v.set = x, true
+		return nil
+	}
+	list, _ := v.value.([]interface{})
+	for _, part := range strings.Split(s, ",") {
+		x, err := parseArgValue(strings.TrimSpace(part), itemType, v.arg.Enum)
+		if err != nil {
+			return err
+		}
+		list = append(list, x)
+	}
+	v.value, v.set = list, true
+	return nil
+}
+
+// parseArgValue converts s to the JSON value of an argument of type typ,
+// such as "integer(int64)", and checks it against enum if that is set.
+func parseArgValue(s, typ string, enum []string) (interface{}, error) {
+	if len(enum) > 0 && !slices.Contains(enum, s) {
+		return nil, fmt.Errorf("%q is not one of %s", s, strings.Join(enum, ", "))
+	}
+	base, _, _ := strings.Cut(typ, "(")
+	var x interface{} = s
+	var err error
+	switch base {
+	case "integer":
+		x, err = strconv.ParseInt(s, 10, 64)
+	case "number":
+		x, err = strconv.ParseFloat(s, 64)
+	case "boolean":
+		x, err = strconv.ParseBool(s)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a valid %s", s, base)
+	}
+	return x, nil
+}
+
+// AddBodyFlags declares --body and a flag on fs for every body argument
+// of hc. Fields whose names fs already has, such as the --id of a command,
+// get no flag and can only be set with --body.
+func AddBodyFlags(fs *flag.FlagSet, hc HelpContent) *BodyFlags {
+	b := &BodyFlags{}
+	b.doc = fs.String("body", "", "Request body as a JSON or YAML document, @file to read it from a file, or - for stdin.")
+	for _, arg := range hc.Arguments {
+		if arg.In != "body" || fs.Lookup(arg.Name) != nil {
+			continue
+		}
+		v := &bodyValue{arg: arg}
+		fs.Var(v, arg.Name, bodyFlagUsage(arg))
+		b.values = append(b.values, v)
+	}
+	return b
+}
+
+// bodyFlagUsage is the usage text of the flag of a body argument: its
+// description, or its type and enum if the spec documents neither, so
+// that no flag is listed without a text.
+func bodyFlagUsage(arg ArgHelp) string {
+	if arg.Description != "" {
+		return arg.Description
+	}
+	usage := "Body field of type " + arg.Type
+	if len(arg.Enum) > 0 {
+		usage += ", one of " + strings.Join(arg.Enum, ", ")
+	}
+	return usage + "."
+}
+
+// Body returns the body that --body and the field flags describe, or nil
+// if neither was given. Field flags override the fields of the document.
+// Required fields are only enforced once some body is given, since a
+// command may not need one.
+func (b *BodyFlags) Body() (interface{}, error) {
+	var doc interface{}
+	if *b.doc != "" {
+		data, err := ReadBody(*b.doc)
+		if err != nil {
+			return nil, fmt.Errorf("--body: %w", err)
+		}
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("--body: %w", err)
+		}
+	}
+	body, isObject := doc.(map[string]interface{})
+	for _, v := range b.values {
+		if !v.set {
+			continue
+		}
+		if doc != nil && !isObject {
+			return nil, fmt.Errorf("--%s cannot be set on a --body that is not an object", v.arg.Name)
+		}
+		if body == nil {
+			body = make(map[string]interface{})
+		}
+		obj := body
+		parts := strings.Split(v.arg.Name, ".")
+		for _, p := range parts[:len(parts)-1] {
+			child, ok := obj[p].(map[string]interface{})
+			if !ok {
+				child = make(map[string]interface{})
+				obj[p] = child
+			}
+			obj = child
+		}
+		obj[parts[len(parts)-1]] = v.value
+	}
+	if body == nil {
+		return doc, nil
+	}
+	for _, v := range b.values {
+		if v.arg.Required && !hasField(body, v.arg.Name) {
+			return nil, fmt.Errorf("--%s is required", v.arg.Name)
+		}
+	}
+	return body, nil
+}
+
+// hasField reports whether the dotted field name is set in body.
+func hasField(body map[string]interface{}, name string) bool {
+	parts := strings.Split(name, ".")
+	for _, p := range parts[:len(parts)-1] {
+		child, ok := body[p].(map[string]interface{})
+		if !ok {
+			return false
+		}
+		body = child
+	}
+	_, ok := body[parts[len(parts)-1]]
+	return ok
+}
+
+// Decode stores the body that --body and the field flags describe in v,
+// such as a *mangaupdates.SeriesSearchRequestV1. Fields given by neither
+// keep their value, so callers can set defaults first.
+func (b *BodyFlags) Decode(v interface{}) error {
+	body, err := b.Body()
+	if err != nil || body == nil {
+		return err
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
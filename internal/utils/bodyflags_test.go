@@ -0,0 +1,220 @@
+package utils
+
+import (
+	"encoding/json"
+	"flag"
+	"github.com/TheDucker1/mangaupdatescli/internal/testutil"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var testBodyHelp = HelpContent{Arguments: []ArgHelp{
+	{Name: "id", Type: "integer(int64)", In: "path", Required: true},
+	{Name: "search", Type: "string", In: "body"},
+	{Name: "page", Type: "integer", In: "body"},
+	{Name: "type", Type: "[]string", In: "body", Enum: []string{"Manga", "Manhwa", "Manhua"}},
+	{Name: "filter.kind", Type: "string", In: "body"},
+	{Name: "filter.exclude", Type: "boolean", In: "body"},
+}}
+
+func TestBodyFlagsBody(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "body.yaml")
+	if err := os.WriteFile(file, []byte("search: from file\npage: 3\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		args    []string
+		want    string // JSON, or "" for no body
+		wantErr string
+	}{
+		{name: "nothing given", args: nil, want: ""},
+		{name: "flags only", args: []string{"--search", "berserk", "--page", "2"}, want: `{"page":2,"search":"berserk"}`},
+		{name: "document only", args: []string{"--body", `{"search":"x","extra":[1]}`}, want: `{"extra":[1],"search":"x"}`},
+		{name: "flags override the document", args: []string{"--body", `{"search":"x","page":1}`, "--search", "y"}, want: `{"page":1,"search":"y"}`},
+		{name: "flag order does not matter", args: []string{"--search", "y", "--body", `{"search":"x"}`}, want: `{"search":"y"}`},
+		{name: "YAML document", args: []string{"--body", "search: x\nfilter:\n  kind: a\n", "--filter.exclude=true"}, want: `{"filter":{"exclude":true,"kind":"a"},"search":"x"}`},
+		{name: "nested flag replaces one field", args: []string{"--body", `{"filter":{"kind":"a","other":1}}`, "--filter.kind", "b"}, want: `{"filter":{"kind":"b","other":1}}`},
+		{name: "nested flag replaces a non-object", args: []string{"--body", `{"filter":"all"}`, "--filter.kind", "b"}, want: `{"filter":{"kind":"b"}}`},
+		{name: "array flags replace the array", args: []string{"--body", `{"type":["Manga"]}`, "--type", "Manhwa,Manhua", "--type", "Manga"}, want: `{"type":["Manhwa","Manhua","Manga"]}`},
+		{name: "document from a file", args: []string{"--body", "@" + file, "--page", "4"}, want: `{"page":4,"search":"from file"}`},
+		{name: "non-object document", args: []string{"--body", `[1,2]`}, want: `[1,2]`},
+		{name: "flag on a non-object document", args: []string{"--body", `[1,2]`, "--page", "1"}, wantErr: "--page cannot be set"},
+		{name: "invalid document", args: []string{"--body", `{"search":`}, wantErr: "--body"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			fs.Int64("id", 0, "")
+			b := AddBodyFlags(fs, testBodyHelp)
+			if err := fs.Parse(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			body, err := b.Body()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Body() error = %v; want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := ""
+			if body != nil {
+				data, _ := json.Marshal(body)
+				got = string(data)
+			}
+			if got != tt.want {
+				t.Errorf("Body() = %s; want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBodyFlagsRequired(t *testing.T) {
+	help := HelpContent{Arguments: []ArgHelp{
+		{Name: "search", Type: "string", In: "body", Required: true},
+		{Name: "page", Type: "integer", In: "body"},
+	}}
+	tests := []struct {
+		args    []string
+		wantErr bool
+	}{
+		{nil, false}, // no body at all is left to the API
+		{[]string{"--page", "1"}, true},
+		{[]string{"--search", "x"}, false},
+		{[]string{"--body", `{"search":"x"}`, "--page", "1"}, false},
+		{[]string{"--body", `{"page":1}`}, true},
+	}
+	for _, tt := range tests {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		b := AddBodyFlags(fs, help)
+		if err := fs.Parse(tt.args); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := b.Body(); (err != nil) != tt.wantErr {
+			t.Errorf("%q: Body() error = %v; want error %v", tt.args, err, tt.wantErr)
+		}
+	}
+}
+
+func TestBodyFlagsValues(t *testing.T) {
+	tests := []struct {
+		args    []string
+		wantErr bool
+	}{
+		{[]string{"--page", "2"}, false},
+		{[]string{"--page", "two"}, true},
+		{[]string{"--type", "Manga,Manhwa"}, false},
+		{[]string{"--type", "Manga,Comic"}, true},
+		{[]string{"--filter.exclude=false"}, false},
+		{[]string{"--filter.exclude=maybe"}, true},
+	}
+	for _, tt := range tests {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		AddBodyFlags(fs, testBodyHelp)
+		if err := fs.Parse(tt.args); (err != nil) != tt.wantErr {
+			t.Errorf("parsing %q: error = %v; want error %v", tt.args, err, tt.wantErr)
+		}
+	}
+}
+
+func TestAddBodyFlagsSkipsTakenNames(t *testing.T) {
+	help := HelpContent{Arguments: []ArgHelp{
+		{Name: "id", Type: "integer", In: "body"},
+		{Name: "page", Type: "integer", In: "body"},
+	}}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	id := fs.Int64("id", 0, "Series ID")
+	b := AddBodyFlags(fs, help) // must not panic on --id
+	if err := fs.Parse([]string{"--id", "7", "--page", "2"}); err != nil {
+		t.Fatal(err)
+	}
+	body, err := b.Body()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := json.Marshal(body)
+	if *id != 7 || string(data) != `{"page":2}` {
+		t.Errorf("--id = %d, body = %s; want 7 and {\"page\":2}", *id, data)
+	}
+}
+
+func TestAddBodyFlagsUsage(t *testing.T) {
+	help := HelpContent{Arguments: []ArgHelp{
+		{Name: "search", Type: "string", In: "body", Description: "Search term."},
+		{Name: "type", Type: "[]string", In: "body", Enum: []string{"Manga", "Manhwa"}},
+		{Name: "page", Type: "integer", In: "body"},
+	}}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	AddBodyFlags(fs, help)
+	want := map[string]string{
+		"search": "Search term.",
+		"type":   "Body field of type []string, one of Manga, Manhwa.",
+		"page":   "Body field of type integer.",
+	}
+	for name, usage := range want {
+		if f := fs.Lookup(name); f == nil || f.Usage != usage {
+			t.Errorf("usage of --%s = %+v; want %q", name, f, usage)
+		}
+	}
+}
+
+func TestBodyFlagsDecode(t *testing.T) {
+	type filter struct {
+		Kind    string `json:"kind"`
+		Exclude bool   `json:"exclude"`
+	}
+	type request struct {
+		Search string   `json:"search"`
+		Page   int      `json:"page"`
+		Type   []string `json:"type"`
+		Filter *filter  `json:"filter"`
+	}
+	tests := []struct {
+		name  string
+		args  []string
+		stdin string
+		want  request
+	}{
+		{name: "defaults kept", args: nil, want: request{Page: 1}},
+		{name: "flags", args: []string{"--search", "x", "--filter.kind", "a"}, want: request{Search: "x", Page: 1, Filter: &filter{Kind: "a"}}},
+		{
+			name:  "stdin document with a flag override",
+			args:  []string{"--body", "-", "--page", "3", "--filter.exclude=true"},
+			stdin: "search: berserk\npage: 2\ntype: [Manga]\nfilter: {kind: a}\n",
+			want:  request{Search: "berserk", Page: 3, Type: []string{"Manga"}, Filter: &filter{Kind: "a", Exclude: true}},
+		},
+	}
+	for _, tt := range tests {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		b := AddBodyFlags(fs, testBodyHelp)
+		if err := fs.Parse(tt.args); err != nil {
+			t.Fatal(err)
+		}
+		got := request{Page: 1}
+		var err error
+		testutil.WithStdin(t, tt.stdin, func() { err = b.Decode(&got) })
+		gotJSON, _ := json.Marshal(got)
+		wantJSON, _ := json.Marshal(tt.want)
+		if err != nil || string(gotJSON) != string(wantJSON) {
+			t.Errorf("%s: Decode = %s, %v; want %s", tt.name, gotJSON, err, wantJSON)
+		}
+	}
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	b := AddBodyFlags(fs, testBodyHelp)
+	if err := fs.Parse([]string{"--body", `{"page":"two"}`}); err != nil {
+		t.Fatal(err)
+	}
+	var req request
+	if err := b.Decode(&req); err == nil {
+		t.Error("Decode accepted a string for an integer field")
+	}
+}
@@ -0,0 +1,283 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/TheDucker1/mangaupdatescli/internal/apiclient"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultParallel is how many IDs a bulk retrieve fetches at once.
+const DefaultParallel = 4
+
+// maxParallel caps --parallel; the shared rate limit makes more pointless.
+const maxParallel = 32
+
+// BulkIDs holds the --id, --ids-from and --parallel flags of a retrieve
+// command that accepts many IDs. Register them with AddBulkIDFlags.
+type BulkIDs struct {
+	ids      idList
+	from     string
+	Parallel int
+}
+
+// idList is a flag.Value for --id 1,2,3; repeating the flag appends.
+type idList []int64
+
+func (l *idList) String() string {
+	parts := make([]string, len(*l))
+	for i, id := range *l {
+		parts[i] = strconv.FormatInt(id, 10)
+	}
+	return strings.Join(parts, ",")
+}
+
+func (l *idList) Set(s string) error {
+	ids, err := parseIDs(strings.Split(s, ","))
+	if err != nil {
+		return err
+	}
+	*l = append(*l, ids...)
+	return nil
+}
+
+// AddBulkIDFlags registers --id, --ids-from and --parallel on fs. what names
+// the resource in flag descriptions, e.g. "Series".
+func AddBulkIDFlags(fs *flag.FlagSet, what string) *BulkIDs {
+	b := &BulkIDs{}
+	fs.Var(&b.ids, "id", what+" ID (required); several may be given as 1,2,3.")
+	fs.StringVar(&b.from, "ids-from", "", "Read IDs from this file, or from stdin for -.")
+	fs.IntVar(&b.Parallel, "parallel", DefaultParallel, "Maximum concurrent requests when fetching several IDs.")
+	return b
+}
+
+// BulkIDHelp returns hc with the --id argument documented as accepting
+// several IDs and the --ids-from and --parallel flags added.
+func BulkIDHelp(hc HelpContent) HelpContent {
+	args := make([]ArgHelp, 0, len(hc.Arguments)+2)
+	for _, arg := range hc.Arguments {
+		if arg.Name == "id" {
+			arg.Type = "integer[]"
+			arg.Description += " Several IDs may be given as 1,2,3; results are then printed as NDJSON."
+		}
+		args = append(args, arg)
+	}
+	hc.Arguments = append(args,
+		ArgHelp{Name: "ids-from", Type: "string", Description: "Read IDs (separated by commas, spaces or newlines) from this file, or from stdin for -. Results are printed as NDJSON."},
+		ArgHelp{Name: "parallel", Type: "integer", Description: "Maximum concurrent requests when fetching several IDs.", Default: strconv.Itoa(DefaultParallel)},
+	)
+	return hc
+}
+
+// IDs returns the requested IDs in order: those from --id first, then those
+// read from --ids-from. stdin is read for --ids-from -.
+func (b *BulkIDs) IDs(stdin io.Reader) ([]int64, error) {
+	if b.Parallel < 1 || b.Parallel > maxParallel {
+		return nil, fmt.Errorf("--parallel must be between 1 and %d", maxParallel)
+	}
+	ids := append([]int64(nil), b.ids...)
+	if b.from == "" {
+		return ids, nil
+	}
+	r := stdin
+	if b.from != "-" {
+		f, err := os.Open(b.from)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+	var words []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		words = append(words, strings.FieldsFunc(line, func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t'
+		})...)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read IDs: %w", err)
+	}
+	fromFile, err := parseIDs(words)
+	if err != nil {
+		return nil, err
+	}
+	return append(ids, fromFile...), nil
+}
+
+// IsBatch reports whether the command was asked for several IDs, in which
+// case results are printed as NDJSON even if only one ID was found.
+func (b *BulkIDs) IsBatch() bool {
+	return len(b.ids) > 1 || b.from != ""
+}
+
+func parseIDs(words []string) ([]int64, error) {
+	ids := make([]int64, 0, len(words))
+	for _, w := range words {
+		w = strings.TrimSpace(w)
+		if w == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(w, 10, 64)
+		if err != nil || id <= 0 {
+			return nil, fmt.Errorf("invalid ID %q", w)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// BulkError reports that some IDs of a batch failed. It unwraps to the first
+// failure, which decides the exit code.
+type BulkError struct {
+	Failed, Total int
+	First         error
+}
+
+func (e *BulkError) Error() string {
+	return fmt.Sprintf("%d of %d IDs failed; first failure: %v", e.Failed, e.Total, e.First)
+}
+
+func (e *BulkError) Unwrap() error { return e.First }
+
+// bulkLine is one NDJSON line of a batch: the response for an ID, or why it
+// could not be fetched.
+type bulkLine struct {
+	ID     int64           `json:"id"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  *bulkLineError  `json:"error,omitempty"`
+}
+
+type bulkLineError struct {
+	Message  string `json:"message"`
+	Status   int    `json:"status,omitempty"`
+	ExitCode int    `json:"exit_code"`
+}
+
+type bulkResult struct {
+	body []byte
+	err  error
+}
+
+// RunBulk fetches every ID with at most parallel concurrent calls to fetch
+// and prints one NDJSON line per ID to stdout, in input order, as soon as
+// all earlier lines are out. A failed ID is reported on its line and does not
+// stop the batch; RunBulk then returns a *BulkError. Cancelling ctx stops
+// the batch and returns ctx.Err().
+func RunBulk(ctx context.Context, ids []int64, parallel int, fetch func(ctx context.Context, id int64) ([]byte, error)) error {
+	type job struct {
+		id     int64
+		result chan bulkResult
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	jobs := make(chan job)
+	// order bounds how many results may wait for a slow earlier ID.
+	order := make(chan job, parallel*4)
+
+	var wg sync.WaitGroup
+	for range parallel {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				body, err := fetch(ctx, j.id)
+				j.result <- bulkResult{body, err}
+			}
+		}()
+	}
+	go func() {
+		defer close(order)
+		defer close(jobs)
+		for _, id := range ids {
+			j := job{id, make(chan bulkResult, 1)}
+			select {
+			case order <- j:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case jobs <- j:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	defer wg.Wait()
+	// On an early return, stop fetching; this runs before wg.Wait.
+	defer cancel()
+	// On an early return, drain order so the dispatcher and workers finish.
+	defer func() {
+		go func() {
+			for range order {
+			}
+		}()
+	}()
+
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+	bulkErr := &BulkError{Total: len(ids)}
+	for j := range order {
+		var res bulkResult
+		select {
+		case res = <-j.result:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		line := bulkLine{ID: j.id}
+		if res.err == nil {
+			line.Result = compactJSON(res.body)
+		} else {
+			bulkErr.Failed++
+			if bulkErr.First == nil {
+				bulkErr.First = fmt.Errorf("ID %d: %w", j.id, res.err)
+			}
+			line.Error = &bulkLineError{Message: res.err.Error(), ExitCode: ExitCode(res.err)}
+			var apiErr *apiclient.APIError
+			if errors.As(res.err, &apiErr) {
+				line.Error.Status = apiErr.StatusCode
+			}
+		}
+		data, err := json.Marshal(line)
+		if err != nil {
+			return err
+		}
+		if _, err := out.Write(data); err != nil {
+			return err
+		}
+		if err := out.WriteByte('\n'); err != nil {
+			return err
+		}
+		// Flush per line so consumers see results as they complete.
+		if err := out.Flush(); err != nil {
+			return err
+		}
+	}
+	if bulkErr.Failed > 0 {
+		return bulkErr
+	}
+	return nil
+}
+
+// compactJSON returns body on a single line, or as a JSON string if it is
+// not JSON at all.
+func compactJSON(body []byte) json.RawMessage {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, body); err == nil {
+		return buf.Bytes()
+	}
+	quoted, _ := json.Marshal(string(body))
+	return quoted
+}
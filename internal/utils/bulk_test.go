@@ -0,0 +1,222 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/TheDucker1/mangaupdatescli/internal/apiclient"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// captureStdout returns what fn writes to os.Stdout.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	done := make(chan []byte)
+	go func() {
+		data, _ := io.ReadAll(r)
+		done <- data
+	}()
+	fn()
+	w.Close()
+	return string(<-done)
+}
+
+func TestRunBulkOrderAndErrorLines(t *testing.T) {
+	ids := []int64{5, 4, 3, 2, 1}
+	fetch := func(ctx context.Context, id int64) ([]byte, error) {
+		// Later IDs finish first, so lines must wait for earlier ones.
+		time.Sleep(time.Duration(id) * 5 * time.Millisecond)
+		switch id {
+		case 4:
+			return nil, &apiclient.APIError{Method: "GET", URL: "https://api.example.com/v1/series/4", StatusCode: 404, Reason: "Not Found"}
+		case 2:
+			return nil, errors.New("boom")
+		case 3:
+			return []byte("not json"), nil
+		}
+		return []byte(fmt.Sprintf("{\n  \"series_id\": %d\n}", id)), nil
+	}
+
+	var err error
+	out := captureStdout(t, func() {
+		err = RunBulk(context.Background(), ids, 3, fetch)
+	})
+
+	want := strings.Join([]string{
+		`{"id":5,"result":{"series_id":5}}`,
+		`{"id":4,"error":{"message":"GET /v1/series/4 returned 404: Not Found","status":404,"exit_code":3}}`,
+		`{"id":3,"result":"not json"}`,
+		`{"id":2,"error":{"message":"boom","exit_code":1}}`,
+		`{"id":1,"result":{"series_id":1}}`,
+	}, "\n") + "\n"
+	if out != want {
+		t.Errorf("RunBulk printed\n%s\nwant\n%s", out, want)
+	}
+
+	var bulkErr *BulkError
+	if !errors.As(err, &bulkErr) || bulkErr.Failed != 2 || bulkErr.Total != 5 {
+		t.Fatalf("RunBulk error = %v; want a BulkError with 2 of 5 failed", err)
+	}
+	if !errors.Is(err, apiclient.ErrNotFound) || ExitCode(err) != ExitNotFound {
+		t.Errorf("RunBulk error %v does not unwrap to the first failure", err)
+	}
+}
+
+func TestRunBulkAllSucceed(t *testing.T) {
+	var calls atomic.Int32
+	fetch := func(ctx context.Context, id int64) ([]byte, error) {
+		calls.Add(1)
+		return []byte(`{}`), nil
+	}
+	var ids []int64
+	for id := range int64(100) {
+		ids = append(ids, id+1)
+	}
+	var err error
+	out := captureStdout(t, func() {
+		err = RunBulk(context.Background(), ids, 4, fetch)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := bytes.Count([]byte(out), []byte("\n")); n != 100 || calls.Load() != 100 {
+		t.Errorf("got %d lines from %d calls; want 100 of each", n, calls.Load())
+	}
+	if !strings.HasPrefix(out, `{"id":1,"result":{}}`+"\n"+`{"id":2,`) {
+		t.Errorf("lines out of order:\n%.80s", out)
+	}
+}
+
+func TestRunBulkCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls atomic.Int32
+	fetch := func(ctx context.Context, id int64) ([]byte, error) {
+		if calls.Add(1) == 3 {
+			cancel()
+		}
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	var ids []int64
+	for id := range int64(1000) {
+		ids = append(ids, id+1)
+	}
+	var err error
+	captureStdout(t, func() {
+		err = RunBulk(ctx, ids, 4, fetch)
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("RunBulk error = %v; want context.Canceled", err)
+	}
+	if n := calls.Load(); n > 8 {
+		t.Errorf("%d IDs were fetched after cancelling; want at most a few", n)
+	}
+}
+
+func TestRunBulkCancelMidway(t *testing.T) {
+	before := runtime.NumGoroutine()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var calls atomic.Int32
+	fetch := func(ctx context.Context, id int64) ([]byte, error) {
+		if calls.Add(1) == 50 {
+			cancel()
+		}
+		select {
+		case <-time.After(time.Millisecond):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		return []byte(fmt.Sprintf(`{"series_id":%d,"title":"%s"}`, id, strings.Repeat("x", 100))), nil
+	}
+	var ids []int64
+	for id := range int64(1000) {
+		ids = append(ids, id+1)
+	}
+	var err error
+	out := captureStdout(t, func() {
+		err = RunBulk(ctx, ids, 4, fetch)
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("RunBulk error = %v; want context.Canceled", err)
+	}
+
+	// Whatever was printed is whole lines for the first IDs, in order.
+	if out != "" && !strings.HasSuffix(out, "\n") {
+		t.Errorf("output ends in a partial line: %q", out[max(0, len(out)-40):])
+	}
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	if out == "" {
+		lines = nil
+	}
+	for i, line := range lines {
+		var got struct {
+			ID     int64           `json:"id"`
+			Result json.RawMessage `json:"result"`
+		}
+		if err := json.Unmarshal([]byte(line), &got); err != nil || got.ID != int64(i+1) || got.Result == nil {
+			t.Errorf("line %d = %q; want the result of ID %d", i+1, line, i+1)
+			break
+		}
+	}
+	if len(lines) == 0 || len(lines) >= 50 {
+		t.Errorf("%d lines printed; want some of the IDs fetched before cancelling", len(lines))
+	}
+
+	// Workers, dispatcher and drain all finish once RunBulk has returned.
+	deadline := time.Now().Add(2 * time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if n := runtime.NumGoroutine(); n > before {
+		buf := make([]byte, 1<<16)
+		t.Errorf("%d goroutines still running after RunBulk returned; %d before:\n%s", n, before, buf[:runtime.Stack(buf, true)])
+	}
+}
+
+func TestRunBulkStopsOnWriteError(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Close() // writes fail with EPIPE
+	defer w.Close()
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	fetch := func(ctx context.Context, id int64) ([]byte, error) {
+		if id == 1 {
+			return []byte(`{}`), nil
+		}
+		// Only returns once RunBulk gives up on the batch.
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	done := make(chan error)
+	go func() { done <- RunBulk(context.Background(), []int64{1, 2, 3, 4, 5, 6}, 3, fetch) }()
+	select {
+	case err := <-done:
+		if err == nil || errors.Is(err, context.Canceled) {
+			t.Errorf("RunBulk error = %v; want the write error", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("RunBulk did not return after failing to write")
+	}
+}
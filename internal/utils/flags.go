@@ -0,0 +1,14 @@
+package utils
+
+import "strings"
+
+// SplitList splits a comma-separated flag value into values of an enum
+// type of the mangaupdates package, such as []mangaupdates.SeriesTypeV1.
+func SplitList[T ~string](s string) []T {
+	parts := strings.Split(s, ",")
+	out := make([]T, len(parts))
+	for i, p := range parts {
+		out[i] = T(p)
+	}
+	return out
+}
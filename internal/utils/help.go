@@ -3,14 +3,15 @@ package utils
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 )
 
 type HelpContent struct {
 	Usage         string
 	Description   string
 	Arguments     []ArgHelp
-	InputJSON     interface{} // Can be a string like "Schema: <SchemaName:L1>" or a map
-	OutputJSON    interface{} // Can be a string like "Schema: <SchemaName:L1>" or a map
+	InputJSON     interface{} // A SchemaHelp, or a string describing the input
+	OutputJSON    interface{} // A SchemaHelp, or a string describing the output
 	ErrorExamples interface{} // Example or schema name
 	AuthRequired  bool        // New field
 }
@@ -20,7 +21,9 @@ type ArgHelp struct {
 	Type        string
 	Required    bool
 	Description string
-	Default     string // Optional default value as string
+	Default     string   // Optional default value as string
+	In          string   // "path", "query" or "body"; body fields become flags via AddBodyFlags
+	Enum        []string // Allowed values, if restricted
 }
 
 func PrintFormattedHelp(hc HelpContent) {
@@ -55,39 +58,26 @@ func PrintFormattedHelp(hc HelpContent) {
 			if arg.Default != "" {
 				defaultStr = fmt.Sprintf("(default: %s)", arg.Default)
 			}
+			description := arg.Description
+			if len(arg.Enum) > 0 {
+				description = strings.TrimSpace(fmt.Sprintf("%s (one of: %s)", description, strings.Join(arg.Enum, ", ")))
+			}
 			// Adjusted formatting for better alignment
 			fmt.Printf("  --%-*s %-*s %s %s %s\n",
 				maxLenName, arg.Name,
 				maxLenType+2, fmt.Sprintf("<%s>", arg.Type), // +2 for <>
-				arg.Description,
+				description,
 				reqStr,
 				defaultStr)
 		}
 	}
-	fmt.Println("\nUse -h for JSON help, -hh for this human-readable help.")
-}
-
-func processSchemaForHelp(schema interface{}, level int, maxLevel int) interface{} {
-	if level > maxLevel {
-		return fmt.Sprintf("<Nested data: L%d+>", level)
-	}
-	if s, ok := schema.(string); ok {
-		return s
+	if input := formatHelpSchema(hc.InputJSON); input != "" {
+		fmt.Print("\nInput:\n", input)
 	}
-	if m, ok := schema.(map[string]interface{}); ok {
-		simplified := make(map[string]interface{})
-		for k, v := range m {
-			if subMap, okSub := v.(map[string]interface{}); okSub {
-				simplified[k] = processSchemaForHelp(subMap, level+1, maxLevel)
-			} else if subArray, okArr := v.([]interface{}); okArr && len(subArray) > 0 {
-				simplified[k] = []interface{}{processSchemaForHelp(subArray[0], level+1, maxLevel)}
-			} else {
-				simplified[k] = fmt.Sprintf("<%T>", v)
-			}
-		}
-		return simplified
+	if output := formatHelpSchema(hc.OutputJSON); output != "" {
+		fmt.Print("\nOutput:\n", output)
 	}
-	return schema
+	fmt.Println("\nUse -h for JSON help, -hh for this human-readable help. --help-depth <n> shows n levels of nested fields.")
 }
 
 func PrintJSONHelp(hc HelpContent) {
@@ -107,6 +97,12 @@ func PrintJSONHelp(hc HelpContent) {
 		if arg.Default != "" {
 			argsInfo[i]["default"] = arg.Default
 		}
+		if arg.In != "" {
+			argsInfo[i]["in"] = arg.In
+		}
+		if len(arg.Enum) > 0 {
+			argsInfo[i]["enum"] = arg.Enum
+		}
 	}
 	if len(argsInfo) > 0 {
 		output["arguments"] = argsInfo
@@ -114,8 +110,8 @@ func PrintJSONHelp(hc HelpContent) {
 		output["arguments"] = "None"
 	}
 
-	output["expected_input_schema"] = processSchemaForHelp(hc.InputJSON, 1, 1)
-	output["expected_output_schema"] = processSchemaForHelp(hc.OutputJSON, 1, 1)
+	output["expected_input_schema"] = helpSchemaJSON(hc.InputJSON)
+	output["expected_output_schema"] = helpSchemaJSON(hc.OutputJSON)
 	output["error_examples"] = hc.ErrorExamples
 
 	jsonData, err := json.MarshalIndent(output, "", "  ")
@@ -0,0 +1,122 @@
+package utils
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/TheDucker1/mangaupdatescli/mangaupdates"
+	"os"
+)
+
+// Operation describes an API operation as the OpenAPI spec does, for
+// commands without a hand-written handler. The <tag>_generated_commands.go
+// files declare one per such operation and run it with RunOperation.
+type Operation struct {
+	ID           string
+	Method       string
+	Path         string // OpenAPI path template, e.g. /lists/{id}
+	Params       []OperationParam
+	Body         bool // takes a JSON request body
+	BodyRequired bool
+	Auth         bool
+}
+
+// OperationParam is a path or query parameter of an Operation. Each one
+// becomes a flag of the same name.
+type OperationParam struct {
+	Name        string
+	In          string // "path" or "query"
+	Type        string // OpenAPI type: string, integer, number, boolean or array
+	Required    bool
+	Enum        []string
+	Description string
+}
+
+// Check reports whether v is a valid value for p.
+func (p OperationParam) Check(v string) error {
+	_, err := parseArgValue(v, p.Type, p.Enum)
+	return err
+}
+
+// RunOperation is the handler of a generated command: it parses args into
+// op's parameters and a request body, given with the flags of AddBodyFlags,
+// sends the request and prints the response like the hand-written handlers
+// do.
+func RunOperation(ctx context.Context, op Operation, help HelpContent, args []string) {
+	fs := flag.NewFlagSet(op.ID, flag.ContinueOnError)
+	values := make(map[string]*string, len(op.Params))
+	for _, p := range op.Params {
+		values[p.Name] = fs.String(p.Name, "", p.Description)
+	}
+	var bodyFlags *BodyFlags
+	if op.Body {
+		bodyFlags = AddBodyFlags(fs, help)
+	}
+
+	isJsonHelp, isTextHelp, remainingArgs := CheckHelpFlags(args)
+	if err := fs.Parse(remainingArgs); err != nil {
+		PrintErrorAndExit(fmt.Sprintf("Failed to parse flags for '%s'", op.ID), err)
+	}
+
+	if isJsonHelp {
+		PrintJSONHelp(help)
+		return
+	}
+	if isTextHelp {
+		PrintFormattedHelp(help)
+		return
+	}
+
+	// Parameters left out are not sent, so the API applies its own defaults.
+	given := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { given[f.Name] = true })
+
+	req := mangaupdates.Request{
+		Method:     op.Method,
+		Path:       op.Path,
+		PathParams: make(map[string]string),
+		Query:      make(map[string]string),
+		Auth:       op.Auth,
+	}
+	for _, p := range op.Params {
+		if !given[p.Name] {
+			if p.Required {
+				fmt.Fprintf(os.Stderr, "Error: --%s is required for %s.\n", p.Name, op.ID)
+				PrintFormattedHelp(help)
+				os.Exit(1)
+			}
+			continue
+		}
+		v := *values[p.Name]
+		if err := p.Check(v); err != nil {
+			PrintErrorAndExit(fmt.Sprintf("Invalid value for --%s", p.Name), err)
+		}
+		if p.In == "path" {
+			req.PathParams[p.Name] = v
+		} else {
+			req.Query[p.Name] = v
+		}
+	}
+	if op.Body {
+		body, err := bodyFlags.Body()
+		if err != nil {
+			PrintErrorAndExit(fmt.Sprintf("Invalid flags for '%s'", op.ID), err)
+		}
+		if body == nil && op.BodyRequired {
+			fmt.Fprintf(os.Stderr, "Error: a request body (--body or the flags of its fields) is required for %s.\n", op.ID)
+			PrintFormattedHelp(help)
+			os.Exit(1)
+		}
+		req.Body = body
+	}
+
+	respBody, err := mangaupdates.Default().DoRaw(ctx, req)
+	if err != nil {
+		PrintErrorAndExit("API request failed for "+op.Path, err)
+	}
+	defer respBody.Close()
+
+	if err := PrintJSONStream(respBody); err != nil {
+		PrintErrorAndExit("API request failed for "+op.Path, err)
+	}
+}
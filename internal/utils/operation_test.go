@@ -0,0 +1,109 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/TheDucker1/mangaupdatescli/internal/testutil"
+	"strings"
+	"testing"
+)
+
+var testOperation = Operation{
+	ID:     "updateListSeries",
+	Method: "POST",
+	Path:   "/lists/{id}/series/update",
+	Params: []OperationParam{
+		{Name: "id", In: "path", Type: "integer", Required: true},
+		{Name: "mode", In: "query", Type: "string", Enum: []string{"add", "replace"}},
+		{Name: "dry_run", In: "query", Type: "boolean"},
+	},
+	Body:         true,
+	BodyRequired: true,
+	Auth:         true,
+}
+
+var testOperationHelp = HelpContent{Arguments: []ArgHelp{
+	{Name: "id", Type: "integer", In: "path", Required: true},
+	{Name: "mode", Type: "string", In: "query", Enum: []string{"add", "replace"}},
+	{Name: "dry_run", Type: "boolean", In: "query"},
+	{Name: "title", Type: "string", In: "body"},
+}}
+
+func TestRunOperation(t *testing.T) {
+	sent := testutil.UseServer(t)
+	tests := []struct {
+		name string
+		args []string
+		want testutil.Request
+	}{
+		{
+			name: "path parameter and body",
+			args: []string{"--id", "7", "--body", `{"title":"x"}`},
+			want: testutil.Request{Method: "POST", Path: "/lists/7/series/update", Body: `{"title":"x"}`},
+		},
+		{
+			name: "query parameters and a body flag",
+			args: []string{"--mode", "add", "--id", "7", "--dry_run", "true", "--title", "y"},
+			want: testutil.Request{Method: "POST", Path: "/lists/7/series/update", Query: "dry_run=true&mode=add", Body: `{"title":"y"}`},
+		},
+		{
+			name: "YAML body",
+			args: []string{"--id", "7", "--mode", "replace", "--body", "title: z"},
+			want: testutil.Request{Method: "POST", Path: "/lists/7/series/update", Query: "mode=replace", Body: `{"title":"z"}`},
+		},
+	}
+	for _, tt := range tests {
+		*sent = nil
+		out := captureStdout(t, func() {
+			RunOperation(context.Background(), testOperation, testOperationHelp, tt.args)
+		})
+		tt.want.Authorization = "Bearer token"
+		if len(*sent) != 1 || (*sent)[0] != tt.want {
+			t.Errorf("%s: sent %+v; want %+v", tt.name, *sent, tt.want)
+		}
+		if out != "{\n  \"ok\": true\n}\n" {
+			t.Errorf("%s: printed %q", tt.name, out)
+		}
+	}
+}
+
+func TestRunOperationHelp(t *testing.T) {
+	sent := testutil.UseServer(t)
+	out := captureStdout(t, func() {
+		RunOperation(context.Background(), testOperation, testOperationHelp, []string{"--id", "7", "-h"})
+	})
+	var help struct {
+		Arguments []map[string]interface{} `json:"arguments"`
+	}
+	if err := json.Unmarshal([]byte(out), &help); err != nil || len(help.Arguments) != 4 {
+		t.Errorf("-h printed %q (%v); want the JSON help", out, err)
+	}
+	if len(*sent) != 0 {
+		t.Errorf("-h sent %+v", *sent)
+	}
+}
+
+func TestRunOperationErrors(t *testing.T) {
+	if args, ok := testutil.ExitingArgs(); ok {
+		RunOperation(context.Background(), testOperation, testOperationHelp, args)
+		return
+	}
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"missing path parameter", []string{"--body", "{}"}, "--id is required for updateListSeries"},
+		{"invalid integer", []string{"--id", "x", "--body", "{}"}, "Invalid value for --id"},
+		{"value outside the enum", []string{"--id", "7", "--mode", "drop", "--body", "{}"}, "Invalid value for --mode"},
+		{"missing body", []string{"--id", "7"}, "a request body (--body or the flags of its fields) is required"},
+		{"invalid body", []string{"--id", "7", "--body", "{"}, "Invalid flags for 'updateListSeries'"},
+		{"unknown flag", []string{"--id", "7", "--nope", "1"}, "Failed to parse flags for 'updateListSeries'"},
+	}
+	for _, tt := range tests {
+		stderr, code := testutil.RunExiting(t, "TestRunOperationErrors", tt.args)
+		if code != ExitError || !strings.Contains(stderr, tt.wantErr) {
+			t.Errorf("%s: exit code %d, stderr:\n%s\nwant %d and %q", tt.name, code, stderr, ExitError, tt.wantErr)
+		}
+	}
+}
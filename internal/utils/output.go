@@ -1,12 +1,60 @@
 package utils
 
 import (
+	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"github.com/TheDucker1/mangaupdatescli/internal/apiclient"
+	"io"
 	"os"
 )
 
+// Exit codes of the CLI. They are part of its interface for scripts and must
+// not be renumbered; see the readme.
+const (
+	ExitOK           = 0   // success
+	ExitError        = 1   // any failure not listed below, including bad usage
+	ExitNotFound     = 3   // the API answered 404
+	ExitUnauthorized = 4   // not logged in, session expired, or 401/403
+	ExitValidation   = 5   // the API rejected the request as invalid (400/422)
+	ExitRateLimited  = 6   // the API answered 429 after all retries
+	ExitServer       = 7   // the API answered 5xx after all retries
+	ExitNetwork      = 8   // the API could not be reached
+	ExitTimeout      = 9   // a slow transaction did not finish in time
+	ExitSchema       = 10  // a response did not match its schema (--strict)
+	ExitInterrupted  = 130 // cancelled with Ctrl-C or SIGTERM
+)
+
+// ExitCode maps an error to the exit code the CLI reports for it.
+func ExitCode(err error) int {
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.Is(err, context.Canceled):
+		return ExitInterrupted
+	case errors.Is(err, apiclient.ErrNotFound):
+		return ExitNotFound
+	case errors.Is(err, apiclient.ErrUnauthorized), errors.Is(err, apiclient.ErrNotLoggedIn):
+		return ExitUnauthorized
+	case errors.Is(err, apiclient.ErrValidation):
+		return ExitValidation
+	case errors.Is(err, apiclient.ErrRateLimited):
+		return ExitRateLimited
+	case errors.Is(err, apiclient.ErrServer):
+		return ExitServer
+	case errors.Is(err, apiclient.ErrNetwork):
+		return ExitNetwork
+	case errors.Is(err, apiclient.ErrTransactionTimeout):
+		return ExitTimeout
+	case errors.Is(err, ErrSchemaMismatch):
+		return ExitSchema
+	}
+	return ExitError
+}
+
 func PrintJSON(data []byte) {
 	var prettyJSON bytes.Buffer
 	err := json.Indent(&prettyJSON, data, "", "  ")
@@ -17,11 +65,135 @@ func PrintJSON(data []byte) {
 	fmt.Println(prettyJSON.String())
 }
 
+// PrintJSONStream copies a response body to stdout as it arrives, indented
+// like PrintJSON, so large responses are never held in memory. Bodies that do
+// not start with '{' or '[', such as RSS feeds, are copied unchanged.
+func PrintJSONStream(r io.Reader) error {
+	out := bufio.NewWriter(os.Stdout)
+	err := indentJSONStream(out, bufio.NewReader(r))
+	if flushErr := out.Flush(); err == nil {
+		err = flushErr
+	}
+	return err
+}
+
+// indentJSONStream re-indents JSON byte by byte with two spaces, producing
+// the same layout as json.Indent. It does not validate its input.
+func indentJSONStream(w *bufio.Writer, r *bufio.Reader) error {
+	newline := func(depth int) {
+		w.WriteByte('\n')
+		for i := 0; i < depth; i++ {
+			w.WriteString("  ")
+		}
+	}
+
+	var first byte
+	for {
+		c, err := r.ReadByte()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if c != ' ' && c != '\t' && c != '\n' && c != '\r' {
+			first = c
+			r.UnreadByte()
+			break
+		}
+	}
+	if first != '{' && first != '[' {
+		_, err := io.Copy(w, r)
+		// The line is ended even if the body fails its --strict check
+		// or is cut off, so the error on stderr starts on its own line.
+		w.WriteByte('\n')
+		return err
+	}
+
+	depth := 0
+	inString, escaped := false, false
+	pendingOpen := false // a container was opened; its first line is not written yet
+	for {
+		c, err := r.ReadByte()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			w.WriteByte('\n')
+			return err
+		}
+		if inString {
+			w.WriteByte(c)
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+			continue
+		}
+		if c == ' ' || c == '\t' || c == '\n' || c == '\r' {
+			continue
+		}
+		if pendingOpen {
+			pendingOpen = false
+			if c == '}' || c == ']' {
+				// Empty containers stay on one line, as with json.Indent.
+				depth--
+				w.WriteByte(c)
+				continue
+			}
+			newline(depth)
+		}
+		switch c {
+		case '"':
+			inString = true
+			w.WriteByte(c)
+		case '{', '[':
+			w.WriteByte(c)
+			depth++
+			pendingOpen = true
+		case '}', ']':
+			depth--
+			newline(depth)
+			w.WriteByte(c)
+		case ',':
+			w.WriteByte(c)
+			newline(depth)
+		case ':':
+			w.WriteString(": ")
+		default:
+			w.WriteByte(c)
+		}
+	}
+	return w.WriteByte('\n')
+}
+
+// PrintErrorAndExit reports err on stderr, along with any detail the API sent
+// with it, and exits with the matching exit code.
 func PrintErrorAndExit(msg string, err error) {
+	// Ctrl-C is not a failure worth a stack of wrapped messages.
+	if errors.Is(err, context.Canceled) {
+		fmt.Fprintln(os.Stderr, "Interrupted.")
+		os.Exit(ExitInterrupted)
+	}
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %s: %v\n", msg, err)
 	} else {
 		fmt.Fprintf(os.Stderr, "Error: %s\n", msg)
 	}
-	os.Exit(1)
+	var apiErr *apiclient.APIError
+	if errors.As(err, &apiErr) && len(apiErr.Context) > 0 {
+		if details, jsonErr := json.MarshalIndent(apiErr.Context, "", "  "); jsonErr == nil {
+			fmt.Fprintf(os.Stderr, "Details: %s\n", details)
+		}
+	}
+	// Without an error to classify, msg alone still reports a failure.
+	code := ExitCode(err)
+	if code == ExitOK {
+		code = ExitError
+	}
+	os.Exit(code)
 }
@@ -0,0 +1,138 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/TheDucker1/mangaupdatescli/internal/apiclient"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+func TestIndentJSONStreamMatchesJSONIndent(t *testing.T) {
+	inputs := []string{
+		`{}`,
+		`[]`,
+		`{"a":1}`,
+		`{"a":[1,2,{"b":"x\"y,:{"}],"c":{},"d":[],"e":null}`,
+		` [ {"k" : "v\\"} , [ ] ] `,
+		`{"deep":{"a":{"b":[[1],[2,3]]}}, "s":"éé<>&"}`,
+		"{\n\t\"tabs\" :\r\n true ,\"n\": -1.5e3}",
+		`[{"url":"https:\/\/example.com\/x"},"]}",  "\\"]`,
+	}
+	for _, in := range inputs {
+		// json.Indent keeps trailing whitespace, which the stream drops.
+		var want bytes.Buffer
+		if err := json.Indent(&want, []byte(strings.TrimSpace(in)), "", "  "); err != nil {
+			t.Fatalf("json.Indent(%s): %v", in, err)
+		}
+		want.WriteByte('\n')
+
+		var got bytes.Buffer
+		w := bufio.NewWriter(&got)
+		if err := indentJSONStream(w, bufio.NewReader(strings.NewReader(in))); err != nil {
+			t.Fatalf("indentJSONStream(%s): %v", in, err)
+		}
+		w.Flush()
+		if got.String() != want.String() {
+			t.Errorf("indentJSONStream(%s) =\n%s\nwant\n%s", in, got.String(), want.String())
+		}
+	}
+}
+
+func TestIndentJSONStreamCopiesOtherBodies(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{``, ``},
+		{"  \n", ``},
+		{`<?xml version="1.0"?><rss/>`, "<?xml version=\"1.0\"?><rss/>\n"},
+		{`  "a string"`, "\"a string\"\n"},
+	}
+	for _, tt := range tests {
+		var got bytes.Buffer
+		w := bufio.NewWriter(&got)
+		if err := indentJSONStream(w, bufio.NewReader(strings.NewReader(tt.in))); err != nil {
+			t.Fatalf("indentJSONStream(%q): %v", tt.in, err)
+		}
+		w.Flush()
+		if got.String() != tt.want {
+			t.Errorf("indentJSONStream(%q) = %q; want %q", tt.in, got.String(), tt.want)
+		}
+	}
+}
+
+func TestIndentJSONStreamEndsLineOnError(t *testing.T) {
+	failed := errors.New("schema mismatch")
+	tests := []struct {
+		in, want string
+	}{
+		{`{"a":1}`, "{\n  \"a\": 1\n}\n"},
+		{`<rss/>`, "<rss/>\n"},
+	}
+	for _, tt := range tests {
+		// As a body failing its --strict check: the error replaces io.EOF.
+		r := io.MultiReader(strings.NewReader(tt.in), iotest.ErrReader(failed))
+		var got bytes.Buffer
+		w := bufio.NewWriter(&got)
+		if err := indentJSONStream(w, bufio.NewReader(r)); !errors.Is(err, failed) {
+			t.Errorf("indentJSONStream(%q) error = %v; want %v", tt.in, err, failed)
+		}
+		w.Flush()
+		if got.String() != tt.want {
+			t.Errorf("indentJSONStream(%q) = %q; want %q", tt.in, got.String(), tt.want)
+		}
+	}
+}
+
+// TestExitCode checks the exit codes that the readme promises scripts.
+func TestExitCode(t *testing.T) {
+	unreachable, err := apiclient.New(
+		apiclient.WithBaseURL("http://127.0.0.1:1"),
+		apiclient.WithRetryPolicy(apiclient.RetryPolicy{MaxWait: time.Second}),
+		apiclient.WithLogger(log.New(io.Discard, "", 0)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, networkErr := unreachable.Do(context.Background(), http.MethodGet, "http://127.0.0.1:1/genres", nil)
+	_, _, notLoggedIn := unreachable.DoAuth(context.Background(), http.MethodGet, "http://127.0.0.1:1/account/profile", nil)
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"success", nil, 0},
+		{"other failure", errors.New("bad flag"), 1},
+		{"400", &apiclient.APIError{StatusCode: 400}, 5},
+		{"401", &apiclient.APIError{StatusCode: 401}, 4},
+		{"403", &apiclient.APIError{StatusCode: 403}, 4},
+		{"404", fmt.Errorf("retrieveSeries: %w", &apiclient.APIError{StatusCode: 404}), 3},
+		{"409", &apiclient.APIError{StatusCode: 409}, 1},
+		{"422", &apiclient.APIError{StatusCode: 422}, 5},
+		{"429", &apiclient.APIError{StatusCode: 429}, 6},
+		{"500", &apiclient.APIError{StatusCode: 500}, 7},
+		{"503", &apiclient.APIError{StatusCode: 503}, 7},
+		{"not logged in", notLoggedIn, 4},
+		{"network", networkErr, 8},
+		{"slow transaction timeout", fmt.Errorf("%w abc after 30s", apiclient.ErrTransactionTimeout), 9},
+		{"schema mismatch", fmt.Errorf("%w (retrieveGenres: 1 problem)", ErrSchemaMismatch), 10},
+		{"interrupted", fmt.Errorf("GET /genres: %w", context.Canceled), 130},
+		// Ctrl-C during a request that then failed still counts as an interrupt.
+		{"interrupted request", errors.Join(context.Canceled, networkErr), 130},
+	}
+	for _, tt := range tests {
+		if got := ExitCode(tt.err); got != tt.want {
+			t.Errorf("ExitCode(%s: %v) = %d; want %d", tt.name, tt.err, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Schema is an API schema as shown in command help. Nodes naming a
+// component schema only set Ref (and maybe Description), and are looked up
+// in Schemas when rendered, so recursive schemas stay finite.
+type Schema struct {
+	Ref         string
+	Type        string // OpenAPI type with its format, e.g. "integer(int64)"
+	Description string
+	Enum        []string
+	Nullable    bool
+	Properties  []SchemaProperty // in spec order
+	Items       *Schema          // of an array
+	Values      *Schema          // of a map (additionalProperties)
+	OneOf       []*Schema        // alternatives of a oneOf or anyOf
+}
+
+// SchemaProperty is a named field of an object Schema.
+type SchemaProperty struct {
+	Name     string
+	Required bool
+	Schema   *Schema
+}
+
+// SchemaHelp is the InputJSON or OutputJSON of a HelpContent generated from
+// the spec, e.g. {Label: "Schema (on 200)", Schema: &Schema{Ref: "SeriesModelV1"}}.
+type SchemaHelp struct {
+	Label  string
+	Schema *Schema
+}
+
+// Schemas holds the component schemas of the API by name. It is filled in
+// by schemas_generated.go, which 'go generate' writes from the spec.
+var Schemas = map[string]*Schema{}
+
+// ResponseSchemas holds the schema of the JSON success response of each
+// operation by operation ID, from the same generated file as Schemas.
+var ResponseSchemas = map[string]*Schema{}
+
+// HelpDepth is how many levels of nested objects help renders; the
+// --help-depth global flag sets it.
+var HelpDepth = 2
+
+// resolveSchema follows s.Ref, if any. Unknown names resolve to nil.
+func resolveSchema(s *Schema) *Schema {
+	for s != nil && s.Ref != "" {
+		s = Schemas[s.Ref]
+	}
+	return s
+}
+
+// schemaTypeName describes the type of s in one word, such as
+// "SeriesModelV1", "[]string" or "map[string]integer".
+func schemaTypeName(s *Schema) string {
+	switch {
+	case s == nil:
+		return "any"
+	case s.Ref != "":
+		return s.Ref
+	case s.Items != nil:
+		return "[]" + schemaTypeName(s.Items)
+	case s.Values != nil:
+		return "map[string]" + schemaTypeName(s.Values)
+	case len(s.OneOf) > 0:
+		names := make([]string, len(s.OneOf))
+		for i, alt := range s.OneOf {
+			names[i] = schemaTypeName(alt)
+		}
+		return strings.Join(names, " | ")
+	case s.Type != "":
+		return s.Type
+	case len(s.Properties) > 0:
+		return "object"
+	}
+	return "any"
+}
+
+// schemaChildren returns the object whose fields are listed under s: s
+// itself, or the target of its reference or of its array items.
+func schemaChildren(s *Schema) *Schema {
+	for s != nil {
+		switch {
+		case s.Ref != "":
+			s = resolveSchema(s)
+		case s.Items != nil:
+			s = s.Items
+		case s.Values != nil:
+			s = s.Values
+		default:
+			if len(s.Properties) == 0 {
+				return nil
+			}
+			return s
+		}
+	}
+	return nil
+}
+
+// schemaEnum returns the enum of s, or of the items of an array.
+func schemaEnum(s *Schema) []string {
+	for s != nil {
+		if t := resolveSchema(s); t != nil && len(t.Enum) > 0 {
+			return t.Enum
+		}
+		if s.Items == nil {
+			break
+		}
+		s = s.Items
+	}
+	return nil
+}
+
+// SchemaJSON renders s for the JSON help and 'spec schema', listing nested
+// fields down to depth levels; deeper objects are only named.
+func SchemaJSON(s *Schema, depth int) map[string]interface{} {
+	out := map[string]interface{}{"type": schemaTypeName(s)}
+	if s == nil {
+		return out
+	}
+	if s.Description != "" {
+		out["description"] = s.Description
+	} else if t := resolveSchema(s); t != nil && t.Description != "" {
+		out["description"] = t.Description
+	}
+	if enum := schemaEnum(s); len(enum) > 0 {
+		out["enum"] = enum
+	}
+	if s.Nullable {
+		out["nullable"] = true
+	}
+	obj := schemaChildren(s)
+	if obj == nil || depth <= 0 {
+		return out
+	}
+	props := make(map[string]interface{}, len(obj.Properties))
+	for _, p := range obj.Properties {
+		prop := SchemaJSON(p.Schema, depth-1)
+		if p.Required {
+			prop["required"] = true
+		}
+		props[p.Name] = prop
+	}
+	out["properties"] = props
+	return out
+}
+
+// helpSchemaJSON renders the InputJSON or OutputJSON of a HelpContent for
+// the JSON help. Plain descriptions are passed through.
+func helpSchemaJSON(v interface{}) interface{} {
+	sh, ok := v.(SchemaHelp)
+	if !ok {
+		return v
+	}
+	return map[string]interface{}{
+		"label":  sh.Label,
+		"schema": SchemaJSON(sh.Schema, HelpDepth),
+	}
+}
+
+// formatSchemaTree writes the fields of s as an indented tree, one line per
+// field with its type, whether it is required, its description and enum.
+func formatSchemaTree(b *strings.Builder, s *Schema, indent string, depth int) {
+	obj := schemaChildren(s)
+	if obj == nil || depth <= 0 {
+		return
+	}
+	width := 0
+	for _, p := range obj.Properties {
+		width = max(width, len(p.Name))
+	}
+	for _, p := range obj.Properties {
+		line := fmt.Sprintf("%s%-*s <%s>", indent, width, p.Name, schemaTypeName(p.Schema))
+		if p.Required {
+			line += " (required)"
+		}
+		if p.Schema != nil && p.Schema.Nullable {
+			line += " (nullable)"
+		}
+		if p.Schema != nil && p.Schema.Description != "" {
+			line += " " + strings.Join(strings.Fields(p.Schema.Description), " ")
+		}
+		if enum := schemaEnum(p.Schema); len(enum) > 0 {
+			line += fmt.Sprintf(" (one of: %s)", strings.Join(enum, ", "))
+		}
+		b.WriteString(line + "\n")
+		formatSchemaTree(b, p.Schema, indent+"  ", depth-1)
+	}
+}
+
+// formatHelpSchema renders the InputJSON or OutputJSON of a HelpContent for
+// the human-readable help.
+func formatHelpSchema(v interface{}) string {
+	switch v := v.(type) {
+	case SchemaHelp:
+		var b strings.Builder
+		fmt.Fprintf(&b, "  %s: <%s>\n", v.Label, schemaTypeName(v.Schema))
+		formatSchemaTree(&b, v.Schema, "    ", HelpDepth)
+		return b.String()
+	case string:
+		return "  " + v + "\n"
+	case nil:
+		return ""
+	}
+	return fmt.Sprintf("  %v\n", v)
+}
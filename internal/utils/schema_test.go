@@ -0,0 +1,154 @@
+package utils
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// useTestSchemas replaces Schemas for one test with a series model whose
+// related series refer back to it, so that rendering must stop at a depth.
+func useTestSchemas(t *testing.T) {
+	t.Helper()
+	saved := Schemas
+	t.Cleanup(func() { Schemas = saved })
+	Schemas = map[string]*Schema{
+		"TypeV1": {Type: "string", Enum: []string{"Manga", "Novel"}},
+		"SeriesV1": {Type: "object", Description: "A series.", Properties: []SchemaProperty{
+			{Name: "id", Required: true, Schema: &Schema{Type: "integer(int64)"}},
+			{Name: "title", Required: true, Schema: &Schema{Type: "string", Description: "Main   title,\n  as listed."}},
+			{Name: "year", Schema: &Schema{Type: "string", Nullable: true}},
+			{Name: "type", Schema: &Schema{Ref: "TypeV1"}},
+			{Name: "formats", Schema: &Schema{Type: "array", Items: &Schema{Ref: "TypeV1"}}},
+			{Name: "related", Schema: &Schema{Type: "array", Items: &Schema{Ref: "SeriesV1"}}},
+			{Name: "counts", Schema: &Schema{Type: "object", Values: &Schema{Type: "integer"}}},
+			{Name: "value", Schema: &Schema{OneOf: []*Schema{{Type: "string"}, {Ref: "TypeV1"}}}},
+		}},
+	}
+}
+
+func TestFormatSchemaTree(t *testing.T) {
+	useTestSchemas(t)
+	tests := []struct {
+		depth int
+		want  string
+	}{
+		{0, ""},
+		{1, `
+id      <integer(int64)> (required)
+title   <string> (required) Main title, as listed.
+year    <string> (nullable)
+type    <TypeV1> (one of: Manga, Novel)
+formats <[]TypeV1> (one of: Manga, Novel)
+related <[]SeriesV1>
+counts  <map[string]integer>
+value   <string | TypeV1>
+`},
+		// The recursive related series end where the depth does.
+		{2, `
+id      <integer(int64)> (required)
+title   <string> (required) Main title, as listed.
+year    <string> (nullable)
+type    <TypeV1> (one of: Manga, Novel)
+formats <[]TypeV1> (one of: Manga, Novel)
+related <[]SeriesV1>
+  id      <integer(int64)> (required)
+  title   <string> (required) Main title, as listed.
+  year    <string> (nullable)
+  type    <TypeV1> (one of: Manga, Novel)
+  formats <[]TypeV1> (one of: Manga, Novel)
+  related <[]SeriesV1>
+  counts  <map[string]integer>
+  value   <string | TypeV1>
+counts  <map[string]integer>
+value   <string | TypeV1>
+`},
+	}
+	for _, tt := range tests {
+		var b strings.Builder
+		formatSchemaTree(&b, &Schema{Ref: "SeriesV1"}, "", tt.depth)
+		if want := strings.TrimPrefix(tt.want, "\n"); b.String() != want {
+			t.Errorf("formatSchemaTree(depth %d) =\n%s\nwant\n%s", tt.depth, b.String(), want)
+		}
+	}
+
+	// Unknown references and scalars have no fields.
+	for _, s := range []*Schema{nil, {Ref: "MissingV1"}, {Type: "string"}, {Ref: "TypeV1"}} {
+		var b strings.Builder
+		formatSchemaTree(&b, s, "", 3)
+		if b.Len() != 0 {
+			t.Errorf("formatSchemaTree(%+v) = %q; want nothing", s, b.String())
+		}
+	}
+}
+
+func TestFormatHelpSchema(t *testing.T) {
+	useTestSchemas(t)
+	defer func(depth int) { HelpDepth = depth }(HelpDepth)
+	HelpDepth = 1
+	got := formatHelpSchema(SchemaHelp{Label: "Schema (on 200)", Schema: &Schema{Type: "array", Items: &Schema{Ref: "TypeV1"}}})
+	if want := "  Schema (on 200): <[]TypeV1>\n"; got != want {
+		t.Errorf("formatHelpSchema(array of enums) = %q; want %q", got, want)
+	}
+	got = formatHelpSchema(SchemaHelp{Label: "Request Body Schema", Schema: &Schema{Ref: "SeriesV1"}})
+	want := `  Request Body Schema: <SeriesV1>
+    id      <integer(int64)> (required)
+    title   <string> (required) Main title, as listed.
+    year    <string> (nullable)
+    type    <TypeV1> (one of: Manga, Novel)
+    formats <[]TypeV1> (one of: Manga, Novel)
+    related <[]SeriesV1>
+    counts  <map[string]integer>
+    value   <string | TypeV1>
+`
+	if got != want {
+		t.Errorf("formatHelpSchema(SeriesV1) at depth 1 =\n%s\nwant\n%s", got, want)
+	}
+	if got := formatHelpSchema("Plain text body"); got != "  Plain text body\n" {
+		t.Errorf("formatHelpSchema(string) = %q", got)
+	}
+}
+
+func TestSchemaJSON(t *testing.T) {
+	useTestSchemas(t)
+	tests := []struct {
+		depth int
+		want  string
+	}{
+		{0, `{"description":"A series.","type":"SeriesV1"}`},
+		{1, `{"description":"A series.","properties":{` +
+			`"counts":{"type":"map[string]integer"},` +
+			`"formats":{"enum":["Manga","Novel"],"type":"[]TypeV1"},` +
+			`"id":{"required":true,"type":"integer(int64)"},` +
+			`"related":{"type":"[]SeriesV1"},` +
+			`"title":{"description":"Main   title,\n  as listed.","required":true,"type":"string"},` +
+			`"type":{"enum":["Manga","Novel"],"type":"TypeV1"},` +
+			`"value":{"type":"string | TypeV1"},` +
+			`"year":{"nullable":true,"type":"string"}` +
+			`},"type":"SeriesV1"}`},
+	}
+	for _, tt := range tests {
+		data, _ := json.Marshal(SchemaJSON(&Schema{Ref: "SeriesV1"}, tt.depth))
+		if string(data) != tt.want {
+			t.Errorf("SchemaJSON(depth %d) =\n%s\nwant\n%s", tt.depth, data, tt.want)
+		}
+	}
+
+	// At depth 2 the related series list their fields, but not theirs.
+	data, _ := json.Marshal(SchemaJSON(&Schema{Ref: "SeriesV1"}, 2))
+	var out struct {
+		Properties map[string]struct {
+			Type       string
+			Properties map[string]json.RawMessage
+		}
+	}
+	json.Unmarshal(data, &out)
+	related := out.Properties["related"]
+	if related.Type != "[]SeriesV1" || len(related.Properties) != 8 || strings.Contains(string(related.Properties["related"]), "properties") {
+		t.Errorf("SchemaJSON(depth 2) related = %+v; want the 8 fields of SeriesV1 without theirs", related)
+	}
+
+	if data, _ := json.Marshal(SchemaJSON(&Schema{Ref: "MissingV1"}, 2)); string(data) != `{"type":"MissingV1"}` {
+		t.Errorf("SchemaJSON(unknown reference) = %s", data)
+	}
+}
@@ -0,0 +1,228 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/TheDucker1/mangaupdatescli/internal/openapi"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// SchemaIssue is a way in which a JSON document does not match its schema.
+type SchemaIssue struct {
+	Pointer string `json:"pointer"` // JSON pointer to the value; "" is the whole document
+	Problem string `json:"problem"` // "unknown", "missing", "type" or "enum"
+	Message string `json:"message"`
+}
+
+func (i SchemaIssue) String() string {
+	pointer := i.Pointer
+	if pointer == "" {
+		pointer = "/"
+	}
+	return pointer + ": " + i.Message
+}
+
+// ValidateJSON checks the JSON document data against s. It reports fields
+// that s does not declare, required fields that are missing, and values of
+// the wrong type or outside their enum, in document order.
+func ValidateJSON(s *Schema, data []byte) ([]SchemaIssue, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("not a JSON document: %w", err)
+	}
+	return ValidateValue(s, v), nil
+}
+
+// ValidateValue is ValidateJSON for a decoded document. Numbers must have
+// been decoded as json.Number.
+func ValidateValue(s *Schema, v interface{}) []SchemaIssue {
+	var issues []SchemaIssue
+	validateValue(s, v, "", &issues)
+	return issues
+}
+
+func validateValue(s *Schema, v interface{}, pointer string, issues *[]SchemaIssue) {
+	t := resolveSchema(s)
+	if t == nil {
+		return
+	}
+	mismatch := func() {
+		*issues = append(*issues, SchemaIssue{pointer, "type", fmt.Sprintf("got %s, want %s", jsonTypeName(v), schemaTypeName(s))})
+	}
+	if v == nil {
+		if !s.Nullable && !t.Nullable && (t.Type != "" || len(t.Properties) > 0) {
+			mismatch()
+		}
+		return
+	}
+	if len(t.OneOf) > 0 {
+		for _, alt := range t.OneOf {
+			if len(ValidateValue(alt, v)) == 0 {
+				return
+			}
+		}
+		mismatch()
+		return
+	}
+
+	base, _, _ := strings.Cut(t.Type, "(")
+	switch {
+	case base == "array" || t.Items != nil:
+		list, ok := v.([]interface{})
+		if !ok {
+			mismatch()
+			return
+		}
+		for i, x := range list {
+			validateValue(t.Items, x, pointer+"/"+strconv.Itoa(i), issues)
+		}
+	case base == "object" || len(t.Properties) > 0 || t.Values != nil:
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			mismatch()
+			return
+		}
+		known := make(map[string]bool, len(t.Properties))
+		for _, p := range t.Properties {
+			known[p.Name] = true
+			x, ok := obj[p.Name]
+			if !ok {
+				if p.Required {
+					*issues = append(*issues, SchemaIssue{pointer + "/" + escapePointer(p.Name), "missing", "required field is missing"})
+				}
+				continue
+			}
+			validateValue(p.Schema, x, pointer+"/"+escapePointer(p.Name), issues)
+		}
+		for _, name := range slices.Sorted(maps.Keys(obj)) {
+			switch {
+			case known[name]:
+			case t.Values != nil:
+				validateValue(t.Values, obj[name], pointer+"/"+escapePointer(name), issues)
+			case len(t.Properties) > 0:
+				*issues = append(*issues, SchemaIssue{pointer + "/" + escapePointer(name), "unknown", fmt.Sprintf("field is not in %s", schemaTypeName(s))})
+			}
+		}
+	case base == "string":
+		if _, ok := v.(string); !ok {
+			mismatch()
+			return
+		}
+	case base == "integer":
+		n, ok := v.(json.Number)
+		if !ok || strings.ContainsAny(n.String(), ".eE") {
+			mismatch()
+			return
+		}
+	case base == "number":
+		if _, ok := v.(json.Number); !ok {
+			mismatch()
+			return
+		}
+	case base == "boolean":
+		if _, ok := v.(bool); !ok {
+			mismatch()
+			return
+		}
+	}
+	if len(t.Enum) > 0 && !slices.Contains(t.Enum, fmt.Sprint(v)) {
+		text, _ := json.Marshal(v)
+		*issues = append(*issues, SchemaIssue{pointer, "enum", fmt.Sprintf("%s is not one of %s", text, strings.Join(t.Enum, ", "))})
+	}
+}
+
+// jsonTypeName names the JSON type of a decoded value.
+func jsonTypeName(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number:
+		if strings.ContainsAny(v.String(), ".eE") {
+			return "number"
+		}
+		return "integer"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+// escapePointer escapes a field name for use in a JSON pointer.
+func escapePointer(name string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(name, "~", "~0"), "/", "~1")
+}
+
+// StrictValidation makes CheckResponse fail on a response that does not
+// match its schema, instead of only warning; the --strict global flag sets
+// it.
+var StrictValidation bool
+
+// ErrSchemaMismatch is returned by CheckResponse in strict mode.
+var ErrSchemaMismatch = errors.New("the response does not match its schema in the OpenAPI spec")
+
+// maxReportedIssues bounds how many problems CheckResponse lists for one
+// response.
+const maxReportedIssues = 20
+
+var checkMu sync.Mutex
+
+// CheckResponse is the response check of the --validate global flag (see
+// apiclient.WithResponseCheck). It looks up the operation of method and
+// path, relative to the API root, in the embedded spec and reports on
+// stderr how body differs from the operation's success response schema.
+func CheckResponse(method, path string, body []byte) error {
+	spec, err := openapi.Load()
+	if err != nil {
+		return err
+	}
+	op, _, ok := spec.Match(method, path)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Warning: %s %s is not in the OpenAPI spec; its response is not validated.\n", method, path)
+		return nil
+	}
+	schema, ok := ResponseSchemas[op.ID]
+	if !ok {
+		return nil
+	}
+	issues, err := ValidateJSON(schema, body)
+	if err != nil {
+		issues = []SchemaIssue{{Problem: "type", Message: err.Error()}}
+	}
+	if len(issues) == 0 {
+		return nil
+	}
+
+	checkMu.Lock()
+	defer checkMu.Unlock()
+	fmt.Fprintf(os.Stderr, "Warning: the response of %s (%s %s) does not match its schema:\n", op.ID, method, path)
+	for i, issue := range issues {
+		if i == maxReportedIssues {
+			fmt.Fprintf(os.Stderr, "  ... and %d more\n", len(issues)-i)
+			break
+		}
+		fmt.Fprintf(os.Stderr, "  %s\n", issue)
+	}
+	if StrictValidation {
+		problems := "problems"
+		if len(issues) == 1 {
+			problems = "problem"
+		}
+		return fmt.Errorf("%w (%s: %d %s)", ErrSchemaMismatch, op.ID, len(issues), problems)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package utils
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+// captureStderr runs fn and returns what it wrote to os.Stderr.
+func captureStderr(t *testing.T, fn func()) string {
+	t.Helper()
+	f, err := os.CreateTemp(t.TempDir(), "stderr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	saved := os.Stderr
+	os.Stderr = f
+	defer func() { os.Stderr = saved }()
+	fn()
+	f.Seek(0, io.SeekStart)
+	out, _ := io.ReadAll(f)
+	return string(out)
+}
+
+func TestCheckResponse(t *testing.T) {
+	defer func(strict bool) { StrictValidation = strict }(StrictValidation)
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		strict   bool
+		wantErr  string // "" means no error
+		wantWarn []string
+	}{
+		{"matches", "GET", "/genres", `[{"id":1,"genre":"Action"}]`, true, "", nil},
+		{"one problem", "GET", "/genres", `[{"id":1,"genre":"Action","bogus":true}]`, true,
+			"(retrieveGenres: 1 problem)", []string{"/0/bogus: field is not in GenreModelV1"}},
+		{"two problems", "GET", "/genres", `[{"id":"1"}]`, true,
+			"(retrieveGenres: 2 problems)", []string{"/0/id: got string, want integer(int64)", "/0/genre: required field is missing"}},
+		{"warning only", "GET", "/genres", `[{"id":"1","genre":"Action"}]`, false,
+			"", []string{"does not match its schema", "/0/id: got string"}},
+		{"not JSON", "GET", "/genres", `<html>`, true,
+			"(retrieveGenres: 1 problem)", []string{"/: not a JSON document"}},
+		{"unknown path", "GET", "/nowhere/1", `{}`, true, "", []string{"is not in the OpenAPI spec"}},
+		// Only the first maxReportedIssues problems are listed.
+		{"many problems", "GET", "/genres", "[" + strings.Repeat(`{"id":1},`, 24) + `{"id":1}]`, true,
+			"(retrieveGenres: 25 problems)", []string{"/19/genre: required field is missing\n  ... and 5 more\n"}},
+	}
+	for _, tt := range tests {
+		StrictValidation = tt.strict
+		var err error
+		stderr := captureStderr(t, func() {
+			err = CheckResponse(tt.method, tt.path, []byte(tt.body))
+		})
+		switch {
+		case tt.wantErr == "" && err != nil:
+			t.Errorf("%s: err = %v; want nil", tt.name, err)
+		case tt.wantErr != "" && (!errors.Is(err, ErrSchemaMismatch) || !strings.HasSuffix(err.Error(), tt.wantErr)):
+			t.Errorf("%s: err = %v; want ErrSchemaMismatch ending in %q", tt.name, err, tt.wantErr)
+		}
+		for _, want := range tt.wantWarn {
+			if !strings.Contains(stderr, want) {
+				t.Errorf("%s: stderr does not contain %q:\n%s", tt.name, want, stderr)
+			}
+		}
+		if tt.wantWarn == nil && stderr != "" {
+			t.Errorf("%s: unexpected warning:\n%s", tt.name, stderr)
+		}
+	}
+}
+
+func TestValidateJSON(t *testing.T) {
+	defer func(saved map[string]*Schema) { Schemas = saved }(Schemas)
+	Schemas = map[string]*Schema{
+		"KindV1": {Type: "string", Enum: []string{"Manga", "Novel"}},
+		"ItemV1": {Type: "object", Properties: []SchemaProperty{
+			{Name: "id", Required: true, Schema: &Schema{Type: "integer(int64)"}},
+			{Name: "title", Schema: &Schema{Type: "string"}},
+			{Name: "volume", Schema: &Schema{Type: "string", Nullable: true}},
+			{Name: "rating", Schema: &Schema{Type: "number"}},
+			{Name: "done", Schema: &Schema{Type: "boolean"}},
+			{Name: "kind", Schema: &Schema{Ref: "KindV1"}},
+			{Name: "kind_or_null", Schema: &Schema{Ref: "KindV1", Nullable: true}},
+			{Name: "value", Schema: &Schema{OneOf: []*Schema{{Type: "string"}, {Type: "integer"}}}},
+			{Name: "counts", Schema: &Schema{Type: "object", Values: &Schema{Type: "integer"}}},
+			{Name: "tags", Schema: &Schema{Type: "array", Items: &Schema{Type: "string"}}},
+			{Name: "a/b", Schema: &Schema{Type: "string"}},
+		}},
+	}
+	item := &Schema{Ref: "ItemV1"}
+
+	tests := []struct {
+		name string
+		data string
+		want []string // issues as "pointer problem"
+	}{
+		{"valid", `{"id":1,"title":"x","rating":4.5,"done":true,"kind":"Manga","tags":["a"]}`, nil},
+		{"unknown field", `{"id":1,"extra":1,"other":2}`, []string{"/extra unknown", "/other unknown"}},
+		{"missing required", `{"title":"x"}`, []string{"/id missing"}},
+		{"integer with fraction", `{"id":1.5}`, []string{"/id type"}},
+		{"number accepts integer", `{"id":1,"rating":4}`, nil},
+		{"wrong types", `{"id":1,"title":2,"done":"yes","tags":"a"}`, []string{"/title type", "/done type", "/tags type"}},
+		{"array item", `{"id":1,"tags":["a",2]}`, []string{"/tags/1 type"}},
+		{"enum", `{"id":1,"kind":"Comic"}`, []string{"/kind enum"}},
+		{"oneOf string", `{"id":1,"value":"x"}`, nil},
+		{"oneOf integer", `{"id":1,"value":2}`, nil},
+		{"oneOf neither", `{"id":1,"value":true}`, []string{"/value type"}},
+		{"nullable", `{"id":1,"volume":null}`, nil},
+		{"not nullable", `{"id":1,"title":null}`, []string{"/title type"}},
+		{"nullable reference", `{"id":1,"kind_or_null":null,"kind":null}`, []string{"/kind type"}},
+		{"map values", `{"id":1,"counts":{"a":1,"b":"2"}}`, []string{"/counts/b type"}},
+		{"escaped pointer", `{"id":1,"a/b":1}`, []string{"/a~1b type"}},
+		{"root type", `[]`, []string{" type"}},
+	}
+	for _, tt := range tests {
+		issues, err := ValidateJSON(item, []byte(tt.data))
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		var got []string
+		for _, issue := range issues {
+			got = append(got, issue.Pointer+" "+issue.Problem)
+		}
+		if strings.Join(got, ", ") != strings.Join(tt.want, ", ") {
+			t.Errorf("%s: ValidateJSON(%s) = %q; want %q", tt.name, tt.data, got, tt.want)
+		}
+	}
+
+	if _, err := ValidateJSON(item, []byte(`{"id":`)); err == nil {
+		t.Error("ValidateJSON accepted a truncated document")
+	}
+}
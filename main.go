@@ -1,38 +1,139 @@
 package main
 
-//go:generate go run tools/helpcodegen/main.go --spec openapi.yaml --outdir_root cmd --clean
+//go:generate go run ./tools/helpcodegen --spec internal/openapi/openapi.yaml --outdir_root cmd --models_out mangaupdates/models_generated.go --schemas_out internal/utils/schemas_generated.go --subprograms_out subprograms_generated.go --clean
 
 import (
+	"context"
 	"fmt"
-	"mangaupdatescli/cmd/authors"
-	"mangaupdatescli/cmd/categories"
-	"mangaupdatescli/cmd/genre"
-	"mangaupdatescli/cmd/groups"
-	"mangaupdatescli/cmd/misc"
-	"mangaupdatescli/cmd/publishers"
-	"mangaupdatescli/cmd/releases"
-	"mangaupdatescli/cmd/series"
+	"github.com/TheDucker1/mangaupdatescli/cmd/account"
+	"github.com/TheDucker1/mangaupdatescli/cmd/authors"
+	"github.com/TheDucker1/mangaupdatescli/cmd/cache"
+	"github.com/TheDucker1/mangaupdatescli/cmd/call"
+	"github.com/TheDucker1/mangaupdatescli/cmd/categories"
+	"github.com/TheDucker1/mangaupdatescli/cmd/doctor"
+	"github.com/TheDucker1/mangaupdatescli/cmd/genre"
+	"github.com/TheDucker1/mangaupdatescli/cmd/groups"
+	"github.com/TheDucker1/mangaupdatescli/cmd/misc"
+	"github.com/TheDucker1/mangaupdatescli/cmd/mock"
+	"github.com/TheDucker1/mangaupdatescli/cmd/profile"
+	"github.com/TheDucker1/mangaupdatescli/cmd/publishers"
+	"github.com/TheDucker1/mangaupdatescli/cmd/releases"
+	"github.com/TheDucker1/mangaupdatescli/cmd/series"
+	"github.com/TheDucker1/mangaupdatescli/cmd/spec"
+	"github.com/TheDucker1/mangaupdatescli/cmd/validate"
+	"github.com/TheDucker1/mangaupdatescli/internal/apiclient"
+	"github.com/TheDucker1/mangaupdatescli/internal/config"
+	"github.com/TheDucker1/mangaupdatescli/internal/openapi"
+	"github.com/TheDucker1/mangaupdatescli/internal/utils"
+	"log"
+	"net/http"
 	"os"
+	"os/signal"
+	"sort"
+	"syscall"
 )
 
+// generatedSubprogram is a subprogram for an API tag that has no
+// hand-written package under cmd, only generated commands.
+type generatedSubprogram struct {
+	handle    func(ctx context.Context, command string, args []string)
+	printHelp func(jsonFormat bool)
+}
+
+// generatedSubprograms is populated by subprograms_generated.go, which
+// 'go generate' writes along with the generated commands.
+var generatedSubprograms = make(map[string]generatedSubprogram)
+
 func printTopLevelHelp() {
 	fmt.Println("MangaUpdates API CLI Tool")
-	fmt.Println("Usage: mangaupdatescli <subprogram> <command> [arguments...]")
+	fmt.Println("Usage: mangaupdatescli [global flags] <subprogram> <command> [arguments...]")
 	fmt.Println("\nAvailable Subprograms:")
-	fmt.Println("  authors")
-	fmt.Println("  categories")
-	fmt.Println("  genre")
-	fmt.Println("  groups")
-	fmt.Println("  misc")
-	fmt.Println("  publishers")
-	fmt.Println("  releases")
-	fmt.Println("  series")
+	names := []string{"account", "authors", "cache", "call", "categories", "doctor", "genre", "groups", "misc", "mock", "profile", "publishers", "releases", "series", "spec", "validate"}
+	for name := range generatedSubprograms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Println("  " + name)
+	}
 	fmt.Println("\nUse 'mangaupdatescli <subprogram> -h' or '-hh' for command list and descriptions of a subprogram.")
 	fmt.Println("Use 'mangaupdatescli <subprogram> <command> -h' for JSON help on a specific command.")
 	fmt.Println("Use 'mangaupdatescli <subprogram> <command> -hh' for human-readable help on a specific command.")
+	printGlobalFlags()
 }
 
 func main() {
+	globalArgs, rest := extractGlobalFlags(globalFlags, os.Args[1:])
+	if err := globalFlags.Parse(globalArgs); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if globalOpts.retries < 0 || globalOpts.retryMaxWait <= 0 {
+		fmt.Fprintln(os.Stderr, "Error: --retries must be >= 0 and --retry-max-wait must be positive")
+		os.Exit(1)
+	}
+	clientOpts := []apiclient.Option{
+		apiclient.WithRetryPolicy(apiclient.RetryPolicy{
+			MaxRetries: globalOpts.retries,
+			BaseDelay:  apiclient.DefaultRetryPolicy.BaseDelay,
+			MaxWait:    globalOpts.retryMaxWait,
+		}),
+	}
+	if globalOpts.helpDepth < 0 {
+		fmt.Fprintln(os.Stderr, "Error: --help-depth must be >= 0")
+		os.Exit(1)
+	}
+	utils.HelpDepth = globalOpts.helpDepth
+	if globalOpts.rateLimit < 0 {
+		fmt.Fprintln(os.Stderr, "Error: --rate-limit must be >= 0")
+		os.Exit(1)
+	}
+	if globalOpts.record != "" && globalOpts.replay != "" {
+		fmt.Fprintln(os.Stderr, "Error: --record and --replay cannot be used together")
+		os.Exit(1)
+	}
+	// Cassettes must see every request, so neither mode may be served from the cache.
+	switch {
+	case globalOpts.record != "":
+		globalOpts.noCache = true
+	case globalOpts.replay != "":
+		globalOpts.noCache = true
+		globalOpts.rateLimit = 0
+	}
+	var tracer *log.Logger
+	if globalOpts.trace {
+		tracer = log.New(os.Stderr, "trace: ", log.Ltime|log.Lmicroseconds)
+		clientOpts = append(clientOpts, apiclient.WithTrace(tracer))
+	}
+	utils.StrictValidation = globalOpts.strict
+	if globalOpts.validate {
+		if _, err := openapi.Load(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --validate needs the OpenAPI spec: %v\n", err)
+			os.Exit(1)
+		}
+		clientOpts = append(clientOpts, apiclient.WithResponseCheck(utils.CheckResponse))
+	}
+	cacheDir, cacheDirErr := config.CacheDir()
+	if globalOpts.rateLimit > 0 {
+		statePath := ""
+		if cacheDirErr == nil {
+			statePath = apiclient.DefaultRateLimiterStatePath(cacheDir)
+		}
+		burst := max(1, int(globalOpts.rateLimit))
+		clientOpts = append(clientOpts, apiclient.WithRateLimiter(apiclient.NewRateLimiter(globalOpts.rateLimit, burst, statePath)))
+	}
+	if cacheDirErr == nil {
+		cacheMode := apiclient.CacheUse
+		if globalOpts.noCache {
+			cacheMode = apiclient.CacheBypass
+		} else if globalOpts.refresh {
+			cacheMode = apiclient.CacheRefresh
+		}
+		clientOpts = append(clientOpts, apiclient.WithCache(apiclient.NewCache(apiclient.DefaultCacheDir(cacheDir)), cacheMode))
+	}
+	// Everything below only sees the subprogram, command and its arguments.
+	os.Args = append(os.Args[:1:1], rest...)
+
 	if len(os.Args) < 2 {
 		printTopLevelHelp()
 		os.Exit(1)
@@ -46,6 +147,83 @@ func main() {
 		return
 	}
 
+	// Attach the active profile's session (if any) to every API request.
+	config.SelectProfile(globalOpts.profile)
+	profileName, activeProfile := config.DefaultProfile, &config.Profile{}
+	if cfg, err := config.Load(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: ignoring stored profiles: %v\n", err)
+	} else {
+		_, exists := cfg.Profiles[cfg.ActiveProfileName()]
+		if !exists && cfg.ActiveProfileName() != config.DefaultProfile && subprogram != "profile" {
+			fmt.Fprintf(os.Stderr, "Error: unknown profile %q (see 'mangaupdatescli profile list')\n", cfg.ActiveProfileName())
+			os.Exit(1)
+		}
+		profileName, activeProfile = cfg.ActiveProfileName(), cfg.ActiveProfile()
+	}
+
+	// The base URL comes from --api-url, then the environment, then the profile.
+	apiURL, apiURLSource := globalOpts.apiURL, "--api-url"
+	if apiURL == "" {
+		apiURL, apiURLSource = os.Getenv("MANGAUPDATESCLI_API_URL"), "MANGAUPDATESCLI_API_URL"
+	}
+	if apiURL == "" {
+		apiURL, apiURLSource = activeProfile.APIURL, "profile api_url"
+	}
+	if apiURL != "" {
+		clientOpts = append(clientOpts, apiclient.WithBaseURL(apiURL))
+	} else {
+		apiURLSource = "default"
+	}
+	clientOpts = append(clientOpts, apiclient.WithSessionToken(sessionToken(activeProfile, profileName, apiURL)))
+
+	network, err := resolveNetworkSettings(activeProfile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	network.Profile, network.APIURLSource = profileName, apiURLSource
+	doctor.SetSettings(network)
+	if network.Transport.InsecureSkipVerify {
+		fmt.Fprintln(os.Stderr, "WARNING: TLS certificate verification is DISABLED (insecure_skip_verify). Anyone on the network path can read and alter API traffic, including your session token.")
+	}
+	clientOpts = append(clientOpts,
+		apiclient.WithTimeout(network.Timeout),
+		apiclient.WithMaxResponseSize(int64(globalOpts.maxResponseSize)),
+		apiclient.WithTransactionWait(!globalOpts.noFollowTransactions, apiclient.WaitOptions{}))
+
+	// Replay never touches the network; recording and HAR wrap the real transport.
+	var transport http.RoundTripper
+	if globalOpts.replay != "" {
+		transport = apiclient.NewReplayer(globalOpts.replay)
+	} else {
+		base, err := apiclient.NewTransport(network.Transport)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		transport = base
+		if globalOpts.record != "" {
+			transport = apiclient.NewRecorder(globalOpts.record, base, int64(globalOpts.maxResponseSize))
+		}
+	}
+	if globalOpts.har != "" {
+		transport = apiclient.NewHARRecorder(globalOpts.har, transport, int64(globalOpts.maxResponseSize))
+	}
+	clientOpts = append(clientOpts, apiclient.WithTransport(transport))
+	client, err := apiclient.New(clientOpts...)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	apiclient.SetDefault(client)
+	if tracer != nil {
+		tracer.Printf("API base URL %s (from %s), profile %q", client.BaseURL(), apiURLSource, profileName)
+	}
+
+	// Ctrl-C cancels in-flight requests; handlers report it via PrintErrorAndExit.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	var command string
 	var actualArgs []string
 
@@ -78,55 +256,117 @@ func main() {
 	implicitJsonHelp := len(os.Args) <= 2 || os.Args[2] == "-h"
 
 	switch subprogram {
+	case "account":
+		if command == "help" && len(actualArgs) == 0 {
+			account.PrintAccountSubprogramHelp(implicitJsonHelp)
+			return
+		}
+		account.HandleCommand(ctx, command, actualArgs)
 	case "authors":
 		if command == "help" && len(actualArgs) == 0 {
 			authors.PrintAuthorsSubprogramHelp(implicitJsonHelp)
 			return
 		}
-		authors.HandleCommand(command, actualArgs)
+		authors.HandleCommand(ctx, command, actualArgs)
+	case "cache":
+		if command == "help" && len(actualArgs) == 0 {
+			cache.PrintCacheSubprogramHelp(implicitJsonHelp)
+			return
+		}
+		cache.HandleCommand(ctx, command, actualArgs)
+	case "call":
+		// The command is the operation ID or HTTP method to call.
+		if command == "help" && len(actualArgs) == 0 {
+			call.PrintCallHelp(implicitJsonHelp)
+			return
+		}
+		call.HandleCommand(ctx, command, actualArgs)
 	case "categories":
 		if command == "help" && len(actualArgs) == 0 {
 			categories.PrintCategoriesSubprogramHelp(implicitJsonHelp)
 			return
 		}
-		categories.HandleCommand(command, actualArgs)
+		categories.HandleCommand(ctx, command, actualArgs)
+	case "doctor":
+		// A bare 'doctor' runs the checks rather than listing commands.
+		if len(os.Args) <= 2 {
+			command = "check"
+		}
+		if command == "help" && len(actualArgs) == 0 {
+			doctor.PrintDoctorSubprogramHelp(implicitJsonHelp)
+			return
+		}
+		doctor.HandleCommand(ctx, command, actualArgs)
 	case "genre":
 		if command == "help" && len(actualArgs) == 0 {
 			genre.PrintGenreSubprogramHelp(implicitJsonHelp)
 			return
 		}
-		genre.HandleCommand(command, actualArgs)
+		genre.HandleCommand(ctx, command, actualArgs)
 	case "groups":
 		if command == "help" && len(actualArgs) == 0 {
 			groups.PrintGroupsSubprogramHelp(implicitJsonHelp)
 			return
 		}
-		groups.HandleCommand(command, actualArgs)
+		groups.HandleCommand(ctx, command, actualArgs)
 	case "misc":
 		if command == "help" && len(actualArgs) == 0 { // e.g. ./mangaupdatescli misc -h
 			misc.PrintMiscSubprogramHelp(implicitJsonHelp) // Pass true if JSON help requested
 			return
 		}
-		misc.HandleCommand(command, actualArgs)
+		misc.HandleCommand(ctx, command, actualArgs)
+	case "mock":
+		if command == "help" && len(actualArgs) == 0 {
+			mock.PrintMockSubprogramHelp(implicitJsonHelp)
+			return
+		}
+		mock.HandleCommand(ctx, command, actualArgs)
+	case "profile":
+		if command == "help" && len(actualArgs) == 0 {
+			profile.PrintProfileSubprogramHelp(implicitJsonHelp)
+			return
+		}
+		profile.HandleCommand(ctx, command, actualArgs)
 	case "publishers":
 		if command == "help" && len(actualArgs) == 0 { // e.g. ./mangaupdatescli misc -h
 			publishers.PrintPublishersSubprogramHelp(implicitJsonHelp) // Pass true if JSON help requested
 			return
 		}
-		publishers.HandleCommand(command, actualArgs)
+		publishers.HandleCommand(ctx, command, actualArgs)
 	case "releases":
 		if command == "help" && len(actualArgs) == 0 { // e.g. ./mangaupdatescli misc -h
 			releases.PrintReleasesSubprogramHelp(implicitJsonHelp) // Pass true if JSON help requested
 			return
 		}
-		releases.HandleCommand(command, actualArgs)
+		releases.HandleCommand(ctx, command, actualArgs)
 	case "series":
 		if command == "help" && len(actualArgs) == 0 { // e.g. ./mangaupdatescli misc -h
 			series.PrintSeriesSubprogramHelp(implicitJsonHelp) // Pass true if JSON help requested
 			return
 		}
-		series.HandleCommand(command, actualArgs)
+		series.HandleCommand(ctx, command, actualArgs)
+	case "spec":
+		if command == "help" && len(actualArgs) == 0 {
+			spec.PrintSpecSubprogramHelp(implicitJsonHelp)
+			return
+		}
+		spec.HandleCommand(ctx, command, actualArgs)
+	case "validate":
+		// Everything after 'validate' is its arguments, not a command name.
+		if command == "help" && len(actualArgs) == 0 {
+			validate.PrintValidateHelp(implicitJsonHelp)
+			return
+		}
+		validate.HandleCommand(ctx, os.Args[2:])
 	default:
+		if sp, ok := generatedSubprograms[subprogram]; ok {
+			if command == "help" && len(actualArgs) == 0 {
+				sp.printHelp(implicitJsonHelp)
+				return
+			}
+			sp.handle(ctx, command, actualArgs)
+			return
+		}
 		fmt.Fprintf(os.Stderr, "Unknown subprogram: %s\n", subprogram)
 		printTopLevelHelp()
 		os.Exit(1)
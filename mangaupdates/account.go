@@ -0,0 +1,35 @@
+package mangaupdates
+
+import (
+	"context"
+	"errors"
+
+	"github.com/TheDucker1/mangaupdatescli/internal/apiclient"
+)
+
+// Login exchanges credentials for a session token, which WithSessionToken
+// then attaches to the requests of a Client. Any token c already has is not
+// sent.
+func (c *Client) Login(ctx context.Context, username, password string) (*AccountLoginResponseV1, error) {
+	api, err := c.api.With(apiclient.WithSessionToken(""))
+	if err != nil {
+		return nil, err
+	}
+	resp, err := decode[AccountLoginResponseV1](ctx, wrap(api), operation{
+		method: "PUT",
+		path:   "/account/login",
+		body:   AccountLoginRequestV1{Username: username, Password: password},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Context == nil || resp.Context.SessionToken == "" {
+		return nil, errors.New("login response did not contain a session token: " + resp.Reason)
+	}
+	return resp, nil
+}
+
+// Logout ends the session of c's token.
+func (c *Client) Logout(ctx context.Context) (*ApiResponseV1, error) {
+	return decode[ApiResponseV1](ctx, c, operation{method: "POST", path: "/account/logout", auth: true})
+}
@@ -0,0 +1,65 @@
+package mangaupdates
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// RetrieveAuthor returns an author.
+func (c *Client) RetrieveAuthor(ctx context.Context, id int64, opts *RetrieveOptions) (*AuthorModelV1, error) {
+	return decode[AuthorModelV1](ctx, c, retrieveAuthorOp(id, opts))
+}
+
+// RetrieveAuthorRaw is RetrieveAuthor returning the response body unread.
+func (c *Client) RetrieveAuthorRaw(ctx context.Context, id int64, opts *RetrieveOptions) (io.ReadCloser, error) {
+	return c.open(ctx, retrieveAuthorOp(id, opts))
+}
+
+func retrieveAuthorOp(id int64, opts *RetrieveOptions) operation {
+	return operation{method: "GET", path: fmt.Sprintf("/authors/%d", id), query: opts.query()}
+}
+
+// RetrieveAuthorLocks returns the locked fields of an author.
+func (c *Client) RetrieveAuthorLocks(ctx context.Context, id int64) (LocksV1, error) {
+	return decodeValue[LocksV1](ctx, c, retrieveAuthorLocksOp(id))
+}
+
+// RetrieveAuthorLocksRaw is RetrieveAuthorLocks returning the response body
+// unread.
+func (c *Client) RetrieveAuthorLocksRaw(ctx context.Context, id int64) (io.ReadCloser, error) {
+	return c.open(ctx, retrieveAuthorLocksOp(id))
+}
+
+func retrieveAuthorLocksOp(id int64) operation {
+	return operation{method: "GET", path: fmt.Sprintf("/authors/%d/locks", id)}
+}
+
+// SearchAuthors searches authors.
+func (c *Client) SearchAuthors(ctx context.Context, req *AuthorsSearchRequestV1) (*AuthorsSearchResponseV1, error) {
+	return decode[AuthorsSearchResponseV1](ctx, c, searchAuthorsOp(req))
+}
+
+// SearchAuthorsRaw is SearchAuthors returning the response body unread.
+func (c *Client) SearchAuthorsRaw(ctx context.Context, req *AuthorsSearchRequestV1) (io.ReadCloser, error) {
+	return c.open(ctx, searchAuthorsOp(req))
+}
+
+func searchAuthorsOp(req *AuthorsSearchRequestV1) operation {
+	return operation{method: "POST", path: "/authors/search", body: req}
+}
+
+// RetrieveAuthorSeries returns the series of an author.
+func (c *Client) RetrieveAuthorSeries(ctx context.Context, id int64, req *AuthorsSeriesListRequestV1) (*AuthorsSeriesListResponseV1, error) {
+	return decode[AuthorsSeriesListResponseV1](ctx, c, retrieveAuthorSeriesOp(id, req))
+}
+
+// RetrieveAuthorSeriesRaw is RetrieveAuthorSeries returning the response body
+// unread.
+func (c *Client) RetrieveAuthorSeriesRaw(ctx context.Context, id int64, req *AuthorsSeriesListRequestV1) (io.ReadCloser, error) {
+	return c.open(ctx, retrieveAuthorSeriesOp(id, req))
+}
+
+func retrieveAuthorSeriesOp(id int64, req *AuthorsSeriesListRequestV1) operation {
+	return operation{method: "POST", path: fmt.Sprintf("/authors/%d/series", id), body: req}
+}
@@ -0,0 +1,46 @@
+package mangaupdates
+
+import (
+	"context"
+	"io"
+)
+
+// FindCategoryByPrefix returns the categories starting with prefix.
+func (c *Client) FindCategoryByPrefix(ctx context.Context, prefix string) ([]CategoriesModelV1, error) {
+	return decodeValue[[]CategoriesModelV1](ctx, c, findCategoryOp("/categories/findByPrefix", prefix))
+}
+
+// FindCategoryByPrefixRaw is FindCategoryByPrefix returning the response body
+// unread.
+func (c *Client) FindCategoryByPrefixRaw(ctx context.Context, prefix string) (io.ReadCloser, error) {
+	return c.open(ctx, findCategoryOp("/categories/findByPrefix", prefix))
+}
+
+// FindCategoryByExact returns the category named exactly name.
+func (c *Client) FindCategoryByExact(ctx context.Context, name string) (*CategoriesModelV1, error) {
+	return decode[CategoriesModelV1](ctx, c, findCategoryOp("/categories/findByExact", name))
+}
+
+// FindCategoryByExactRaw is FindCategoryByExact returning the response body
+// unread.
+func (c *Client) FindCategoryByExactRaw(ctx context.Context, name string) (io.ReadCloser, error) {
+	return c.open(ctx, findCategoryOp("/categories/findByExact", name))
+}
+
+func findCategoryOp(path, category string) operation {
+	return operation{method: "POST", path: path, body: CategoriesModelUpdateV1{Category: category}}
+}
+
+// SearchCategories searches categories.
+func (c *Client) SearchCategories(ctx context.Context, req *CategoriesSearchRequestV1) (*CategoriesSearchResponseV1, error) {
+	return decode[CategoriesSearchResponseV1](ctx, c, searchCategoriesOp(req))
+}
+
+// SearchCategoriesRaw is SearchCategories returning the response body unread.
+func (c *Client) SearchCategoriesRaw(ctx context.Context, req *CategoriesSearchRequestV1) (io.ReadCloser, error) {
+	return c.open(ctx, searchCategoriesOp(req))
+}
+
+func searchCategoriesOp(req *CategoriesSearchRequestV1) operation {
+	return operation{method: "POST", path: "/categories/search", body: req}
+}
@@ -0,0 +1,195 @@
+// Package mangaupdates is a Go client for the MangaUpdates API v1, the same
+// one the mangaupdatescli commands are built on.
+//
+//	client, err := mangaupdates.New(mangaupdates.WithTimeout(10 * time.Second))
+//	if err != nil { ... }
+//	series, err := client.RetrieveSeries(ctx, 15180124327, nil)
+//
+// The operations that the CLI's hand-written commands use have a typed
+// method that decodes the response into the models of this package, and a
+// Raw variant that returns the response body unread, for callers that
+// forward it or decode it themselves. Any other operation of the spec can
+// be sent with DoRaw. The models
+// are generated from the component schemas of the API's OpenAPI document
+// (see tools/helpcodegen) into models_generated.go, and are named after
+// them. Errors are *APIError values matching the sentinel errors below, as
+// in the CLI.
+package mangaupdates
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/TheDucker1/mangaupdatescli/internal/apiclient"
+	"io"
+	"net/url"
+	"strings"
+)
+
+// Client calls the MangaUpdates API. It is safe for concurrent use.
+type Client struct {
+	api *apiclient.Client
+}
+
+// Option configures a Client; see New.
+type Option = apiclient.Option
+
+// Options accepted by New. See the apiclient documentation of each for
+// details; all of them can be combined.
+var (
+	WithBaseURL         = apiclient.WithBaseURL
+	WithTimeout         = apiclient.WithTimeout
+	WithTransport       = apiclient.WithTransport
+	WithUserAgent       = apiclient.WithUserAgent
+	WithLogger          = apiclient.WithLogger
+	WithSessionToken    = apiclient.WithSessionToken
+	WithRetryPolicy     = apiclient.WithRetryPolicy
+	WithRateLimiter     = apiclient.WithRateLimiter
+	WithMaxResponseSize = apiclient.WithMaxResponseSize
+	WithTransactionWait = apiclient.WithTransactionWait
+)
+
+// RetryPolicy controls how failed read requests are retried; see
+// WithRetryPolicy.
+type RetryPolicy = apiclient.RetryPolicy
+
+// DefaultRetryPolicy is the retry policy of a Client that sets none.
+var DefaultRetryPolicy = apiclient.DefaultRetryPolicy
+
+// RateLimiter is a token bucket that can be shared by several clients, and
+// through a state file by several processes; see WithRateLimiter.
+type RateLimiter = apiclient.RateLimiter
+
+// NewRateLimiter returns a limiter allowing rps requests per second with
+// bursts of up to burst requests. If statePath is non-empty the budget is
+// shared with other processes through that file.
+func NewRateLimiter(rps float64, burst int, statePath string) *RateLimiter {
+	return apiclient.NewRateLimiter(rps, burst, statePath)
+}
+
+// DefaultBaseURL is the production API.
+const DefaultBaseURL = apiclient.DefaultBaseURL
+
+// New returns a Client for the production API, adjusted by opts. Unlike the
+// CLI it has no cache and no rate limit unless configured.
+func New(opts ...Option) (*Client, error) {
+	api, err := apiclient.New(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{api: api}, nil
+}
+
+// wrap returns a Client sending its requests through api.
+func wrap(api *apiclient.Client) *Client {
+	return &Client{api: api}
+}
+
+// Default returns a Client using the process-wide apiclient default, which
+// the CLI configures from its flags and profile.
+func Default() *Client {
+	return wrap(apiclient.Default())
+}
+
+// operation is one API call, described independently of how its response
+// is consumed, so typed and raw methods can never disagree on the request.
+type operation struct {
+	method string
+	path   string
+	query  map[string]string
+	body   any
+	auth   bool // the endpoint only works for a logged-in user
+}
+
+// open sends op and returns the successful response body unread.
+func (c *Client) open(ctx context.Context, op operation) (io.ReadCloser, error) {
+	fullURL, err := c.api.BuildURL(op.path, op.query)
+	if err != nil {
+		return nil, err
+	}
+	var body io.ReadCloser
+	if op.auth {
+		body, _, err = c.api.StreamAuth(ctx, op.method, fullURL, op.body)
+	} else {
+		body, _, err = c.api.Stream(ctx, op.method, fullURL, op.body)
+	}
+	return body, err
+}
+
+// Request is an API call described by its OpenAPI path template, for
+// operations that have no method of their own, such as the ones the CLI
+// generates its commands for.
+type Request struct {
+	Method     string
+	Path       string            // e.g. "/lists/{id}"
+	PathParams map[string]string // values for the {name} placeholders of Path
+	Query      map[string]string
+	Body       any  // marshalled as JSON; nil sends no body
+	Auth       bool // the endpoint only works for a logged-in user
+}
+
+// DoRaw sends req and returns the successful response body unread.
+func (c *Client) DoRaw(ctx context.Context, req Request) (io.ReadCloser, error) {
+	path := req.Path
+	for name, value := range req.PathParams {
+		path = strings.ReplaceAll(path, "{"+name+"}", url.PathEscape(value))
+	}
+	if strings.ContainsRune(path, '{') {
+		return nil, fmt.Errorf("missing path parameters for %s", path)
+	}
+	return c.open(ctx, operation{method: req.Method, path: path, query: req.Query, body: req.Body, auth: req.Auth})
+}
+
+// decode sends op and decodes its response into a T.
+func decode[T any](ctx context.Context, c *Client, op operation) (*T, error) {
+	body, err := c.open(ctx, op)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	var out T
+	if err := json.NewDecoder(body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("failed to decode %s %s response: %w", op.method, op.path, err)
+	}
+	// Reading to the end hands the body to any response check (--validate).
+	if _, err := io.Copy(io.Discard, body); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// decodeValue is decode for the slice and map models, which are returned
+// by value.
+func decodeValue[T any](ctx context.Context, c *Client, op operation) (T, error) {
+	out, err := decode[T](ctx, c, op)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return *out, nil
+}
+
+// readAll sends op and returns its whole response, for non-JSON bodies.
+func readAll(ctx context.Context, c *Client, op operation) ([]byte, error) {
+	body, err := c.open(ctx, op)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	return io.ReadAll(body)
+}
+
+// RetrieveOptions are the query parameters shared by the single-record
+// retrieve operations. A nil *RetrieveOptions means the API defaults.
+type RetrieveOptions struct {
+	// UnrenderedFields returns text fields in their editable form instead
+	// of rendered HTML.
+	UnrenderedFields bool
+}
+
+func (o *RetrieveOptions) query() map[string]string {
+	if o == nil || !o.UnrenderedFields {
+		return nil
+	}
+	return map[string]string{"unrenderedFields": "true"}
+}
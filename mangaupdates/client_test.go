@@ -0,0 +1,214 @@
+package mangaupdates
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a Client, logged in and without retries unless opts
+// say otherwise, for a server answering every request with status and body.
+// It also returns the "METHOD /path?query" of the requests it received.
+func newTestClient(t *testing.T, status int, body string, opts ...Option) (*Client, *[]string) {
+	t.Helper()
+	var received []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Method+" "+r.URL.RequestURI())
+		w.WriteHeader(status)
+		io.WriteString(w, body)
+	}))
+	t.Cleanup(srv.Close)
+	c, err := New(append([]Option{
+		WithBaseURL(srv.URL),
+		WithSessionToken("token"),
+		WithRetryPolicy(RetryPolicy{MaxRetries: 0, MaxWait: time.Second}),
+		WithLogger(log.New(io.Discard, "", 0)),
+	}, opts...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c, &received
+}
+
+func TestRetrieveSeriesDecodes(t *testing.T) {
+	c, received := newTestClient(t, http.StatusOK, `{
+		"series_id": 15180124327, "title": "Berserk", "type": "Manhwa",
+		"description": null, "bayesian_rating": 9.1, "licensed": false,
+		"image": {"url": {"original": "https://example.com/b.jpg"}}}`)
+	s, err := c.RetrieveSeries(context.Background(), 15180124327, &RetrieveOptions{UnrenderedFields: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "GET /series/15180124327?unrenderedFields=true"; len(*received) != 1 || (*received)[0] != want {
+		t.Errorf("sent %q; want %q", *received, want)
+	}
+	if s.SeriesID != 15180124327 || s.Title != "Berserk" || s.Type != SeriesTypeV1Manhwa {
+		t.Errorf("RetrieveSeries = %d, %q, %q; want 15180124327, Berserk, %q", s.SeriesID, s.Title, s.Type, SeriesTypeV1Manhwa)
+	}
+	// null and absent nullable fields are nil, present ones are set even
+	// when they hold the zero value.
+	if s.Description != nil || s.Completed != nil || s.Rank != nil {
+		t.Errorf("Description, Completed, Rank = %v, %v, %v; want nil", s.Description, s.Completed, s.Rank)
+	}
+	if s.BayesianRating == nil || *s.BayesianRating != 9.1 || s.Licensed == nil || *s.Licensed || s.Image == nil {
+		t.Errorf("BayesianRating, Licensed, Image = %v, %v, %v; want 9.1, false and an image", s.BayesianRating, s.Licensed, s.Image)
+	}
+}
+
+func TestDecodeValueAndErrors(t *testing.T) {
+	c, _ := newTestClient(t, http.StatusOK, `[{"id":1,"genre":"Action"},{"id":2,"genre":"Drama"}]`)
+	genres, err := c.RetrieveGenres(context.Background())
+	if err != nil || len(genres) != 2 || genres[1].Genre != "Drama" {
+		t.Errorf("RetrieveGenres = %+v, %v; want 2 genres", genres, err)
+	}
+
+	c, _ = newTestClient(t, http.StatusOK, `{"series_id": "not a number"}`)
+	if _, err := c.RetrieveSeries(context.Background(), 1, nil); err == nil {
+		t.Error("RetrieveSeries decoded a mistyped response")
+	}
+}
+
+func TestRawReturnsTheBodyUnread(t *testing.T) {
+	const body = `{"anything": ["the", "server", "sent"]}`
+	ctx := context.Background()
+	tests := []struct {
+		name string
+		call func(c *Client) (io.ReadCloser, error)
+		want string // the request, "METHOD /path?query"
+	}{
+		{"RetrieveAuthorRaw", func(c *Client) (io.ReadCloser, error) { return c.RetrieveAuthorRaw(ctx, 1, nil) }, "GET /authors/1"},
+		{"RetrieveAuthorLocksRaw", func(c *Client) (io.ReadCloser, error) { return c.RetrieveAuthorLocksRaw(ctx, 1) }, "GET /authors/1/locks"},
+		{"SearchAuthorsRaw", func(c *Client) (io.ReadCloser, error) { return c.SearchAuthorsRaw(ctx, &AuthorsSearchRequestV1{}) }, "POST /authors/search"},
+		{"RetrieveAuthorSeriesRaw", func(c *Client) (io.ReadCloser, error) {
+			return c.RetrieveAuthorSeriesRaw(ctx, 1, &AuthorsSeriesListRequestV1{})
+		}, "POST /authors/1/series"},
+		{"FindCategoryByPrefixRaw", func(c *Client) (io.ReadCloser, error) { return c.FindCategoryByPrefixRaw(ctx, "Ad") }, "POST /categories/findByPrefix"},
+		{"FindCategoryByExactRaw", func(c *Client) (io.ReadCloser, error) { return c.FindCategoryByExactRaw(ctx, "Adventure") }, "POST /categories/findByExact"},
+		{"SearchCategoriesRaw", func(c *Client) (io.ReadCloser, error) {
+			return c.SearchCategoriesRaw(ctx, &CategoriesSearchRequestV1{})
+		}, "POST /categories/search"},
+		{"RetrieveGenresRaw", func(c *Client) (io.ReadCloser, error) { return c.RetrieveGenresRaw(ctx) }, "GET /genres"},
+		{"RetrieveGenreRaw", func(c *Client) (io.ReadCloser, error) { return c.RetrieveGenreRaw(ctx, 1, nil) }, "GET /genres/1"},
+		{"RetrieveGroupRaw", func(c *Client) (io.ReadCloser, error) { return c.RetrieveGroupRaw(ctx, 1, nil) }, "GET /groups/1"},
+		{"SearchGroupsRaw", func(c *Client) (io.ReadCloser, error) { return c.SearchGroupsRaw(ctx, &GroupsSearchRequestV1{}) }, "POST /groups/search"},
+		{"RetrieveGroupSeriesRaw", func(c *Client) (io.ReadCloser, error) { return c.RetrieveGroupSeriesRaw(ctx, 1) }, "GET /groups/1/series"},
+		{"RetrieveTimeRaw", func(c *Client) (io.ReadCloser, error) { return c.RetrieveTimeRaw(ctx) }, "GET /misc/time"},
+		{"ListOnlineUsersRaw", func(c *Client) (io.ReadCloser, error) { return c.ListOnlineUsersRaw(ctx) }, "GET /misc/online"},
+		{"SiteStatsRaw", func(c *Client) (io.ReadCloser, error) { return c.SiteStatsRaw(ctx) }, "GET /misc/stats"},
+		{"RetrieveSlowTransactionStatusRaw", func(c *Client) (io.ReadCloser, error) {
+			return c.RetrieveSlowTransactionStatusRaw(ctx, "a/b")
+		}, "GET /misc/slow-transaction-status/a%2Fb"},
+		{"RetrievePublisherRaw", func(c *Client) (io.ReadCloser, error) { return c.RetrievePublisherRaw(ctx, 1, nil) }, "GET /publishers/1"},
+		{"SearchPublishersRaw", func(c *Client) (io.ReadCloser, error) {
+			return c.SearchPublishersRaw(ctx, &PublishersSearchRequestV1{})
+		}, "POST /publishers/search"},
+		{"RetrievePublisherSeriesRaw", func(c *Client) (io.ReadCloser, error) { return c.RetrievePublisherSeriesRaw(ctx, 1) }, "GET /publishers/1/series"},
+		{"RetrievePublicationSeriesRaw", func(c *Client) (io.ReadCloser, error) {
+			return c.RetrievePublicationSeriesRaw(ctx, "Young Animal")
+		}, "GET /publishers/publication?pubname=Young+Animal"},
+		{"RetrieveReleaseRaw", func(c *Client) (io.ReadCloser, error) { return c.RetrieveReleaseRaw(ctx, 1, nil) }, "GET /releases/1"},
+		{"ListReleasesByDayRaw", func(c *Client) (io.ReadCloser, error) {
+			return c.ListReleasesByDayRaw(ctx, &ListReleasesByDayOptions{Page: 2})
+		}, "GET /releases/days?page=2"},
+		{"ReleaseRSSFeedRaw", func(c *Client) (io.ReadCloser, error) { return c.ReleaseRSSFeedRaw(ctx) }, "GET /releases/rss"},
+		{"SearchReleasesRaw", func(c *Client) (io.ReadCloser, error) { return c.SearchReleasesRaw(ctx, &ReleaseSearchRequestV1{}) }, "POST /releases/search"},
+		{"RetrieveSeriesRaw", func(c *Client) (io.ReadCloser, error) { return c.RetrieveSeriesRaw(ctx, 1, nil) }, "GET /series/1"},
+		{"SearchSeriesRaw", func(c *Client) (io.ReadCloser, error) { return c.SearchSeriesRaw(ctx, &SeriesSearchRequestV1{}) }, "POST /series/search"},
+		{"RetrieveSeriesCategoryVotesRaw", func(c *Client) (io.ReadCloser, error) {
+			return c.RetrieveSeriesCategoryVotesRaw(ctx, 1)
+		}, "GET /series/1/categories/votes"},
+		{"RetrieveSeriesCommentRaw", func(c *Client) (io.ReadCloser, error) { return c.RetrieveSeriesCommentRaw(ctx, 1, 2, nil) }, "GET /series/1/comments/2"},
+		{"RetrieveMySeriesCommentRaw", func(c *Client) (io.ReadCloser, error) {
+			return c.RetrieveMySeriesCommentRaw(ctx, 1, nil)
+		}, "GET /series/1/comments/my_comment"},
+		{"RetrieveSeriesCommentLocationRaw", func(c *Client) (io.ReadCloser, error) {
+			return c.RetrieveSeriesCommentLocationRaw(ctx, 1, 2)
+		}, "GET /series/1/comments/2/location"},
+		{"SearchSeriesCommentsRaw", func(c *Client) (io.ReadCloser, error) {
+			return c.SearchSeriesCommentsRaw(ctx, 1, &SeriesCommentSearchRequestV1{})
+		}, "POST /series/1/comments/search"},
+		{"RetrieveSeriesGroupsRaw", func(c *Client) (io.ReadCloser, error) { return c.RetrieveSeriesGroupsRaw(ctx, 1) }, "GET /series/1/groups"},
+		{"SearchSeriesHistoryRaw", func(c *Client) (io.ReadCloser, error) {
+			return c.SearchSeriesHistoryRaw(ctx, 1, &PerPageSearchRequestV1{})
+		}, "POST /series/1/history"},
+		{"RetrieveSeriesLocksRaw", func(c *Client) (io.ReadCloser, error) { return c.RetrieveSeriesLocksRaw(ctx, 1) }, "GET /series/1/locks"},
+		{"RetrieveSeriesRankLocationRaw", func(c *Client) (io.ReadCloser, error) {
+			return c.RetrieveSeriesRankLocationRaw(ctx, 1, "week")
+		}, "GET /series/1/rank/week"},
+		{"RetrieveUserSeriesRatingRaw", func(c *Client) (io.ReadCloser, error) { return c.RetrieveUserSeriesRatingRaw(ctx, 1) }, "GET /series/1/rating"},
+		{"RetrieveSeriesRatingRainbowRaw", func(c *Client) (io.ReadCloser, error) {
+			return c.RetrieveSeriesRatingRainbowRaw(ctx, 1)
+		}, "GET /series/1/ratingrainbow"},
+		{"SeriesReleaseRSSFeedRaw", func(c *Client) (io.ReadCloser, error) { return c.SeriesReleaseRSSFeedRaw(ctx, 1) }, "GET /series/1/rss"},
+		{"DoRaw", func(c *Client) (io.ReadCloser, error) {
+			return c.DoRaw(ctx, Request{Method: "DELETE", Path: "/lists/{id}", PathParams: map[string]string{"id": "7"}, Auth: true})
+		}, "DELETE /lists/7"},
+	}
+	for _, tt := range tests {
+		c, received := newTestClient(t, http.StatusOK, body)
+		rc, err := tt.call(c)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		got, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil || string(got) != body {
+			t.Errorf("%s returned %q, %v; want the body as sent", tt.name, got, err)
+		}
+		if len(*received) != 1 || (*received)[0] != tt.want {
+			t.Errorf("%s sent %q; want %q", tt.name, *received, tt.want)
+		}
+	}
+}
+
+func TestStatusErrors(t *testing.T) {
+	tests := []struct {
+		status int
+		want   error
+	}{
+		{http.StatusBadRequest, ErrValidation},
+		{http.StatusUnauthorized, ErrUnauthorized},
+		{http.StatusForbidden, ErrUnauthorized},
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusUnprocessableEntity, ErrValidation},
+		{http.StatusTooManyRequests, ErrRateLimited},
+		{http.StatusInternalServerError, ErrServer},
+		{http.StatusServiceUnavailable, ErrServer},
+	}
+	for _, tt := range tests {
+		c, _ := newTestClient(t, tt.status, `{"status":"exception","reason":"No."}`)
+		_, err := c.RetrieveGenre(context.Background(), 1, nil)
+		var apiErr *APIError
+		if !errors.Is(err, tt.want) || !errors.As(err, &apiErr) {
+			t.Errorf("status %d: err = %v; want an *APIError matching %v", tt.status, err, tt.want)
+		}
+		// The Raw variants fail the same way instead of returning the body.
+		if rc, err := c.RetrieveGenreRaw(context.Background(), 1, nil); !errors.Is(err, tt.want) {
+			if rc != nil {
+				rc.Close()
+			}
+			t.Errorf("status %d: Raw err = %v; want %v", tt.status, err, tt.want)
+		}
+	}
+
+	// Without a session, methods needing one fail before sending anything.
+	c, received := newTestClient(t, http.StatusOK, `{}`, WithSessionToken(""))
+	if _, err := c.RetrieveUserSeriesRating(context.Background(), 1); !errors.Is(err, ErrNotLoggedIn) {
+		t.Errorf("RetrieveUserSeriesRating without a session: err = %v; want ErrNotLoggedIn", err)
+	}
+	if len(*received) != 0 {
+		t.Errorf("sent %q without a session", *received)
+	}
+
+	// A server that cannot be reached is a network error.
+	c, _ = New(WithBaseURL("http://127.0.0.1:1"), WithRetryPolicy(RetryPolicy{MaxWait: time.Second}), WithLogger(log.New(io.Discard, "", 0)))
+	if _, err := c.RetrieveGenres(context.Background()); !errors.Is(err, ErrNetwork) {
+		t.Errorf("unreachable server: err = %v; want ErrNetwork", err)
+	}
+}
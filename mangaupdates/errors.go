@@ -0,0 +1,22 @@
+package mangaupdates
+
+import "github.com/TheDucker1/mangaupdatescli/internal/apiclient"
+
+// APIError is a non-2xx response. It matches one of the Err* kinds below
+// with errors.Is, and carries the API's reason and context.
+type APIError = apiclient.APIError
+
+// Errors returned by Client methods, for use with errors.Is.
+var (
+	ErrNotFound           = apiclient.ErrNotFound
+	ErrUnauthorized       = apiclient.ErrUnauthorized
+	ErrValidation         = apiclient.ErrValidation
+	ErrRateLimited        = apiclient.ErrRateLimited
+	ErrServer             = apiclient.ErrServer
+	ErrNetwork            = apiclient.ErrNetwork
+	ErrNotLoggedIn        = apiclient.ErrNotLoggedIn
+	ErrSessionExpired     = apiclient.ErrSessionExpired
+	ErrResponseTooLarge   = apiclient.ErrResponseTooLarge
+	ErrTransactionTimeout = apiclient.ErrTransactionTimeout
+	ErrTransactionFailed  = apiclient.ErrTransactionFailed
+)
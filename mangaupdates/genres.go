@@ -0,0 +1,35 @@
+package mangaupdates
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// RetrieveGenres returns all genres.
+func (c *Client) RetrieveGenres(ctx context.Context) ([]GenreModelV1, error) {
+	return decodeValue[[]GenreModelV1](ctx, c, retrieveGenresOp())
+}
+
+// RetrieveGenresRaw is RetrieveGenres returning the response body unread.
+func (c *Client) RetrieveGenresRaw(ctx context.Context) (io.ReadCloser, error) {
+	return c.open(ctx, retrieveGenresOp())
+}
+
+func retrieveGenresOp() operation {
+	return operation{method: "GET", path: "/genres"}
+}
+
+// RetrieveGenre returns a genre.
+func (c *Client) RetrieveGenre(ctx context.Context, id int64, opts *RetrieveOptions) (*GenreModelV1, error) {
+	return decode[GenreModelV1](ctx, c, retrieveGenreOp(id, opts))
+}
+
+// RetrieveGenreRaw is RetrieveGenre returning the response body unread.
+func (c *Client) RetrieveGenreRaw(ctx context.Context, id int64, opts *RetrieveOptions) (io.ReadCloser, error) {
+	return c.open(ctx, retrieveGenreOp(id, opts))
+}
+
+func retrieveGenreOp(id int64, opts *RetrieveOptions) operation {
+	return operation{method: "GET", path: fmt.Sprintf("/genres/%d", id), query: opts.query()}
+}
@@ -0,0 +1,50 @@
+package mangaupdates
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// RetrieveGroup returns a scanlation group.
+func (c *Client) RetrieveGroup(ctx context.Context, id int64, opts *RetrieveOptions) (*GroupModelV1, error) {
+	return decode[GroupModelV1](ctx, c, retrieveGroupOp(id, opts))
+}
+
+// RetrieveGroupRaw is RetrieveGroup returning the response body unread.
+func (c *Client) RetrieveGroupRaw(ctx context.Context, id int64, opts *RetrieveOptions) (io.ReadCloser, error) {
+	return c.open(ctx, retrieveGroupOp(id, opts))
+}
+
+func retrieveGroupOp(id int64, opts *RetrieveOptions) operation {
+	return operation{method: "GET", path: fmt.Sprintf("/groups/%d", id), query: opts.query()}
+}
+
+// SearchGroups searches scanlation groups.
+func (c *Client) SearchGroups(ctx context.Context, req *GroupsSearchRequestV1) (*GroupsSearchResponseV1, error) {
+	return decode[GroupsSearchResponseV1](ctx, c, searchGroupsOp(req))
+}
+
+// SearchGroupsRaw is SearchGroups returning the response body unread.
+func (c *Client) SearchGroupsRaw(ctx context.Context, req *GroupsSearchRequestV1) (io.ReadCloser, error) {
+	return c.open(ctx, searchGroupsOp(req))
+}
+
+func searchGroupsOp(req *GroupsSearchRequestV1) operation {
+	return operation{method: "POST", path: "/groups/search", body: req}
+}
+
+// RetrieveGroupSeries returns the series a group has released.
+func (c *Client) RetrieveGroupSeries(ctx context.Context, id int64) (*GroupsSeriesListResponseV1, error) {
+	return decode[GroupsSeriesListResponseV1](ctx, c, retrieveGroupSeriesOp(id))
+}
+
+// RetrieveGroupSeriesRaw is RetrieveGroupSeries returning the response body
+// unread.
+func (c *Client) RetrieveGroupSeriesRaw(ctx context.Context, id int64) (io.ReadCloser, error) {
+	return c.open(ctx, retrieveGroupSeriesOp(id))
+}
+
+func retrieveGroupSeriesOp(id int64) operation {
+	return operation{method: "GET", path: fmt.Sprintf("/groups/%d/series", id)}
+}
@@ -0,0 +1,90 @@
+package mangaupdates
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/url"
+	"time"
+
+	"github.com/TheDucker1/mangaupdatescli/internal/apiclient"
+)
+
+// WaitOptions controls how WaitForTransaction polls.
+type WaitOptions = apiclient.WaitOptions
+
+// RetrieveTime returns the API server's clock.
+func (c *Client) RetrieveTime(ctx context.Context) (*TimeV1, error) {
+	return decode[TimeV1](ctx, c, retrieveTimeOp())
+}
+
+// RetrieveTimeRaw is RetrieveTime returning the response body unread.
+func (c *Client) RetrieveTimeRaw(ctx context.Context) (io.ReadCloser, error) {
+	return c.open(ctx, retrieveTimeOp())
+}
+
+func retrieveTimeOp() operation {
+	return operation{method: "GET", path: "/misc/time"}
+}
+
+// ListOnlineUsers returns the users currently online.
+func (c *Client) ListOnlineUsers(ctx context.Context) (*OnlineUsersV1, error) {
+	return decode[OnlineUsersV1](ctx, c, listOnlineUsersOp())
+}
+
+// ListOnlineUsersRaw is ListOnlineUsers returning the response body unread.
+func (c *Client) ListOnlineUsersRaw(ctx context.Context) (io.ReadCloser, error) {
+	return c.open(ctx, listOnlineUsersOp())
+}
+
+func listOnlineUsersOp() operation {
+	return operation{method: "GET", path: "/misc/online"}
+}
+
+// SiteStats returns site-wide statistics.
+func (c *Client) SiteStats(ctx context.Context) (SiteStatsV1, error) {
+	return decodeValue[SiteStatsV1](ctx, c, siteStatsOp())
+}
+
+// SiteStatsRaw is SiteStats returning the response body unread.
+func (c *Client) SiteStatsRaw(ctx context.Context) (io.ReadCloser, error) {
+	return c.open(ctx, siteStatsOp())
+}
+
+func siteStatsOp() operation {
+	return operation{method: "GET", path: "/misc/stats"}
+}
+
+// RetrieveSlowTransactionStatus returns the current status of a slow
+// transaction without waiting for it.
+func (c *Client) RetrieveSlowTransactionStatus(ctx context.Context, id string) (*ApiResponseV1, error) {
+	return decode[ApiResponseV1](ctx, c, retrieveSlowTransactionStatusOp(id))
+}
+
+// RetrieveSlowTransactionStatusRaw is RetrieveSlowTransactionStatus returning
+// the response body unread.
+func (c *Client) RetrieveSlowTransactionStatusRaw(ctx context.Context, id string) (io.ReadCloser, error) {
+	return c.open(ctx, retrieveSlowTransactionStatusOp(id))
+}
+
+func retrieveSlowTransactionStatusOp(id string) operation {
+	return operation{method: "GET", path: "/misc/slow-transaction-status/" + url.PathEscape(id)}
+}
+
+// WaitForTransaction polls a slow transaction until it finishes and returns
+// its final status. See apiclient.Client.WaitForTransaction.
+func (c *Client) WaitForTransaction(ctx context.Context, id string, opts WaitOptions) (*ApiResponseV1, json.RawMessage, error) {
+	raw, err := c.api.WaitForTransaction(ctx, id, opts)
+	if raw == nil {
+		return nil, nil, err
+	}
+	var status ApiResponseV1
+	if jsonErr := json.Unmarshal(raw, &status); jsonErr != nil && err == nil {
+		err = jsonErr
+	}
+	return &status, raw, err
+}
+
+// DefaultTransactionWaitTimeout is the wait timeout used when WaitOptions
+// leaves it zero.
+const DefaultTransactionWaitTimeout time.Duration = apiclient.DefaultTransactionWaitTimeout
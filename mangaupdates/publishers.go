@@ -0,0 +1,67 @@
+package mangaupdates
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// RetrievePublisher returns a publisher.
+func (c *Client) RetrievePublisher(ctx context.Context, id int64, opts *RetrieveOptions) (*PublisherModelV1, error) {
+	return decode[PublisherModelV1](ctx, c, retrievePublisherOp(id, opts))
+}
+
+// RetrievePublisherRaw is RetrievePublisher returning the response body
+// unread.
+func (c *Client) RetrievePublisherRaw(ctx context.Context, id int64, opts *RetrieveOptions) (io.ReadCloser, error) {
+	return c.open(ctx, retrievePublisherOp(id, opts))
+}
+
+func retrievePublisherOp(id int64, opts *RetrieveOptions) operation {
+	return operation{method: "GET", path: fmt.Sprintf("/publishers/%d", id), query: opts.query()}
+}
+
+// SearchPublishers searches publishers.
+func (c *Client) SearchPublishers(ctx context.Context, req *PublishersSearchRequestV1) (*PublishersSearchResponseV1, error) {
+	return decode[PublishersSearchResponseV1](ctx, c, searchPublishersOp(req))
+}
+
+// SearchPublishersRaw is SearchPublishers returning the response body unread.
+func (c *Client) SearchPublishersRaw(ctx context.Context, req *PublishersSearchRequestV1) (io.ReadCloser, error) {
+	return c.open(ctx, searchPublishersOp(req))
+}
+
+func searchPublishersOp(req *PublishersSearchRequestV1) operation {
+	return operation{method: "POST", path: "/publishers/search", body: req}
+}
+
+// RetrievePublisherSeries returns the series of a publisher.
+func (c *Client) RetrievePublisherSeries(ctx context.Context, id int64) (*PublisherSeriesListResponseV1, error) {
+	return decode[PublisherSeriesListResponseV1](ctx, c, retrievePublisherSeriesOp(id))
+}
+
+// RetrievePublisherSeriesRaw is RetrievePublisherSeries returning the
+// response body unread.
+func (c *Client) RetrievePublisherSeriesRaw(ctx context.Context, id int64) (io.ReadCloser, error) {
+	return c.open(ctx, retrievePublisherSeriesOp(id))
+}
+
+func retrievePublisherSeriesOp(id int64) operation {
+	return operation{method: "GET", path: fmt.Sprintf("/publishers/%d/series", id)}
+}
+
+// RetrievePublicationSeries returns the series of a publication (magazine
+// or imprint), by name.
+func (c *Client) RetrievePublicationSeries(ctx context.Context, pubname string) (*PublisherSeriesListResponseV1, error) {
+	return decode[PublisherSeriesListResponseV1](ctx, c, retrievePublicationSeriesOp(pubname))
+}
+
+// RetrievePublicationSeriesRaw is RetrievePublicationSeries returning the
+// response body unread.
+func (c *Client) RetrievePublicationSeriesRaw(ctx context.Context, pubname string) (io.ReadCloser, error) {
+	return c.open(ctx, retrievePublicationSeriesOp(pubname))
+}
+
+func retrievePublicationSeriesOp(pubname string) operation {
+	return operation{method: "GET", path: "/publishers/publication", query: map[string]string{"pubname": pubname}}
+}
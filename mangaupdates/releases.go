@@ -0,0 +1,80 @@
+package mangaupdates
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// ListReleasesByDayOptions are the query parameters of ListReleasesByDay.
+type ListReleasesByDayOptions struct {
+	Page            int64 // 0 means the API default
+	IncludeMetadata *bool // nil means the API default
+}
+
+// RetrieveRelease returns a release.
+func (c *Client) RetrieveRelease(ctx context.Context, id int64, opts *RetrieveOptions) (*ReleaseModelV1, error) {
+	return decode[ReleaseModelV1](ctx, c, retrieveReleaseOp(id, opts))
+}
+
+// RetrieveReleaseRaw is RetrieveRelease returning the response body unread.
+func (c *Client) RetrieveReleaseRaw(ctx context.Context, id int64, opts *RetrieveOptions) (io.ReadCloser, error) {
+	return c.open(ctx, retrieveReleaseOp(id, opts))
+}
+
+func retrieveReleaseOp(id int64, opts *RetrieveOptions) operation {
+	return operation{method: "GET", path: fmt.Sprintf("/releases/%d", id), query: opts.query()}
+}
+
+// ListReleasesByDay returns a page of releases grouped by day, newest first.
+func (c *Client) ListReleasesByDay(ctx context.Context, opts *ListReleasesByDayOptions) (*ReleaseSearchResponseV1, error) {
+	return decode[ReleaseSearchResponseV1](ctx, c, listReleasesByDayOp(opts))
+}
+
+// ListReleasesByDayRaw is ListReleasesByDay returning the response body
+// unread.
+func (c *Client) ListReleasesByDayRaw(ctx context.Context, opts *ListReleasesByDayOptions) (io.ReadCloser, error) {
+	return c.open(ctx, listReleasesByDayOp(opts))
+}
+
+func listReleasesByDayOp(opts *ListReleasesByDayOptions) operation {
+	query := make(map[string]string)
+	if opts != nil {
+		if opts.Page > 0 {
+			query["page"] = strconv.FormatInt(opts.Page, 10)
+		}
+		if opts.IncludeMetadata != nil {
+			query["include_metadata"] = strconv.FormatBool(*opts.IncludeMetadata)
+		}
+	}
+	return operation{method: "GET", path: "/releases/days", query: query}
+}
+
+// ReleaseRSSFeed returns the RSS feed (XML) of the latest releases.
+func (c *Client) ReleaseRSSFeed(ctx context.Context) ([]byte, error) {
+	return readAll(ctx, c, releaseRSSFeedOp())
+}
+
+// ReleaseRSSFeedRaw is ReleaseRSSFeed returning the response body unread.
+func (c *Client) ReleaseRSSFeedRaw(ctx context.Context) (io.ReadCloser, error) {
+	return c.open(ctx, releaseRSSFeedOp())
+}
+
+func releaseRSSFeedOp() operation {
+	return operation{method: "GET", path: "/releases/rss"}
+}
+
+// SearchReleases searches releases.
+func (c *Client) SearchReleases(ctx context.Context, req *ReleaseSearchRequestV1) (*ReleaseSearchResponseV1, error) {
+	return decode[ReleaseSearchResponseV1](ctx, c, searchReleasesOp(req))
+}
+
+// SearchReleasesRaw is SearchReleases returning the response body unread.
+func (c *Client) SearchReleasesRaw(ctx context.Context, req *ReleaseSearchRequestV1) (io.ReadCloser, error) {
+	return c.open(ctx, searchReleasesOp(req))
+}
+
+func searchReleasesOp(req *ReleaseSearchRequestV1) operation {
+	return operation{method: "POST", path: "/releases/search", body: req}
+}
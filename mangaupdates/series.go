@@ -0,0 +1,223 @@
+package mangaupdates
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+)
+
+func seriesPath(id int64, rest string) string {
+	return fmt.Sprintf("/series/%d%s", id, rest)
+}
+
+// RetrieveSeries returns a series.
+func (c *Client) RetrieveSeries(ctx context.Context, id int64, opts *RetrieveOptions) (*SeriesModelV1, error) {
+	return decode[SeriesModelV1](ctx, c, retrieveSeriesOp(id, opts))
+}
+
+// RetrieveSeriesRaw is RetrieveSeries returning the response body unread.
+// The caller must close it.
+func (c *Client) RetrieveSeriesRaw(ctx context.Context, id int64, opts *RetrieveOptions) (io.ReadCloser, error) {
+	return c.open(ctx, retrieveSeriesOp(id, opts))
+}
+
+func retrieveSeriesOp(id int64, opts *RetrieveOptions) operation {
+	return operation{method: "GET", path: seriesPath(id, ""), query: opts.query()}
+}
+
+// SearchSeries searches series.
+func (c *Client) SearchSeries(ctx context.Context, req *SeriesSearchRequestV1) (*SeriesSearchResponseV1, error) {
+	return decode[SeriesSearchResponseV1](ctx, c, searchSeriesOp(req))
+}
+
+// SearchSeriesRaw is SearchSeries returning the response body unread.
+func (c *Client) SearchSeriesRaw(ctx context.Context, req *SeriesSearchRequestV1) (io.ReadCloser, error) {
+	return c.open(ctx, searchSeriesOp(req))
+}
+
+func searchSeriesOp(req *SeriesSearchRequestV1) operation {
+	return operation{method: "POST", path: "/series/search", body: req}
+}
+
+// RetrieveSeriesCategoryVotes returns the category votes of a series.
+func (c *Client) RetrieveSeriesCategoryVotes(ctx context.Context, id int64) ([]SeriesCategoryV1, error) {
+	return decodeValue[[]SeriesCategoryV1](ctx, c, retrieveSeriesCategoryVotesOp(id))
+}
+
+// RetrieveSeriesCategoryVotesRaw is RetrieveSeriesCategoryVotes returning the
+// response body unread.
+func (c *Client) RetrieveSeriesCategoryVotesRaw(ctx context.Context, id int64) (io.ReadCloser, error) {
+	return c.open(ctx, retrieveSeriesCategoryVotesOp(id))
+}
+
+func retrieveSeriesCategoryVotesOp(id int64) operation {
+	return operation{method: "GET", path: seriesPath(id, "/categories/votes")}
+}
+
+// RetrieveSeriesComment returns a comment on a series.
+func (c *Client) RetrieveSeriesComment(ctx context.Context, id, commentID int64, opts *RetrieveOptions) (*SeriesCommentModelV1, error) {
+	return decode[SeriesCommentModelV1](ctx, c, retrieveSeriesCommentOp(id, commentID, opts))
+}
+
+// RetrieveSeriesCommentRaw is RetrieveSeriesComment returning the response
+// body unread.
+func (c *Client) RetrieveSeriesCommentRaw(ctx context.Context, id, commentID int64, opts *RetrieveOptions) (io.ReadCloser, error) {
+	return c.open(ctx, retrieveSeriesCommentOp(id, commentID, opts))
+}
+
+func retrieveSeriesCommentOp(id, commentID int64, opts *RetrieveOptions) operation {
+	return operation{method: "GET", path: seriesPath(id, fmt.Sprintf("/comments/%d", commentID)), query: opts.query()}
+}
+
+// RetrieveMySeriesComment returns the logged-in user's comment on a series.
+func (c *Client) RetrieveMySeriesComment(ctx context.Context, id int64, opts *RetrieveOptions) (*SeriesCommentModelV1, error) {
+	return decode[SeriesCommentModelV1](ctx, c, retrieveMySeriesCommentOp(id, opts))
+}
+
+// RetrieveMySeriesCommentRaw is RetrieveMySeriesComment returning the
+// response body unread.
+func (c *Client) RetrieveMySeriesCommentRaw(ctx context.Context, id int64, opts *RetrieveOptions) (io.ReadCloser, error) {
+	return c.open(ctx, retrieveMySeriesCommentOp(id, opts))
+}
+
+func retrieveMySeriesCommentOp(id int64, opts *RetrieveOptions) operation {
+	return operation{method: "GET", path: seriesPath(id, "/comments/my_comment"), query: opts.query(), auth: true}
+}
+
+// RetrieveSeriesCommentLocation returns where a comment appears in the
+// series' comment list.
+func (c *Client) RetrieveSeriesCommentLocation(ctx context.Context, id, commentID int64) (*SeriesCommentLocationV1, error) {
+	return decode[SeriesCommentLocationV1](ctx, c, retrieveSeriesCommentLocationOp(id, commentID))
+}
+
+// RetrieveSeriesCommentLocationRaw is RetrieveSeriesCommentLocation returning
+// the response body unread.
+func (c *Client) RetrieveSeriesCommentLocationRaw(ctx context.Context, id, commentID int64) (io.ReadCloser, error) {
+	return c.open(ctx, retrieveSeriesCommentLocationOp(id, commentID))
+}
+
+func retrieveSeriesCommentLocationOp(id, commentID int64) operation {
+	return operation{method: "GET", path: seriesPath(id, fmt.Sprintf("/comments/%d/location", commentID))}
+}
+
+// SearchSeriesComments searches the comments on a series.
+func (c *Client) SearchSeriesComments(ctx context.Context, id int64, req *SeriesCommentSearchRequestV1) (*SeriesCommentSearchResponseV1, error) {
+	return decode[SeriesCommentSearchResponseV1](ctx, c, searchSeriesCommentsOp(id, req))
+}
+
+// SearchSeriesCommentsRaw is SearchSeriesComments returning the response body
+// unread.
+func (c *Client) SearchSeriesCommentsRaw(ctx context.Context, id int64, req *SeriesCommentSearchRequestV1) (io.ReadCloser, error) {
+	return c.open(ctx, searchSeriesCommentsOp(id, req))
+}
+
+func searchSeriesCommentsOp(id int64, req *SeriesCommentSearchRequestV1) operation {
+	return operation{method: "POST", path: seriesPath(id, "/comments/search"), body: req}
+}
+
+// RetrieveSeriesGroups returns the groups releasing a series.
+func (c *Client) RetrieveSeriesGroups(ctx context.Context, id int64) (*SeriesGroupsResponseV1, error) {
+	return decode[SeriesGroupsResponseV1](ctx, c, retrieveSeriesGroupsOp(id))
+}
+
+// RetrieveSeriesGroupsRaw is RetrieveSeriesGroups returning the response body
+// unread.
+func (c *Client) RetrieveSeriesGroupsRaw(ctx context.Context, id int64) (io.ReadCloser, error) {
+	return c.open(ctx, retrieveSeriesGroupsOp(id))
+}
+
+func retrieveSeriesGroupsOp(id int64) operation {
+	return operation{method: "GET", path: seriesPath(id, "/groups")}
+}
+
+// SearchSeriesHistory returns a page of the edit history of a series.
+func (c *Client) SearchSeriesHistory(ctx context.Context, id int64, req *PerPageSearchRequestV1) (*SeriesHistoryResponseV1, error) {
+	return decode[SeriesHistoryResponseV1](ctx, c, searchSeriesHistoryOp(id, req))
+}
+
+// SearchSeriesHistoryRaw is SearchSeriesHistory returning the response body
+// unread.
+func (c *Client) SearchSeriesHistoryRaw(ctx context.Context, id int64, req *PerPageSearchRequestV1) (io.ReadCloser, error) {
+	return c.open(ctx, searchSeriesHistoryOp(id, req))
+}
+
+func searchSeriesHistoryOp(id int64, req *PerPageSearchRequestV1) operation {
+	return operation{method: "POST", path: seriesPath(id, "/history"), body: req}
+}
+
+// RetrieveSeriesLocks returns the locked fields of a series.
+func (c *Client) RetrieveSeriesLocks(ctx context.Context, id int64) (LocksV1, error) {
+	return decodeValue[LocksV1](ctx, c, retrieveSeriesLocksOp(id))
+}
+
+// RetrieveSeriesLocksRaw is RetrieveSeriesLocks returning the response body
+// unread.
+func (c *Client) RetrieveSeriesLocksRaw(ctx context.Context, id int64) (io.ReadCloser, error) {
+	return c.open(ctx, retrieveSeriesLocksOp(id))
+}
+
+func retrieveSeriesLocksOp(id int64) operation {
+	return operation{method: "GET", path: seriesPath(id, "/locks")}
+}
+
+// RetrieveSeriesRankLocation returns where a series appears in the ranking
+// of statType.
+func (c *Client) RetrieveSeriesRankLocation(ctx context.Context, id int64, statType string) (*SeriesRankLocationV1, error) {
+	return decode[SeriesRankLocationV1](ctx, c, retrieveSeriesRankLocationOp(id, statType))
+}
+
+// RetrieveSeriesRankLocationRaw is RetrieveSeriesRankLocation returning the
+// response body unread.
+func (c *Client) RetrieveSeriesRankLocationRaw(ctx context.Context, id int64, statType string) (io.ReadCloser, error) {
+	return c.open(ctx, retrieveSeriesRankLocationOp(id, statType))
+}
+
+func retrieveSeriesRankLocationOp(id int64, statType string) operation {
+	return operation{method: "GET", path: seriesPath(id, "/rank/"+url.PathEscape(statType))}
+}
+
+// RetrieveUserSeriesRating returns the logged-in user's rating of a series.
+func (c *Client) RetrieveUserSeriesRating(ctx context.Context, id int64) (*SeriesRatingModelV1, error) {
+	return decode[SeriesRatingModelV1](ctx, c, retrieveUserSeriesRatingOp(id))
+}
+
+// RetrieveUserSeriesRatingRaw is RetrieveUserSeriesRating returning the
+// response body unread.
+func (c *Client) RetrieveUserSeriesRatingRaw(ctx context.Context, id int64) (io.ReadCloser, error) {
+	return c.open(ctx, retrieveUserSeriesRatingOp(id))
+}
+
+func retrieveUserSeriesRatingOp(id int64) operation {
+	return operation{method: "GET", path: seriesPath(id, "/rating"), auth: true}
+}
+
+// RetrieveSeriesRatingRainbow returns the rating distribution of a series.
+func (c *Client) RetrieveSeriesRatingRainbow(ctx context.Context, id int64) (SeriesRatingRainbowV1, error) {
+	return decodeValue[SeriesRatingRainbowV1](ctx, c, retrieveSeriesRatingRainbowOp(id))
+}
+
+// RetrieveSeriesRatingRainbowRaw is RetrieveSeriesRatingRainbow returning the
+// response body unread.
+func (c *Client) RetrieveSeriesRatingRainbowRaw(ctx context.Context, id int64) (io.ReadCloser, error) {
+	return c.open(ctx, retrieveSeriesRatingRainbowOp(id))
+}
+
+func retrieveSeriesRatingRainbowOp(id int64) operation {
+	return operation{method: "GET", path: seriesPath(id, "/ratingrainbow")}
+}
+
+// SeriesReleaseRSSFeed returns the RSS feed (XML) of a series' releases.
+func (c *Client) SeriesReleaseRSSFeed(ctx context.Context, id int64) ([]byte, error) {
+	return readAll(ctx, c, seriesReleaseRSSFeedOp(id))
+}
+
+// SeriesReleaseRSSFeedRaw is SeriesReleaseRSSFeed returning the response body
+// unread.
+func (c *Client) SeriesReleaseRSSFeedRaw(ctx context.Context, id int64) (io.ReadCloser, error) {
+	return c.open(ctx, seriesReleaseRSSFeedOp(id))
+}
+
+func seriesReleaseRSSFeedOp(id int64) operation {
+	return operation{method: "GET", path: seriesPath(id, "/rss")}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/TheDucker1/mangaupdatescli/cmd/doctor"
+	"github.com/TheDucker1/mangaupdatescli/internal/apiclient"
+	"github.com/TheDucker1/mangaupdatescli/internal/config"
+	"time"
+)
+
+// globalFlagSet reports whether the named global flag was given on the
+// command line, as opposed to holding its default.
+func globalFlagSet(name string) bool {
+	set := false
+	globalFlags.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+// resolveNetworkSettings combines the network flags with the profile's
+// settings. A flag given on the command line always wins over the profile.
+func resolveNetworkSettings(p *config.Profile) (doctor.Settings, error) {
+	s := doctor.Settings{
+		Transport: apiclient.TransportConfig{
+			Proxy:              p.Proxy,
+			CAFile:             p.CAFile,
+			InsecureSkipVerify: p.InsecureSkipVerify || globalOpts.insecureSkipVerify,
+		},
+		ProxySource:   "profile proxy",
+		CAFileSource:  "profile ca_file",
+		Timeout:       globalOpts.timeout,
+		TimeoutSource: "default",
+	}
+	if globalOpts.proxy != "" {
+		s.Transport.Proxy, s.ProxySource = globalOpts.proxy, "--proxy"
+	}
+	if globalOpts.caFile != "" {
+		s.Transport.CAFile, s.CAFileSource = globalOpts.caFile, "--ca-file"
+	}
+
+	switch {
+	case globalFlagSet("timeout"):
+		s.TimeoutSource = "--timeout"
+	case p.Timeout != "":
+		timeout, err := time.ParseDuration(p.Timeout)
+		if err != nil {
+			return s, fmt.Errorf("invalid timeout %q in profile: %w", p.Timeout, err)
+		}
+		s.Timeout, s.TimeoutSource = timeout, "profile timeout"
+	}
+	if s.Timeout < 0 {
+		return s, fmt.Errorf("--timeout must be >= 0")
+	}
+	return s, nil
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"github.com/TheDucker1/mangaupdatescli/internal/apiclient"
+	"github.com/TheDucker1/mangaupdatescli/internal/config"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// withGlobalFlags runs fn with the global flags parsed from args.
+func withGlobalFlags(t *testing.T, args []string, fn func()) {
+	t.Helper()
+	savedOpts, savedFlags := globalOpts, globalFlags
+	defer func() { globalOpts, globalFlags = savedOpts, savedFlags }()
+	globalOpts = globalOptions{maxResponseSize: apiclient.DefaultMaxResponseSize}
+	globalFlags = newGlobalFlagSet(&globalOpts)
+	if err := globalFlags.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	fn()
+}
+
+func TestResolveNetworkSettings(t *testing.T) {
+	// The environment is read once per process, on the first request that
+	// needs a proxy, so it must be set before any transport is used.
+	t.Setenv("HTTPS_PROXY", "http://env-proxy:3128")
+	t.Setenv("NO_PROXY", "")
+	req, _ := http.NewRequest(http.MethodGet, "https://api.mangaupdates.com/v1/genres", nil)
+
+	tests := []struct {
+		name        string
+		args        []string
+		profile     config.Profile
+		wantProxy   string // the proxy the transport uses
+		wantSource  string
+		wantTimeout time.Duration
+		wantTSource string
+		wantErr     string
+	}{
+		{
+			name:      "environment",
+			wantProxy: "http://env-proxy:3128", wantSource: "profile proxy",
+			wantTimeout: apiclient.DefaultTimeout, wantTSource: "default",
+		},
+		{
+			name:      "profile over the environment",
+			profile:   config.Profile{Proxy: "socks5://profile-proxy:1080", Timeout: "5s"},
+			wantProxy: "socks5://profile-proxy:1080", wantSource: "profile proxy",
+			wantTimeout: 5 * time.Second, wantTSource: "profile timeout",
+		},
+		{
+			name:      "flags over the profile",
+			args:      []string{"--proxy", "http://flag-proxy:8080", "--timeout", "2s"},
+			profile:   config.Profile{Proxy: "socks5://profile-proxy:1080", Timeout: "5s"},
+			wantProxy: "http://flag-proxy:8080", wantSource: "--proxy",
+			wantTimeout: 2 * time.Second, wantTSource: "--timeout",
+		},
+		{
+			// A timeout flag set to its default still wins.
+			name:      "default timeout flag",
+			args:      []string{"--timeout", apiclient.DefaultTimeout.String()},
+			profile:   config.Profile{Timeout: "5s"},
+			wantProxy: "http://env-proxy:3128", wantSource: "profile proxy",
+			wantTimeout: apiclient.DefaultTimeout, wantTSource: "--timeout",
+		},
+		{name: "invalid profile timeout", profile: config.Profile{Timeout: "soon"}, wantErr: `invalid timeout "soon" in profile`},
+		{name: "negative profile timeout", profile: config.Profile{Timeout: "-1s"}, wantErr: "--timeout must be >= 0"},
+		{name: "negative timeout flag", args: []string{"--timeout", "-1s"}, wantErr: "--timeout must be >= 0"},
+	}
+	for _, tt := range tests {
+		withGlobalFlags(t, tt.args, func() {
+			s, err := resolveNetworkSettings(&tt.profile)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("%s: error = %v; want one containing %q", tt.name, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%s: %v", tt.name, err)
+			}
+			if s.ProxySource != tt.wantSource || s.Timeout != tt.wantTimeout || s.TimeoutSource != tt.wantTSource {
+				t.Errorf("%s: proxy source, timeout, timeout source = %q, %s, %q; want %q, %s, %q",
+					tt.name, s.ProxySource, s.Timeout, s.TimeoutSource, tt.wantSource, tt.wantTimeout, tt.wantTSource)
+			}
+			transport, err := apiclient.NewTransport(s.Transport)
+			if err != nil {
+				t.Fatalf("%s: %v", tt.name, err)
+			}
+			if proxy, err := transport.Proxy(req); err != nil || proxy == nil || proxy.String() != tt.wantProxy {
+				t.Errorf("%s: proxy = %v, %v; want %s", tt.name, proxy, err, tt.wantProxy)
+			}
+		})
+	}
+}
+
+func TestResolveNetworkSettingsCAFile(t *testing.T) {
+	tests := []struct {
+		args       []string
+		profile    config.Profile
+		wantCAFile string
+		wantSource string
+		wantSkip   bool
+	}{
+		{profile: config.Profile{CAFile: "profile.pem"}, wantCAFile: "profile.pem", wantSource: "profile ca_file"},
+		{args: []string{"--ca-file", "flag.pem"}, profile: config.Profile{CAFile: "profile.pem"}, wantCAFile: "flag.pem", wantSource: "--ca-file"},
+		// Either the profile or the flag turns verification off.
+		{profile: config.Profile{InsecureSkipVerify: true}, wantSource: "profile ca_file", wantSkip: true},
+		{args: []string{"--insecure-skip-verify"}, wantSource: "profile ca_file", wantSkip: true},
+	}
+	for _, tt := range tests {
+		withGlobalFlags(t, tt.args, func() {
+			s, err := resolveNetworkSettings(&tt.profile)
+			if err != nil || s.Transport.CAFile != tt.wantCAFile || s.CAFileSource != tt.wantSource || s.Transport.InsecureSkipVerify != tt.wantSkip {
+				t.Errorf("resolveNetworkSettings(%q, %+v) = %+v, %v; want CA file %q from %q, skip verify %v",
+					tt.args, tt.profile, s, err, tt.wantCAFile, tt.wantSource, tt.wantSkip)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"github.com/TheDucker1/mangaupdatescli/internal/apiclient"
+	"github.com/TheDucker1/mangaupdatescli/internal/config"
+	"os"
+)
+
+// sessionToken returns the session token of profile p to send to the API
+// at apiURL ("" for the production API). A token is only sent to the API
+// root that issued it, so a staging server, mock or proxy given with
+// --api-url never receives the production session; for any other root it
+// warns and returns "".
+func sessionToken(p *config.Profile, profileName, apiURL string) string {
+	if p.SessionToken == "" {
+		return ""
+	}
+	issuer, err := apiclient.NormalizeBaseURL(orDefaultBaseURL(p.SessionAPIURL))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: not sending the session of profile %q: %v\n", profileName, err)
+		return ""
+	}
+	target, err := apiclient.NormalizeBaseURL(orDefaultBaseURL(apiURL))
+	if err != nil {
+		// The client reports the invalid URL.
+		return ""
+	}
+	if target != issuer {
+		fmt.Fprintf(os.Stderr, "Warning: not sending the session of profile %q to %s; it was created for %s. Log in against %s to use a session there.\n",
+			profileName, target, issuer, target)
+		return ""
+	}
+	return p.SessionToken
+}
+
+// orDefaultBaseURL returns apiURL, or the production API root if it is "".
+func orDefaultBaseURL(apiURL string) string {
+	if apiURL == "" {
+		return apiclient.DefaultBaseURL
+	}
+	return apiURL
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"github.com/TheDucker1/mangaupdatescli/internal/config"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestSessionToken(t *testing.T) {
+	tests := []struct {
+		name       string
+		sessionURL string // API root the session was created against
+		apiURL     string // effective --api-url; "" is the production API
+		want       string
+		wantWarn   bool
+	}{
+		{name: "production session, production API", want: "SECRET"},
+		{name: "stored before the URL was recorded", apiURL: "https://api.mangaupdates.com/v1", want: "SECRET"},
+		{name: "production session, local server", apiURL: "http://127.0.0.1:18777/v1", wantWarn: true},
+		{name: "production session, other path", apiURL: "https://api.mangaupdates.com/v2/", wantWarn: true},
+		{name: "staging session, staging API", sessionURL: "https://staging.example.com/v1/", apiURL: "https://staging.example.com/v1", want: "SECRET"},
+		{name: "staging session, production API", sessionURL: "https://staging.example.com/v1/", wantWarn: true},
+		{name: "invalid API URL", apiURL: "ftp://example.com"},
+	}
+	for _, tt := range tests {
+		p := &config.Profile{SessionToken: "SECRET", SessionAPIURL: tt.sessionURL}
+		var got string
+		stderr := captureStderr(t, func() { got = sessionToken(p, "default", tt.apiURL) })
+		if got != tt.want {
+			t.Errorf("%s: sessionToken = %q; want %q", tt.name, got, tt.want)
+		}
+		if warned := strings.Contains(stderr, "not sending the session"); warned != tt.wantWarn {
+			t.Errorf("%s: stderr = %q; want a warning %v", tt.name, stderr, tt.wantWarn)
+		}
+		if strings.Contains(stderr, "SECRET") {
+			t.Errorf("%s: the warning shows the token: %q", tt.name, stderr)
+		}
+	}
+
+	if got := sessionToken(&config.Profile{}, "default", "http://127.0.0.1:1/"); got != "" {
+		t.Errorf("sessionToken of a profile without a session = %q", got)
+	}
+}
+
+// captureStderr returns what fn writes to os.Stderr.
+func captureStderr(t *testing.T, fn func()) string {
+	t.Helper()
+	f, err := os.CreateTemp(t.TempDir(), "stderr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	saved := os.Stderr
+	os.Stderr = f
+	defer func() { os.Stderr = saved }()
+	fn()
+	f.Seek(0, io.SeekStart)
+	out, _ := io.ReadAll(f)
+	return string(out)
+}
@@ -0,0 +1,259 @@
+// tools/helpcodegen/bodyargs.go
+package main
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+)
+
+// maxBodyDepth bounds how deep nested objects of a request body are turned
+// into dotted flags, so that recursive schemas terminate.
+const maxBodyDepth = 3
+
+// commandFlags are flag names that body fields must not take: --body
+// itself and the ID flags of hand-written and bulk commands
+// (utils.BulkIDs). A field named like one has no flag of its own.
+var commandFlags = map[string]bool{
+	"body": true, "id": true, "ids-from": true, "parallel": true,
+}
+
+// globalFlags are the global flags of globals.go. The CLI takes them from
+// anywhere on the command line before a command sees its arguments, so no
+// parameter or body field may be named like one; see globalFlagCollisions.
+var globalFlags = map[string]bool{
+	"profile": true, "api-url": true, "retries": true, "rate-limit": true,
+	"no-cache": true, "refresh": true, "record": true, "replay": true,
+	"trace": true, "har": true, "validate": true, "strict": true,
+	"proxy": true, "ca-file": true, "insecure-skip-verify": true,
+	"timeout": true, "max-response-size": true, "retry-max-wait": true,
+	"no-follow-transactions": true, "help-depth": true,
+}
+
+// globalFlagCollisions lists the parameters and body fields of the
+// operations in spec whose flags would be named like a global flag. The
+// global flag would swallow them, so generation stops instead.
+func globalFlagCollisions(spec *OpenAPISpec) []string {
+	g := &modelGen{schemas: spec.Components.Schemas, decls: make(map[string]string)}
+	var collisions []string
+	for _, item := range spec.Paths {
+		for _, op := range item {
+			if op.OperationID == "" {
+				continue
+			}
+			for _, param := range op.Parameters {
+				if globalFlags[param.Name] || globalFlags[strings.ReplaceAll(param.Name, "_", "-")] {
+					collisions = append(collisions, fmt.Sprintf("%s: %s parameter %q", op.OperationID, param.In, param.Name))
+				}
+			}
+			if op.RequestBody == nil {
+				continue
+			}
+			if media, ok := op.RequestBody.Content["application/json"]; ok {
+				for _, arg := range bodyArguments(g, &media.Schema) {
+					if globalFlags[arg.Name] {
+						collisions = append(collisions, fmt.Sprintf("%s: body field %q", op.OperationID, arg.Name))
+					}
+				}
+			}
+		}
+	}
+	sort.Strings(collisions)
+	return collisions
+}
+
+// withoutFlagCollisions drops the body arguments of opID whose names are
+// taken by a parameter or another flag of the command, logging each one;
+// those fields can still be set with --body. dropRequired reports whether
+// a required field was dropped.
+func withoutFlagCollisions(opID string, fields []ArgHelpGo, params []Parameter) (kept []ArgHelpGo, dropRequired bool) {
+	taken := make(map[string]bool, len(params))
+	for _, param := range params {
+		taken[param.Name] = true
+		taken[strings.ReplaceAll(param.Name, "_", "-")] = true
+	}
+	for _, arg := range fields {
+		if taken[arg.Name] || commandFlags[arg.Name] {
+			log.Printf("%s: body field %q has no flag of its own, --%s is already taken; it can be set with --body", opID, arg.Name, arg.Name)
+			dropRequired = dropRequired || arg.Required
+			continue
+		}
+		kept = append(kept, arg)
+	}
+	return kept, dropRequired
+}
+
+// bodyArguments lists the fields of a JSON request body as arguments, in
+// spec order. Scalars and arrays of scalars become arguments of their own;
+// the fields of nested objects are named with dots (e.g. "filter.type").
+// Fields that cannot be given on the command line, such as arrays of
+// objects or free-form maps, are left out. A nested field is only required
+// if its parents are.
+func bodyArguments(g *modelGen, body *Schema) []ArgHelpGo {
+	var args []ArgHelpGo
+	var walk func(prefix string, s *Schema, required bool, depth int)
+	walk = func(prefix string, s *Schema, required bool, depth int) {
+		names, props, requiredProps := g.fields(s)
+		for _, name := range names {
+			prop := props[name]
+			isRequired := required && requiredProps[name]
+			if g.isStruct(prop) {
+				if depth < maxBodyDepth {
+					walk(prefix+name+".", prop, isRequired, depth+1)
+				}
+				continue
+			}
+			argType, enum, ok := bodyArgType(g, prop)
+			if !ok {
+				continue
+			}
+			description := prop.Description
+			if description == "" {
+				description = g.resolve(prop).Description
+			}
+			args = append(args, ArgHelpGo{
+				Name:        prefix + name,
+				Type:        argType,
+				Required:    isRequired,
+				Description: strings.Join(strings.Fields(description), " "),
+				In:          "body",
+				Enum:        enum,
+			})
+		}
+	}
+	if g.isStruct(body) {
+		walk("", body, true, 0)
+	}
+	return args
+}
+
+// bodyArgType returns the argument type of a body field, in the notation of
+// the parameters ("integer(int64)", or "[]string" for arrays), and its enum.
+// ok is false for fields that are not scalars or arrays of scalars.
+func bodyArgType(g *modelGen, s *Schema) (argType string, enum []string, ok bool) {
+	s = g.resolve(s)
+	if len(s.OneOf) > 0 || len(s.AnyOf) > 0 {
+		return "", nil, false
+	}
+	switch s.Type.Name {
+	case "string", "integer", "number", "boolean":
+		argType = s.Type.Name
+		if s.Format != "" {
+			argType += "(" + s.Format + ")"
+		}
+		for _, v := range s.Enum {
+			enum = append(enum, fmt.Sprint(v))
+		}
+		return argType, enum, true
+	case "array":
+		if s.Items == nil {
+			return "", nil, false
+		}
+		itemType, itemEnum, ok := bodyArgType(g, s.Items)
+		if !ok || strings.HasPrefix(itemType, "[]") {
+			return "", nil, false
+		}
+		return "[]" + itemType, itemEnum, true
+	}
+	return "", nil, false
+}
+
+// bodyFieldDescriptions describe the body fields that the spec leaves
+// undocumented, by operation ID and field name. The "" operation holds the
+// fields shared by the search operations. They are the help texts of the
+// flags that the commands declared by hand before their flags were
+// generated.
+var bodyFieldDescriptions = map[string]map[string]string{
+	"": {
+		"page":    "Page number.",
+		"perpage": "Results per page.",
+		"letter":  "Filter by starting letter.",
+	},
+	"login": {
+		"username": "Account username.",
+		"password": "Account password.",
+	},
+	"searchSeriesPost": {
+		"search":                  "Search term.",
+		"added_by":                "Filter by user ID who added the series.",
+		"stype":                   "Search type (title, description).",
+		"licensed":                "Filter by licensed status (yes, no).",
+		"type":                    "Series types (Manga, Manhwa, ...).",
+		"year":                    "Filter by year.",
+		"filter_types":            "Series types to filter out.",
+		"category":                "Categories.",
+		"pubname":                 "Filter by publication name.",
+		"filters":                 "Filters (scanlated, completed, ...).",
+		"list":                    "Filter by user list type.",
+		"genre":                   "Genres.",
+		"exclude_genre":           "Genres to exclude.",
+		"orderby":                 "Order by (score, title, rank, etc.).",
+		"pending":                 "Include pending series.",
+		"include_rank_metadata":   "Include rank metadata.",
+		"exclude_filtered_genres": "Exclude filtered genres.",
+	},
+	"searchSeriesCommentsPost": {
+		"method":   "Search method (useful, time_added).",
+		"added_by": "Filter by author user ID.",
+	},
+	"searchReleasesPost": {
+		"search":           "Search term.",
+		"search_type":      "Search type (series, regular).",
+		"added_by":         "Filter by user ID who added the release.",
+		"orderby":          "Order by (default: date).",
+		"start_date":       "Start date (YYYY-MM-DD).",
+		"end_date":         "End date (YYYY-MM-DD).",
+		"asc":              "Sort direction (asc, desc; default: desc).",
+		"group_id":         "Filter by group ID.",
+		"pending":          "Include pending releases.",
+		"include_metadata": "Include series metadata.",
+	},
+	"searchCategoriesPost": {
+		"search":  "Search term for categories.",
+		"orderby": "Order by (category, agree, disagree, usage).",
+	},
+	"findCategoryByExact": {
+		"category": "Category name.",
+	},
+	"findCategoryByPrefix": {
+		"category": "Start of the category names.",
+	},
+	"searchPublishersPost": {
+		"search":   "Search term for publishers.",
+		"added_by": "Filter by user ID who added the publisher.",
+		"orderby":  "Order by (score, name, series, etc.).",
+		"pending":  "Filter by pending status.",
+	},
+	"searchAuthorsPost": {
+		"search":   "Search term.",
+		"added_by": "Filter by user ID who added the author.",
+		"genre":    "Genres.",
+		"orderby":  "Order by (name, series, score).",
+		"pending":  "Include pending authors.",
+	},
+	"retrieveAuthorSeries": {
+		"orderby": "Order by (title, year).",
+	},
+	"searchGroupsPost": {
+		"search":   "Search term for groups.",
+		"added_by": "Filter by user ID who added the group.",
+		"active":   "Filter by active status.",
+		"pending":  "Filter by pending status.",
+	},
+}
+
+// describeBodyFields fills in the descriptions of the body arguments of
+// opID that the spec leaves empty from bodyFieldDescriptions.
+func describeBodyFields(opID string, args []ArgHelpGo) {
+	for i, arg := range args {
+		if arg.Description != "" {
+			continue
+		}
+		if d, ok := bodyFieldDescriptions[opID][arg.Name]; ok {
+			args[i].Description = d
+		} else {
+			args[i].Description = bodyFieldDescriptions[""][arg.Name]
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"slices"
+	"sort"
+	"strconv"
+	"testing"
+)
+
+func TestGlobalFlagCollisions(t *testing.T) {
+	spec := parseTestSpec(t, `
+paths:
+  /series/{id}:
+    get:
+      operationId: retrieveSeries
+      parameters:
+        - {name: id, in: path, schema: {type: integer}}
+        - {name: timeout, in: query, schema: {type: integer}}
+        - {name: api_url, in: query, schema: {type: string}}
+  /series/search:
+    post:
+      operationId: searchSeriesPost
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                search: {type: string}
+                profile: {type: string}
+                filter:
+                  type: object
+                  properties:
+                    strict: {type: boolean}
+  /lists:
+    post:
+      operationId: addList
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                id: {type: integer}
+                title: {type: string}
+`)
+	want := []string{
+		`retrieveSeries: query parameter "api_url"`,
+		`retrieveSeries: query parameter "timeout"`,
+		`searchSeriesPost: body field "profile"`,
+	}
+	if got := globalFlagCollisions(spec); !slices.Equal(got, want) {
+		t.Errorf("globalFlagCollisions = %q; want %q", got, want)
+	}
+}
+
+func TestWithoutFlagCollisions(t *testing.T) {
+	fields := []ArgHelpGo{
+		{Name: "id", Required: true},
+		{Name: "series_id"},
+		{Name: "ids-from"},
+		{Name: "search"},
+	}
+	params := []Parameter{{Name: "series_id", In: "path"}}
+	kept, dropRequired := withoutFlagCollisions("op", fields, params)
+	var names []string
+	for _, arg := range kept {
+		names = append(names, arg.Name)
+	}
+	if !slices.Equal(names, []string{"search"}) || !dropRequired {
+		t.Errorf("withoutFlagCollisions kept %q, dropRequired %v; want [search], true", names, dropRequired)
+	}
+}
+
+func TestDescribeBodyFields(t *testing.T) {
+	args := []ArgHelpGo{
+		{Name: "search", Description: "From the spec."},
+		{Name: "orderby"},
+		{Name: "page"},
+		{Name: "undocumented"},
+	}
+	describeBodyFields("searchSeriesPost", args)
+	var got []string
+	for _, arg := range args {
+		got = append(got, arg.Description)
+	}
+	// The spec wins, then the operation's texts, then the shared ones.
+	want := []string{"From the spec.", "Order by (score, title, rank, etc.).", "Page number.", ""}
+	if !slices.Equal(got, want) {
+		t.Errorf("describeBodyFields = %q; want %q", got, want)
+	}
+}
+
+// TestGlobalFlagsMatchGlobalsGo checks that globalFlags lists exactly the
+// flags that newGlobalFlagSet in globals.go declares.
+func TestGlobalFlagsMatchGlobalsGo(t *testing.T) {
+	f, err := parser.ParseFile(token.NewFileSet(), "../../globals.go", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var declared []string
+	ast.Inspect(f, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) < 2 {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		if x, ok := sel.X.(*ast.Ident); !ok || x.Name != "fs" {
+			return true
+		}
+		if lit, ok := call.Args[1].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+			name, _ := strconv.Unquote(lit.Value)
+			declared = append(declared, name)
+		}
+		return true
+	})
+	sort.Strings(declared)
+	listed := sortedKeys(globalFlags)
+	if !slices.Equal(declared, listed) {
+		t.Errorf("globals.go declares %q; globalFlags lists %q", declared, listed)
+	}
+}
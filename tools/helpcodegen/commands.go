@@ -0,0 +1,361 @@
+// tools/helpcodegen/commands.go
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// authRequired reports whether op needs a session. An operation's own
+// security list replaces the global one, and an empty requirement in it
+// means the session is optional.
+func (s *OpenAPISpec) authRequired(op OperationDetail) bool {
+	security := op.Security
+	if security == nil {
+		security = s.Security
+	}
+	for _, requirement := range security {
+		if len(requirement) == 0 {
+			return false
+		}
+	}
+	return len(security) > 0
+}
+
+// --- Structs for the command templates ---
+
+type CommandsGenData struct {
+	PackageName string
+	Description string
+	Operations  []OperationGo
+}
+
+type OperationGo struct {
+	ID           string
+	VarName      string
+	HelpVarName  string
+	Method       string
+	Path         string
+	Params       []Parameter
+	Body         bool
+	BodyRequired bool
+	Auth         bool
+}
+
+type SubprogramGo struct {
+	Name       string
+	Package    string
+	HelpFunc   string
+	ImportPath string
+}
+
+// Operations of a subprogram that are not hand-written, run by utils.RunOperation.
+const commandsTemplate = `// Code generated by tools/helpcodegen; DO NOT EDIT.
+
+package {{.PackageName}}
+
+import (
+	"context"
+	"github.com/TheDucker1/mangaupdatescli/internal/utils"
+)
+{{range .Operations}}
+// {{.VarName}} is {{.Method}} {{.Path}}.
+var {{.VarName}} = utils.Operation{
+	ID:     {{printf "%q" .ID}},
+	Method: {{printf "%q" .Method}},
+	Path:   {{printf "%q" .Path}},
+	{{- if .Params}}
+	Params: []utils.OperationParam{
+		{{- range .Params}}
+		{
+			Name:     {{printf "%q" .Name}},
+			In:       {{printf "%q" .In}},
+			Type:     {{printf "%q" .Schema.Type}},
+			Required: {{.Required}},
+			{{- if .Schema.Enum}}
+			Enum:     {{printf "%#v" .Schema.Enum}},
+			{{- end}}
+			{{- if .Description}}
+			Description: {{printf "%q" .Description}},
+			{{- end}}
+		},
+		{{- end}}
+	},
+	{{- end}}
+	{{- if .Body}}
+	Body:         true,
+	BodyRequired: {{.BodyRequired}},
+	{{- end}}
+	Auth: {{.Auth}},
+}
+{{end}}
+func init() {
+	{{- range .Operations}}
+	{{$.PackageName}}Commands[{{printf "%q" .ID}}] = CommandInfo{
+		Handler: func(ctx context.Context, args []string) {
+			utils.RunOperation(ctx, {{.VarName}}, {{.HelpVarName}}, args)
+		},
+		Help: {{.HelpVarName}},
+	}
+	{{- end}}
+}
+`
+
+// The package of a tag that has no hand-written one, shaped like the
+// hand-written packages so main can dispatch to it the same way.
+const subprogramTemplate = `// Code generated by tools/helpcodegen; DO NOT EDIT.
+
+package {{.PackageName}}
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/TheDucker1/mangaupdatescli/internal/utils"
+	"os"
+	"sort"
+)
+
+// CommandHandler defines the function signature for command handlers
+type CommandHandler func(ctx context.Context, args []string)
+
+// CommandInfo stores the handler and its associated (generated) help content
+type CommandInfo struct {
+	Handler CommandHandler
+	Help    utils.HelpContent
+}
+
+// {{.PackageName}}Commands maps the CLI command name to its handler and help.
+// It is populated by {{.PackageName}}_generated_commands.go.
+var {{.PackageName}}Commands = make(map[string]CommandInfo)
+
+// HandleCommand dispatches to the correct {{.PackageName}} command handler
+func HandleCommand(ctx context.Context, command string, args []string) {
+	cmdInfo, ok := {{.PackageName}}Commands[command]
+	if !ok {
+		isJsonHelp, _, _ := utils.CheckHelpFlags(args)
+		fmt.Fprintf(os.Stderr, "Error: Unknown {{.PackageName}} command: %s\n\n", command)
+		Print{{title .PackageName}}SubprogramHelp(isJsonHelp)
+		os.Exit(1)
+	}
+	cmdInfo.Handler(ctx, args)
+}
+
+// Print{{title .PackageName}}SubprogramHelp prints help for the entire '{{.PackageName}}' subprogram
+func Print{{title .PackageName}}SubprogramHelp(jsonFormat bool) {
+	var commandNames []string
+	for name := range {{.PackageName}}Commands {
+		commandNames = append(commandNames, name)
+	}
+	sort.Strings(commandNames)
+
+	if jsonFormat {
+		type CommandHelpSummary struct {
+			Command     string ` + "`json:\"command\"`" + `
+			Usage       string ` + "`json:\"usage\"`" + `
+			Description string ` + "`json:\"description\"`" + `
+		}
+		var summaries []CommandHelpSummary
+		for _, name := range commandNames {
+			cmdInfo := {{.PackageName}}Commands[name]
+			summaries = append(summaries, CommandHelpSummary{
+				Command:     name,
+				Usage:       cmdInfo.Help.Usage,
+				Description: cmdInfo.Help.Description,
+			})
+		}
+		outputData := map[string]interface{}{
+			"subprogram":  "{{.PackageName}}",
+			"description": {{printf "%q" .Description}},
+			"commands":    summaries,
+		}
+		jsonData, _ := json.MarshalIndent(outputData, "", "  ")
+		fmt.Println(string(jsonData))
+	} else {
+		fmt.Println({{printf "%q" (printf "` + "`%s`" + ` subprogram: %s" .PackageName .Description)}})
+		fmt.Println("Available commands:")
+		for _, name := range commandNames {
+			cmdInfo := {{.PackageName}}Commands[name]
+			fmt.Printf("  %-30s %s\n", name, cmdInfo.Help.Description)
+		}
+		fmt.Println("\nUse 'mangaupdatescli {{.PackageName}} <command> -hh' for more detailed help on a specific command.")
+	}
+}
+`
+
+// The subprograms main dispatches to that exist only as generated packages.
+const subprogramsTemplate = `// Code generated by tools/helpcodegen; DO NOT EDIT.
+
+package main
+{{if .}}
+import (
+	{{- range .}}
+	{{printf "%q" .ImportPath}}
+	{{- end}}
+)
+
+func init() {
+	{{- range .}}
+	generatedSubprograms[{{printf "%q" .Name}}] = generatedSubprogram{
+		handle:    {{.Package}}.HandleCommand,
+		printHelp: {{.Package}}.{{.HelpFunc}},
+	}
+	{{- end}}
+}
+{{end}}`
+
+var (
+	helpVarRefRe    = regexp.MustCompile(`\bhelp[A-Z][A-Za-z0-9]*Content\b`)
+	registeredCmdRe = regexp.MustCompile(`\b[a-z][A-Za-z0-9]*Commands\[\s*"([^"]+)"\s*\]\s*=`)
+)
+
+// handWrittenOps reads the hand-written files of a subprogram package. It
+// reports whether there are any, and what they implement: the command
+// names they register, and the help variables they use. A handler
+// registered under a name other than its operation ID (misc's "online")
+// still prints the help of that operation, while one with help of its own
+// (account's loginHelpContent) is found by the name it registers.
+func handWrittenOps(dir string) (found bool, commands, helpVars map[string]bool) {
+	commands = make(map[string]bool)
+	helpVars = make(map[string]bool)
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return false, commands, helpVars
+	}
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), ".go") || strings.Contains(f.Name(), "_generated_") || strings.HasSuffix(f.Name(), "_test.go") {
+			continue
+		}
+		src, err := os.ReadFile(filepath.Join(dir, f.Name()))
+		if err != nil {
+			log.Fatalf("Error reading %s: %v", filepath.Join(dir, f.Name()), err)
+		}
+		found = true
+		for _, m := range registeredCmdRe.FindAllStringSubmatch(string(src), -1) {
+			commands[m[1]] = true
+		}
+		for _, name := range helpVarRefRe.FindAllString(string(src), -1) {
+			helpVars[name] = true
+		}
+	}
+	return found, commands, helpVars
+}
+
+// writeGoSource formats src and writes it to path.
+func writeGoSource(path string, src []byte) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		log.Fatalf("Error creating directory for %s: %v", path, err)
+	}
+	formatted, err := format.Source(src)
+	if err != nil {
+		log.Printf("Error formatting Go source for %s (writing unformatted to %s_unformatted.go_error): %v", path, path, err)
+		os.WriteFile(path+"_unformatted.go_error", src, 0644)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(path, formatted, 0644); err != nil {
+		log.Fatalf("Error writing Go file %s: %v", path, err)
+	}
+}
+
+// generateCommands writes <tag>_generated_commands.go for every tag, with a
+// handler for each operation that has no hand-written one. Tags without a
+// hand-written package also get <tag>_generated_subprogram.go, and are
+// registered with main in subprogramsOut.
+func generateCommands(spec *OpenAPISpec, subprogramOps map[string]map[string]SpecOperation, outdirRoot, subprogramsOut string) {
+	funcs := template.FuncMap{"title": func(s string) string { return toCamelCase(s, true) }}
+	commandsTmpl := template.Must(template.New("commands").Parse(commandsTemplate))
+	subprogramTmpl := template.Must(template.New("subprogram").Funcs(funcs).Parse(subprogramTemplate))
+	subprogramsTmpl := template.Must(template.New("subprograms").Parse(subprogramsTemplate))
+
+	tagDescriptions := make(map[string]string)
+	for _, tag := range spec.Tags {
+		tagDescriptions[tag.Name] = tag.Description
+	}
+
+	var spNames []string
+	for spName := range subprogramOps {
+		spNames = append(spNames, spName)
+	}
+	sort.Strings(spNames)
+
+	var generated []SubprogramGo
+	for _, spName := range spNames {
+		dir := filepath.Join(outdirRoot, spName)
+		hasPackage, handWrittenCmds, handWrittenHelp := handWrittenOps(dir)
+
+		var opIDs []string
+		for opID := range subprogramOps[spName] {
+			opIDs = append(opIDs, opID)
+		}
+		sort.Strings(opIDs)
+
+		data := CommandsGenData{PackageName: spName, Description: tagDescriptions[spName]}
+		if data.Description == "" {
+			data.Description = fmt.Sprintf("Commands for the %s endpoints of the API.", spName)
+		}
+		for _, opID := range opIDs {
+			opData := subprogramOps[spName][opID]
+			op := OperationGo{
+				ID:          opID,
+				VarName:     "op" + toCamelCase(opID, true),
+				HelpVarName: "help" + toCamelCase(opID, true) + "Content",
+				Method:      strings.ToUpper(opData.Method),
+				Path:        opData.Path,
+				Auth:        spec.authRequired(opData.Details),
+			}
+			if handWrittenCmds[opID] || handWrittenHelp[op.HelpVarName] {
+				continue
+			}
+			if rb := opData.Details.RequestBody; rb != nil && len(rb.Content) > 0 {
+				if _, ok := rb.Content["application/json"]; !ok {
+					log.Printf("Skipping %s: only JSON request bodies are supported", opID)
+					continue
+				}
+				op.Body, op.BodyRequired = true, rb.Required
+			}
+			for _, param := range opData.Details.Parameters {
+				if param.In == "path" || param.In == "query" {
+					op.Params = append(op.Params, param)
+				}
+			}
+			data.Operations = append(data.Operations, op)
+		}
+
+		if !hasPackage {
+			var buf bytes.Buffer
+			if err := subprogramTmpl.Execute(&buf, data); err != nil {
+				log.Fatalf("Error executing subprogram template for %s: %v", spName, err)
+			}
+			writeGoSource(filepath.Join(dir, spName+"_generated_subprogram.go"), buf.Bytes())
+			generated = append(generated, SubprogramGo{
+				Name:       spName,
+				Package:    spName,
+				HelpFunc:   "Print" + toCamelCase(spName, true) + "SubprogramHelp",
+				ImportPath: "github.com/TheDucker1/mangaupdatescli/" + filepath.ToSlash(filepath.Join(filepath.Base(outdirRoot), spName)),
+			})
+		}
+		if len(data.Operations) > 0 {
+			var buf bytes.Buffer
+			if err := commandsTmpl.Execute(&buf, data); err != nil {
+				log.Fatalf("Error executing commands template for %s: %v", spName, err)
+			}
+			writeGoSource(filepath.Join(dir, spName+"_generated_commands.go"), buf.Bytes())
+		}
+		fmt.Printf("Generated %d command handlers for %s\n", len(data.Operations), spName)
+	}
+
+	var buf bytes.Buffer
+	if err := subprogramsTmpl.Execute(&buf, generated); err != nil {
+		log.Fatalf("Error executing subprograms template: %v", err)
+	}
+	writeGoSource(subprogramsOut, buf.Bytes())
+	fmt.Printf("Registered %d generated subprograms in %s\n", len(generated), subprogramsOut)
+}
@@ -0,0 +1,318 @@
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+const commandsTestSpec = `
+paths:
+  /account/login:
+    put:
+      operationId: login
+      tags: [account]
+  /account/logout:
+    post:
+      operationId: logout
+      tags: [account]
+  /account/profile:
+    get:
+      operationId: retrieveAccountProfile
+      tags: [account]
+  /misc/online:
+    get:
+      operationId: listOnlineUsers
+      tags: [misc]
+  /misc/time:
+    get:
+      operationId: time
+      tags: [misc]
+  /lists:
+    get:
+      operationId: retrieveLists
+      tags: [lists]
+`
+
+// The hand-written packages of the test: account registers its commands
+// under their operation IDs with help of its own, misc uses generated help
+// under other command names.
+var commandsTestPackages = map[string]string{
+	"account/account.go": `package account
+
+func init() {
+	accountCommands["login"] = CommandInfo{Handler: handleLogin, Help: loginHelpContent}
+	accountCommands["logout"] = CommandInfo{Handler: handleLogout, Help: logoutHelpContent}
+}
+`,
+	"misc/misc.go": `package misc
+
+func init() {
+	miscCommands["online"] = CommandInfo{
+		Handler: handleListOnlineUsers,
+		Help:    helpListOnlineUsersContent,
+	}
+}
+`,
+}
+
+func parseTestSpec(t *testing.T, src string) *OpenAPISpec {
+	t.Helper()
+	var spec OpenAPISpec
+	if err := yaml.Unmarshal([]byte(src), &spec); err != nil {
+		t.Fatal(err)
+	}
+	return &spec
+}
+
+// subprogramOps groups the operations of spec by their first tag, as main
+// does for generateCommands.
+func subprogramOps(spec *OpenAPISpec) map[string]map[string]SpecOperation {
+	ops := make(map[string]map[string]SpecOperation)
+	for path, item := range spec.Paths {
+		for method, op := range item {
+			if ops[op.Tags[0]] == nil {
+				ops[op.Tags[0]] = make(map[string]SpecOperation)
+			}
+			ops[op.Tags[0]][op.OperationID] = SpecOperation{Details: op, Path: path, Method: method}
+		}
+	}
+	return ops
+}
+
+func TestGenerateCommandsSkipsHandWritten(t *testing.T) {
+	spec := parseTestSpec(t, commandsTestSpec)
+	root := t.TempDir()
+	outdir := filepath.Join(root, "cmd")
+	for name, src := range commandsTestPackages {
+		path := filepath.Join(outdir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	generateCommands(spec, subprogramOps(spec), outdir, filepath.Join(root, "subprograms_generated.go"))
+
+	tests := []struct {
+		file    string
+		want    []string
+		notWant []string
+	}{
+		{
+			file:    "account/account_generated_commands.go",
+			want:    []string{`accountCommands["retrieveAccountProfile"]`},
+			notWant: []string{`accountCommands["login"]`, `accountCommands["logout"]`},
+		},
+		{
+			file:    "misc/misc_generated_commands.go",
+			want:    []string{`miscCommands["time"]`},
+			notWant: []string{`miscCommands["listOnlineUsers"]`},
+		},
+		{
+			file: "lists/lists_generated_commands.go",
+			want: []string{`listsCommands["retrieveLists"]`},
+		},
+	}
+	for _, tt := range tests {
+		data, err := os.ReadFile(filepath.Join(outdir, tt.file))
+		if err != nil {
+			t.Errorf("%s: %v", tt.file, err)
+			continue
+		}
+		for _, s := range tt.want {
+			if !strings.Contains(string(data), s) {
+				t.Errorf("%s does not register %s", tt.file, s)
+			}
+		}
+		for _, s := range tt.notWant {
+			if strings.Contains(string(data), s) {
+				t.Errorf("%s replaces the hand-written %s", tt.file, s)
+			}
+		}
+	}
+}
+
+func TestHandWrittenOps(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"account.go":                    commandsTestPackages["account/account.go"],
+		"account_generated_help.go":     `var x = accountCommands["ignored"]`,
+		"account_generated_commands.go": `func init() { accountCommands["generated"] = CommandInfo{Help: helpGeneratedContent} }`,
+	}
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	found, commands, helpVars := handWrittenOps(dir)
+	if !found {
+		t.Fatal("found = false; want true")
+	}
+	if !commands["login"] || !commands["logout"] || len(commands) != 2 {
+		t.Errorf("commands = %v; want login and logout", commands)
+	}
+	if len(helpVars) != 0 {
+		t.Errorf("helpVars = %v; want none", helpVars)
+	}
+
+	if found, _, _ := handWrittenOps(filepath.Join(dir, "missing")); found {
+		t.Error("found a package in a missing directory")
+	}
+}
+
+func TestGenerateCommandsOperations(t *testing.T) {
+	spec := parseTestSpec(t, `
+security:
+  - bearerAuth: []
+paths:
+  /lists/{id}:
+    get:
+      operationId: retrieveList
+      tags: [lists]
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: integer}}
+        - {name: order, in: query, schema: {type: string, enum: [asc, desc]}, description: Sort order.}
+        - {name: X-Trace, in: header, schema: {type: string}}
+  /lists/search:
+    post:
+      operationId: searchLists
+      tags: [lists]
+      security: [{}]
+      requestBody:
+        required: true
+        content:
+          application/json: {schema: {type: object}}
+  /lists/{id}/image:
+    put:
+      operationId: updateListImage
+      tags: [lists]
+      requestBody:
+        content:
+          multipart/form-data: {schema: {type: object}}
+`)
+	root := t.TempDir()
+	outdir := filepath.Join(root, "cmd")
+	subprograms := filepath.Join(root, "subprograms_generated.go")
+	generateCommands(spec, subprogramOps(spec), outdir, subprograms)
+
+	tests := []struct {
+		file    string
+		want    []string
+		notWant []string
+	}{
+		{
+			file: filepath.Join(outdir, "lists", "lists_generated_commands.go"),
+			want: []string{
+				`var opRetrieveList = utils.Operation{ ID: "retrieveList", Method: "GET", Path: "/lists/{id}", ` +
+					`Params: []utils.OperationParam{ { Name: "id", In: "path", Type: "integer", Required: true, }, ` +
+					`{ Name: "order", In: "query", Type: "string", Required: false, Enum: []string{"asc", "desc"}, Description: "Sort order.", }, }, ` +
+					`Auth: true, }`,
+				`var opSearchLists = utils.Operation{ ID: "searchLists", Method: "POST", Path: "/lists/search", ` +
+					`Body: true, BodyRequired: true, Auth: false, }`,
+				`listsCommands["retrieveList"] = CommandInfo{ Handler: func(ctx context.Context, args []string) { ` +
+					`utils.RunOperation(ctx, opRetrieveList, helpRetrieveListContent, args) }, Help: helpRetrieveListContent, }`,
+			},
+			// Header parameters and non-JSON bodies are not supported.
+			notWant: []string{"X-Trace", "updateListImage"},
+		},
+		{
+			file: filepath.Join(outdir, "lists", "lists_generated_subprogram.go"),
+			want: []string{"package lists", "var listsCommands = make(map[string]CommandInfo)", "func PrintListsSubprogramHelp(jsonFormat bool)"},
+		},
+		{
+			file: subprograms,
+			want: []string{
+				`"github.com/TheDucker1/mangaupdatescli/cmd/lists"`,
+				`generatedSubprograms["lists"] = generatedSubprogram{ handle: lists.HandleCommand, printHelp: lists.PrintListsSubprogramHelp, }`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		data, err := os.ReadFile(tt.file)
+		if err != nil {
+			t.Errorf("%s: %v", tt.file, err)
+			continue
+		}
+		// Compare without gofmt's alignment.
+		src := strings.Join(strings.Fields(string(data)), " ")
+		for _, s := range tt.want {
+			if !strings.Contains(src, s) {
+				t.Errorf("%s does not contain\n%s\n\n%s", filepath.Base(tt.file), s, data)
+			}
+		}
+		for _, s := range tt.notWant {
+			if strings.Contains(src, s) {
+				t.Errorf("%s contains %s", filepath.Base(tt.file), s)
+			}
+		}
+	}
+}
+
+// TestGenerateCommandsNewSubprogram checks that a tag without a package in
+// cmd/ gets a generated subprogram that main registers, and a tag with one
+// does not.
+func TestGenerateCommandsNewSubprogram(t *testing.T) {
+	spec := parseTestSpec(t, `
+paths:
+  /members/{id}:
+    get:
+      operationId: retrieveMember
+      tags: [members]
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: integer}}
+  /account/profile:
+    get:
+      operationId: retrieveAccountProfile
+      tags: [account]
+`)
+	root := t.TempDir()
+	outdir := filepath.Join(root, "cmd")
+	if err := os.MkdirAll(filepath.Join(outdir, "account"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(outdir, "account", "account.go"), []byte(commandsTestPackages["account/account.go"]), 0644); err != nil {
+		t.Fatal(err)
+	}
+	subprograms := filepath.Join(root, "subprograms_generated.go")
+	generateCommands(spec, subprogramOps(spec), outdir, subprograms)
+
+	tests := []struct {
+		file   string
+		exists bool
+	}{
+		{filepath.Join(outdir, "members", "members_generated_subprogram.go"), true},
+		{filepath.Join(outdir, "members", "members_generated_commands.go"), true},
+		{filepath.Join(outdir, "account", "account_generated_subprogram.go"), false},
+		{filepath.Join(outdir, "account", "account_generated_commands.go"), true},
+		{subprograms, true},
+	}
+	for _, tt := range tests {
+		_, err := os.Stat(tt.file)
+		if exists := err == nil; exists != tt.exists {
+			t.Errorf("%s exists = %v; want %v", tt.file, exists, tt.exists)
+			continue
+		}
+		if !tt.exists {
+			continue
+		}
+		if _, err := parser.ParseFile(token.NewFileSet(), tt.file, nil, 0); err != nil {
+			t.Errorf("%s is not valid Go: %v", tt.file, err)
+		}
+	}
+
+	data, err := os.ReadFile(subprograms)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `generatedSubprograms["members"]`) || strings.Contains(string(data), `generatedSubprograms["account"]`) {
+		t.Errorf("subprograms_generated.go registers the wrong subprograms:\n%s", data)
+	}
+}
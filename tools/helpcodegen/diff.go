@@ -0,0 +1,697 @@
+// tools/helpcodegen/diff.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// diffEntry is an operation or component schema that differs between two
+// versions of the spec.
+type diffEntry struct {
+	Kind    string // "+" added, "-" removed, "~" changed
+	Name    string
+	Summary string   // e.g. "GET /series/{id} (series)" for operations
+	Details []string // what changed, for "~"
+}
+
+// specDiff compares an old and a new version of the spec.
+type specDiff struct {
+	old, new       *OpenAPISpec
+	oldG, newG     *modelGen
+	oldOps, newOps map[string]SpecOperation
+
+	Operations []diffEntry
+	Schemas    []diffEntry
+	opChanges  map[string][]string // details of the changed operations
+	changed    map[string]bool     // names of the changed component schemas
+}
+
+// indexOperations returns the operations of spec that have an operationId.
+func indexOperations(spec *OpenAPISpec) map[string]SpecOperation {
+	ops := make(map[string]SpecOperation)
+	for pathStr, pathItem := range spec.Paths {
+		for methodStr, opDetail := range pathItem {
+			if opDetail.OperationID != "" {
+				ops[opDetail.OperationID] = SpecOperation{Details: opDetail, Path: pathStr, Method: methodStr}
+			}
+		}
+	}
+	return ops
+}
+
+func (op SpecOperation) endpoint() string {
+	return strings.ToUpper(op.Method) + " " + op.Path
+}
+
+// tag is the subprogram op belongs to.
+func (op SpecOperation) tag() string {
+	if len(op.Details.Tags) == 0 {
+		return ""
+	}
+	return op.Details.Tags[0]
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func requiredWord(required bool) string {
+	if required {
+		return "required"
+	}
+	return "optional"
+}
+
+// at prefixes msg with the field path it is about, if any.
+func at(path, msg string) string {
+	if path == "" {
+		return msg
+	}
+	return "field " + path + ": " + msg
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// unwrapSchema follows a single-element allOf used to attach nullable or a
+// description to a schema, but not references.
+func unwrapSchema(s *Schema) *Schema {
+	for s.Ref == "" && len(s.AllOf) == 1 && s.Type.Name == "" && s.Properties.Names == nil {
+		s = s.AllOf[0]
+	}
+	return s
+}
+
+// specTypeName describes the type of s in one word, such as
+// "SeriesModelV1", "[]string" or "integer(int64)".
+func specTypeName(s *Schema) string {
+	if s == nil {
+		return "any"
+	}
+	s = unwrapSchema(s)
+	switch {
+	case s.Ref != "":
+		return refName(s.Ref)
+	case len(s.OneOf) > 0 || len(s.AnyOf) > 0:
+		var names []string
+		for _, alt := range slices.Concat(s.OneOf, s.AnyOf) {
+			names = append(names, specTypeName(alt))
+		}
+		return strings.Join(names, " | ")
+	case s.Type.Name == "array":
+		return "[]" + specTypeName(s.Items)
+	case s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil && s.Properties.Names == nil:
+		return "map[string]" + specTypeName(s.AdditionalProperties.Schema)
+	case s.Type.Name != "":
+		if s.Format != "" {
+			return s.Type.Name + "(" + s.Format + ")"
+		}
+		return s.Type.Name
+	case s.Properties.Names != nil || len(s.AllOf) > 0:
+		return "object"
+	}
+	return "any"
+}
+
+func enumStrings(values []interface{}) []string {
+	enum := make([]string, len(values))
+	for i, v := range values {
+		enum[i] = fmt.Sprint(v)
+	}
+	return enum
+}
+
+// diffEnum lists the values added to and removed from an enum.
+func diffEnum(path string, a, b []string) []string {
+	var out []string
+	for _, v := range a {
+		if !slices.Contains(b, v) {
+			out = append(out, at(path, fmt.Sprintf("enum value %q removed", v)))
+		}
+	}
+	for _, v := range b {
+		if !slices.Contains(a, v) {
+			out = append(out, at(path, fmt.Sprintf("enum value %q added", v)))
+		}
+	}
+	return out
+}
+
+// diffSchema compares the old schema a with the new schema b, down through
+// inline objects and arrays. References are compared by name only, since
+// the component schemas are compared on their own.
+func (d *specDiff) diffSchema(path string, a, b *Schema) []string {
+	if ta, tb := specTypeName(a), specTypeName(b); ta != tb {
+		return []string{at(path, fmt.Sprintf("type %s -> %s", ta, tb))}
+	}
+	var out []string
+	if na, nb := a.isNullable() || unwrapSchema(a).isNullable(), b.isNullable() || unwrapSchema(b).isNullable(); na != nb {
+		out = append(out, at(path, fmt.Sprintf("nullable %t -> %t", na, nb)))
+	}
+	a, b = unwrapSchema(a), unwrapSchema(b)
+	if a.Ref != "" {
+		return out
+	}
+	out = append(out, diffEnum(path, enumStrings(a.Enum), enumStrings(b.Enum))...)
+	if a.Items != nil && b.Items != nil {
+		out = append(out, d.diffSchema(path+"[]", a.Items, b.Items)...)
+	}
+	if a.AdditionalProperties != nil && a.AdditionalProperties.Schema != nil && b.AdditionalProperties != nil && b.AdditionalProperties.Schema != nil {
+		out = append(out, d.diffSchema(path+"{}", a.AdditionalProperties.Schema, b.AdditionalProperties.Schema)...)
+	}
+	if d.oldG.isStruct(a) && d.newG.isStruct(b) {
+		out = append(out, d.diffFields(path, a, b)...)
+	}
+	return out
+}
+
+// diffFields compares the properties of two object schemas.
+func (d *specDiff) diffFields(path string, a, b *Schema) []string {
+	var out []string
+	aNames, aProps, aRequired := d.oldG.fields(a)
+	bNames, bProps, bRequired := d.newG.fields(b)
+	for _, name := range aNames {
+		if _, ok := bProps[name]; !ok {
+			out = append(out, fmt.Sprintf("field %s removed", joinPath(path, name)))
+		}
+	}
+	for _, name := range bNames {
+		fieldPath := joinPath(path, name)
+		aProp, ok := aProps[name]
+		if !ok {
+			out = append(out, fmt.Sprintf("field %s added (%s, %s)", fieldPath, specTypeName(bProps[name]), requiredWord(bRequired[name])))
+			continue
+		}
+		if aRequired[name] != bRequired[name] {
+			out = append(out, at(fieldPath, requiredWord(aRequired[name])+" -> "+requiredWord(bRequired[name])))
+		}
+		out = append(out, d.diffSchema(fieldPath, aProp, bProps[name])...)
+	}
+	return out
+}
+
+func paramType(p Parameter) string {
+	if p.Schema.Format != "" {
+		return p.Schema.Type + "(" + p.Schema.Format + ")"
+	}
+	return p.Schema.Type
+}
+
+// diffParams compares the parameters of two versions of an operation.
+func diffParams(a, b []Parameter) []string {
+	var out []string
+	find := func(params []Parameter, name string) (Parameter, bool) {
+		for _, p := range params {
+			if p.Name == name {
+				return p, true
+			}
+		}
+		return Parameter{}, false
+	}
+	for _, p := range a {
+		if _, ok := find(b, p.Name); !ok {
+			out = append(out, fmt.Sprintf("parameter --%s removed", p.Name))
+		}
+	}
+	for _, q := range b {
+		p, ok := find(a, q.Name)
+		if !ok {
+			out = append(out, fmt.Sprintf("parameter --%s added (%s %s, %s)", q.Name, q.In, paramType(q), requiredWord(q.Required)))
+			continue
+		}
+		if p.In != q.In {
+			out = append(out, fmt.Sprintf("parameter --%s: in %s -> %s", q.Name, p.In, q.In))
+		}
+		if paramType(p) != paramType(q) {
+			out = append(out, fmt.Sprintf("parameter --%s: type %s -> %s", q.Name, paramType(p), paramType(q)))
+		}
+		if p.Required != q.Required {
+			out = append(out, fmt.Sprintf("parameter --%s: %s -> %s", q.Name, requiredWord(p.Required), requiredWord(q.Required)))
+		}
+		for _, change := range diffEnum("", p.Schema.Enum, q.Schema.Enum) {
+			out = append(out, fmt.Sprintf("parameter --%s: %s", q.Name, change))
+		}
+	}
+	return out
+}
+
+// diffContent compares the JSON schemas of a request or response body,
+// or else its content types.
+func (d *specDiff) diffContent(what string, a, b map[string]MediaType) []string {
+	aJSON, aOK := a["application/json"]
+	bJSON, bOK := b["application/json"]
+	if aOK && bOK {
+		var out []string
+		for _, change := range d.diffSchema("", &aJSON.Schema, &bJSON.Schema) {
+			out = append(out, what+": "+change)
+		}
+		return out
+	}
+	if aTypes, bTypes := strings.Join(sortedKeys(a), ", "), strings.Join(sortedKeys(b), ", "); aTypes != bTypes {
+		return []string{fmt.Sprintf("%s content: %s -> %s", what, aTypes, bTypes)}
+	}
+	return nil
+}
+
+// successResponse returns the first success response of op, as the help
+// generator picks it.
+func successResponse(op OperationDetail) (string, Response) {
+	for _, code := range []string{"200", "201", "202", "204"} {
+		if resp, ok := op.Responses[code]; ok {
+			return code, resp
+		}
+	}
+	return "", Response{}
+}
+
+// diffOperation compares two versions of an operation.
+func (d *specDiff) diffOperation(a, b SpecOperation) []string {
+	var out []string
+	if a.endpoint() != b.endpoint() {
+		out = append(out, fmt.Sprintf("endpoint: %s -> %s", a.endpoint(), b.endpoint()))
+	}
+	if a.tag() != b.tag() {
+		out = append(out, fmt.Sprintf("tag: %s -> %s", a.tag(), b.tag()))
+	}
+	if aa, ba := d.old.authRequired(a.Details), d.new.authRequired(b.Details); aa != ba {
+		out = append(out, fmt.Sprintf("authentication: %s -> %s", requiredWord(aa), requiredWord(ba)))
+	}
+	out = append(out, diffParams(a.Details.Parameters, b.Details.Parameters)...)
+
+	switch ab, bb := a.Details.RequestBody, b.Details.RequestBody; {
+	case ab == nil && bb != nil:
+		out = append(out, fmt.Sprintf("request body added (%s)", requiredWord(bb.Required)))
+	case ab != nil && bb == nil:
+		out = append(out, "request body removed")
+	case ab != nil:
+		if ab.Required != bb.Required {
+			out = append(out, fmt.Sprintf("request body: %s -> %s", requiredWord(ab.Required), requiredWord(bb.Required)))
+		}
+		out = append(out, d.diffContent("request body", ab.Content, bb.Content)...)
+	}
+
+	aCode, aResp := successResponse(a.Details)
+	bCode, bResp := successResponse(b.Details)
+	if aCode != bCode {
+		out = append(out, fmt.Sprintf("success response: %s -> %s", aCode, bCode))
+	}
+	return append(out, d.diffContent("response", aResp.Content, bResp.Content)...)
+}
+
+// compare fills in the operations and schemas that differ.
+func (d *specDiff) compare() {
+	d.oldOps, d.newOps = indexOperations(d.old), indexOperations(d.new)
+	d.opChanges = make(map[string][]string)
+	d.changed = make(map[string]bool)
+
+	for _, id := range sortedKeys(d.oldOps) {
+		if _, ok := d.newOps[id]; !ok {
+			op := d.oldOps[id]
+			d.Operations = append(d.Operations, diffEntry{Kind: "-", Name: id, Summary: fmt.Sprintf("%s (%s)", op.endpoint(), op.tag())})
+		}
+	}
+	for _, id := range sortedKeys(d.newOps) {
+		op := d.newOps[id]
+		entry := diffEntry{Kind: "+", Name: id, Summary: fmt.Sprintf("%s (%s)", op.endpoint(), op.tag())}
+		if oldOp, ok := d.oldOps[id]; ok {
+			entry.Kind, entry.Details = "~", d.diffOperation(oldOp, op)
+			if len(entry.Details) == 0 {
+				continue
+			}
+			d.opChanges[id] = entry.Details
+		}
+		d.Operations = append(d.Operations, entry)
+	}
+
+	for _, name := range sortedKeys(d.old.Components.Schemas) {
+		if _, ok := d.new.Components.Schemas[name]; !ok {
+			d.Schemas = append(d.Schemas, diffEntry{Kind: "-", Name: name})
+		}
+	}
+	for _, name := range sortedKeys(d.new.Components.Schemas) {
+		s := d.new.Components.Schemas[name]
+		entry := diffEntry{Kind: "+", Name: name, Summary: specTypeName(s)}
+		if old, ok := d.old.Components.Schemas[name]; ok {
+			entry.Kind, entry.Summary, entry.Details = "~", "", d.diffSchema("", old, s)
+			if len(entry.Details) == 0 {
+				continue
+			}
+			d.changed[name] = true
+		}
+		d.Schemas = append(d.Schemas, entry)
+	}
+}
+
+// schemaRefs adds the component schemas s refers to, directly or through
+// other component schemas, to refs.
+func schemaRefs(g *modelGen, s *Schema, refs map[string]bool) {
+	if s == nil {
+		return
+	}
+	if s.Ref != "" {
+		name := refName(s.Ref)
+		if !refs[name] {
+			refs[name] = true
+			schemaRefs(g, g.schemas[name], refs)
+		}
+		return
+	}
+	for _, part := range slices.Concat(s.AllOf, s.OneOf, s.AnyOf) {
+		schemaRefs(g, part, refs)
+	}
+	for _, prop := range s.Properties.Schemas {
+		schemaRefs(g, prop, refs)
+	}
+	schemaRefs(g, s.Items, refs)
+	if s.AdditionalProperties != nil {
+		schemaRefs(g, s.AdditionalProperties.Schema, refs)
+	}
+}
+
+// --- Hand-written commands ---
+
+// registeredCommand is a command that a hand-written subprogram package
+// registers in its <tag>Commands map.
+type registeredCommand struct {
+	Subprogram string
+	Name       string
+	Pos        token.Position
+	OpID       string          // the operation whose help it shows
+	Flags      map[string]bool // flags its handler declares
+	BodyFlags  bool            // the handler declares body fields with utils.AddBodyFlags
+}
+
+var helpVarNameRe = regexp.MustCompile(`^help[A-Z][A-Za-z0-9]*Content$`)
+
+// flagFuncs maps the flag.FlagSet methods that declare a flag to the
+// position of the flag name among their arguments.
+var flagFuncs = map[string]int{
+	"String": 0, "Int": 0, "Int64": 0, "Uint": 0, "Uint64": 0, "Bool": 0, "Float64": 0, "Duration": 0, "Func": 0, "BoolFunc": 0,
+	"StringVar": 1, "IntVar": 1, "Int64Var": 1, "UintVar": 1, "Uint64Var": 1, "BoolVar": 1, "Float64Var": 1, "DurationVar": 1, "Var": 1, "TextVar": 1,
+}
+
+// findHelpVar returns the first helpXxxContent variable that n refers to,
+// looking into the package's functions that n calls.
+func findHelpVar(n ast.Node, funcs map[string]*ast.FuncDecl, seen map[string]bool) string {
+	found := ""
+	ast.Inspect(n, func(n ast.Node) bool {
+		if found != "" {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.Ident:
+			if helpVarNameRe.MatchString(n.Name) {
+				found = n.Name
+			}
+		case *ast.CallExpr:
+			if fn, ok := n.Fun.(*ast.Ident); ok && funcs[fn.Name] != nil && !seen[fn.Name] {
+				seen[fn.Name] = true
+				found = findHelpVar(funcs[fn.Name], funcs, seen)
+			}
+		}
+		return found == ""
+	})
+	return found
+}
+
+// collectFlags records the flags that n declares in c, looking into the
+// package's functions that n calls.
+func collectFlags(c *registeredCommand, n ast.Node, funcs map[string]*ast.FuncDecl, seen map[string]bool) {
+	ast.Inspect(n, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		switch fn := call.Fun.(type) {
+		case *ast.Ident:
+			if funcs[fn.Name] != nil && !seen[fn.Name] {
+				seen[fn.Name] = true
+				collectFlags(c, funcs[fn.Name], funcs, seen)
+			}
+		case *ast.SelectorExpr:
+			switch fn.Sel.Name {
+			case "AddBulkIDFlags":
+				c.Flags["id"], c.Flags["ids-from"], c.Flags["parallel"] = true, true, true
+			case "AddBodyFlags":
+				c.BodyFlags = true
+			default:
+				i, ok := flagFuncs[fn.Sel.Name]
+				if !ok || i >= len(call.Args) {
+					break
+				}
+				if lit, ok := call.Args[i].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+					if name, err := strconv.Unquote(lit.Value); err == nil {
+						c.Flags[name] = true
+					}
+				}
+			}
+		}
+		return true
+	})
+}
+
+// scanCommands parses the hand-written files of the subprogram packages
+// under outdirRoot and returns the commands they register. helpOps maps the
+// help variable of every operation to its operationId.
+func scanCommands(outdirRoot string, helpOps map[string]string) []registeredCommand {
+	dirs, err := os.ReadDir(outdirRoot)
+	if err != nil {
+		log.Fatalf("Error reading %s: %v", outdirRoot, err)
+	}
+	var commands []registeredCommand
+	fset := token.NewFileSet()
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+		pkgDir := filepath.Join(outdirRoot, dir.Name())
+		files, _ := os.ReadDir(pkgDir)
+		var parsed []*ast.File
+		funcs := make(map[string]*ast.FuncDecl)
+		for _, f := range files {
+			if f.IsDir() || !strings.HasSuffix(f.Name(), ".go") || strings.Contains(f.Name(), "_generated_") || strings.HasSuffix(f.Name(), "_test.go") {
+				continue
+			}
+			file, err := parser.ParseFile(fset, filepath.Join(pkgDir, f.Name()), nil, 0)
+			if err != nil {
+				log.Fatalf("Error parsing %s: %v", filepath.Join(pkgDir, f.Name()), err)
+			}
+			parsed = append(parsed, file)
+			for _, decl := range file.Decls {
+				if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil {
+					funcs[fn.Name.Name] = fn
+				}
+			}
+		}
+
+		for _, file := range parsed {
+			ast.Inspect(file, func(n ast.Node) bool {
+				assign, ok := n.(*ast.AssignStmt)
+				if !ok || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+					return true
+				}
+				index, ok := assign.Lhs[0].(*ast.IndexExpr)
+				if !ok {
+					return true
+				}
+				mapName, ok := index.X.(*ast.Ident)
+				name, isLit := index.Index.(*ast.BasicLit)
+				info, isInfo := assign.Rhs[0].(*ast.CompositeLit)
+				if !ok || !isLit || !isInfo || !strings.HasSuffix(mapName.Name, "Commands") {
+					return true
+				}
+				cmdName, err := strconv.Unquote(name.Value)
+				if err != nil {
+					return true
+				}
+				c := registeredCommand{
+					Subprogram: strings.TrimSuffix(mapName.Name, "Commands"),
+					Name:       cmdName,
+					Pos:        fset.Position(assign.Pos()),
+					Flags:      make(map[string]bool),
+				}
+				var handler *ast.FuncDecl
+				helpVar := ""
+				for _, elt := range info.Elts {
+					kv, ok := elt.(*ast.KeyValueExpr)
+					if !ok {
+						continue
+					}
+					switch key, _ := kv.Key.(*ast.Ident); {
+					case key == nil:
+					case key.Name == "Handler":
+						if fn, ok := kv.Value.(*ast.Ident); ok {
+							handler = funcs[fn.Name]
+						}
+					case key.Name == "Help":
+						helpVar = findHelpVar(kv.Value, funcs, make(map[string]bool))
+					}
+				}
+				if helpVar == "" && handler != nil {
+					helpVar = findHelpVar(handler, funcs, make(map[string]bool))
+				}
+				c.OpID = helpOps[helpVar]
+				if c.OpID == "" {
+					c.OpID = cmdName
+				}
+				if handler != nil {
+					collectFlags(&c, handler, funcs, make(map[string]bool))
+				}
+				commands = append(commands, c)
+				return true
+			})
+		}
+	}
+	sort.Slice(commands, func(i, j int) bool {
+		if commands[i].Subprogram != commands[j].Subprogram {
+			return commands[i].Subprogram < commands[j].Subprogram
+		}
+		return commands[i].Name < commands[j].Name
+	})
+	return commands
+}
+
+// checkCommand lists what makes the hand-written command c inconsistent
+// with the new spec. Commands that implement no operation of either spec,
+// such as 'cache stats', are never inconsistent.
+func (d *specDiff) checkCommand(c registeredCommand) []string {
+	oldOp, inOld := d.oldOps[c.OpID]
+	newOp, inNew := d.newOps[c.OpID]
+	if !inNew {
+		if inOld {
+			return []string{fmt.Sprintf("operation %s was removed", c.OpID)}
+		}
+		return nil
+	}
+
+	var problems []string
+	if tag := newOp.tag(); tag != c.Subprogram {
+		problems = append(problems, fmt.Sprintf("operation %s belongs to %s", c.OpID, tag))
+	}
+	for _, change := range d.opChanges[c.OpID] {
+		// The flags of AddBodyFlags follow the fields of the body.
+		if c.BodyFlags && strings.HasPrefix(change, "request body: field ") {
+			continue
+		}
+		problems = append(problems, c.OpID+": "+change)
+	}
+	for _, p := range newOp.Details.Parameters {
+		if (p.In == "path" || p.In == "query") && p.Required && !c.Flags[p.Name] {
+			problems = append(problems, fmt.Sprintf("no flag for the required parameter --%s", p.Name))
+		}
+	}
+	if inOld {
+		for _, p := range oldOp.Details.Parameters {
+			if c.Flags[p.Name] && !slices.ContainsFunc(newOp.Details.Parameters, func(q Parameter) bool { return q.Name == p.Name }) {
+				problems = append(problems, fmt.Sprintf("flag --%s is no longer a parameter", p.Name))
+			}
+		}
+	}
+	if rb := newOp.Details.RequestBody; inOld && !c.BodyFlags && rb != nil {
+		if rbJSON, ok := rb.Content["application/json"]; ok {
+			refs := make(map[string]bool)
+			schemaRefs(d.newG, &rbJSON.Schema, refs)
+			for _, name := range sortedKeys(refs) {
+				if d.changed[name] {
+					problems = append(problems, fmt.Sprintf("request body schema %s changed", name))
+				}
+			}
+		}
+	}
+	return problems
+}
+
+func printEntries(title string, entries []diffEntry) {
+	if len(entries) == 0 {
+		return
+	}
+	fmt.Printf("%s:\n", title)
+	for _, e := range entries {
+		line := fmt.Sprintf("  %s %s", e.Kind, e.Name)
+		if e.Summary != "" {
+			line += " " + e.Summary
+		}
+		fmt.Println(line)
+		for _, detail := range e.Details {
+			fmt.Printf("      %s\n", detail)
+		}
+	}
+	fmt.Println()
+}
+
+// runDiff implements 'helpcodegen diff': it reports how the operations and
+// component schemas of two versions of the spec differ, and which
+// hand-written commands no longer match the new one. It exits with status 1
+// if there are such commands, or flags of the new spec that a global flag
+// would take.
+func runDiff(args []string) {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	oldFile := fs.String("old", "", "Path to the previous OpenAPI YAML specification.")
+	newFile := fs.String("new", "", "Path to the new OpenAPI YAML specification.")
+	outdirRoot := fs.String("outdir_root", "cmd", "Root directory of the cmd subprogram packages whose hand-written commands are checked against --new.")
+	fs.Parse(args)
+
+	if *oldFile == "" || *newFile == "" {
+		log.Fatal("Both --old and --new flags are required.")
+	}
+
+	d := &specDiff{old: loadSpec(*oldFile), new: loadSpec(*newFile)}
+	d.oldG = &modelGen{schemas: d.old.Components.Schemas, decls: make(map[string]string)}
+	d.newG = &modelGen{schemas: d.new.Components.Schemas, decls: make(map[string]string)}
+	d.compare()
+
+	helpOps := make(map[string]string)
+	for _, ops := range []map[string]SpecOperation{d.oldOps, d.newOps} {
+		for id := range ops {
+			helpOps["help"+toCamelCase(id, true)+"Content"] = id
+		}
+	}
+	var inconsistent []diffEntry
+	for _, c := range scanCommands(*outdirRoot, helpOps) {
+		if problems := d.checkCommand(c); len(problems) > 0 {
+			inconsistent = append(inconsistent, diffEntry{
+				Kind:    "!",
+				Name:    c.Subprogram + " " + c.Name,
+				Summary: fmt.Sprintf("(%s:%d)", c.Pos.Filename, c.Pos.Line),
+				Details: problems,
+			})
+		}
+	}
+
+	if len(d.Operations)+len(d.Schemas) == 0 {
+		fmt.Println("The operations and schemas of both specs are the same.")
+	}
+	printEntries("Operations", d.Operations)
+	printEntries("Schemas", d.Schemas)
+	printEntries("Commands inconsistent with the new spec", inconsistent)
+	// go generate refuses these; see globalFlagCollisions.
+	var collisions []diffEntry
+	for _, c := range globalFlagCollisions(d.new) {
+		collisions = append(collisions, diffEntry{Kind: "!", Name: c})
+	}
+	printEntries("Parameters and body fields named like a global flag", collisions)
+	if len(inconsistent)+len(collisions) > 0 {
+		os.Exit(1)
+	}
+}
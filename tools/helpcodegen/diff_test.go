@@ -0,0 +1,183 @@
+package main
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+const diffOldSpec = `
+paths:
+  /series/{id}:
+    get:
+      operationId: retrieveSeries
+      tags: [series]
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: integer, format: int64}}
+      responses:
+        "200":
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/SeriesV1'}
+  /series/{id}/groups:
+    get:
+      operationId: retrieveSeriesGroups
+      tags: [series]
+  /genres:
+    get:
+      operationId: retrieveGenres
+      tags: [genre]
+components:
+  schemas:
+    SeriesV1:
+      type: object
+      properties:
+        title: {type: string}
+        type:
+          type: string
+          enum: [Manga, Manhwa]
+    OldV1:
+      type: object
+`
+
+const diffNewSpec = `
+paths:
+  /series/{id}:
+    get:
+      operationId: retrieveSeries
+      tags: [series]
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: integer, format: int64}}
+        - {name: lang, in: query, required: true, schema: {type: string}}
+      responses:
+        "200":
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/SeriesV1'}
+  /genres:
+    get:
+      operationId: retrieveGenres
+      tags: [genre]
+  /lists:
+    get:
+      operationId: retrieveLists
+      tags: [lists]
+components:
+  schemas:
+    SeriesV1:
+      type: object
+      required: [title]
+      properties:
+        title: {type: string}
+        type:
+          type: string
+          enum: [Manga, Manhua]
+    NewV1:
+      type: array
+      items: {type: string}
+`
+
+func newTestDiff(t *testing.T, oldSpec, newSpec string) *specDiff {
+	t.Helper()
+	d := &specDiff{old: parseTestSpec(t, oldSpec), new: parseTestSpec(t, newSpec)}
+	d.oldG = &modelGen{schemas: d.old.Components.Schemas, decls: make(map[string]string)}
+	d.newG = &modelGen{schemas: d.new.Components.Schemas, decls: make(map[string]string)}
+	d.compare()
+	return d
+}
+
+// captureOutput returns what fn prints to os.Stdout.
+func captureOutput(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+	done := make(chan []byte)
+	go func() {
+		data, _ := io.ReadAll(r)
+		done <- data
+	}()
+	fn()
+	w.Close()
+	return string(<-done)
+}
+
+func TestSpecDiffOutput(t *testing.T) {
+	d := newTestDiff(t, diffOldSpec, diffNewSpec)
+	out := captureOutput(t, func() {
+		printEntries("Operations", d.Operations)
+		printEntries("Schemas", d.Schemas)
+	})
+	want := `Operations:
+  - retrieveSeriesGroups GET /series/{id}/groups (series)
+  + retrieveLists GET /lists (lists)
+  ~ retrieveSeries GET /series/{id} (series)
+      parameter --lang added (query string, required)
+
+Schemas:
+  - OldV1
+  + NewV1 []string
+  ~ SeriesV1
+      field title: optional -> required
+      field type: enum value "Manhwa" removed
+      field type: enum value "Manhua" added
+
+`
+	if out != want {
+		t.Errorf("diff output =\n%s\nwant\n%s", out, want)
+	}
+}
+
+func TestSpecDiffSameSpec(t *testing.T) {
+	d := newTestDiff(t, diffOldSpec, diffOldSpec)
+	if len(d.Operations)+len(d.Schemas) != 0 {
+		t.Errorf("comparing a spec with itself found %v and %v", d.Operations, d.Schemas)
+	}
+}
+
+func TestCheckCommand(t *testing.T) {
+	d := newTestDiff(t, diffOldSpec, diffNewSpec)
+	tests := []struct {
+		name string
+		cmd  registeredCommand
+		want []string
+	}{
+		{
+			name: "removed operation",
+			cmd:  registeredCommand{Subprogram: "series", Name: "groups", OpID: "retrieveSeriesGroups"},
+			want: []string{"operation retrieveSeriesGroups was removed"},
+		},
+		{
+			name: "new required parameter",
+			cmd:  registeredCommand{Subprogram: "series", Name: "retrieveSeries", OpID: "retrieveSeries", Flags: map[string]bool{"id": true}},
+			want: []string{
+				"retrieveSeries: parameter --lang added (query string, required)",
+				"no flag for the required parameter --lang",
+			},
+		},
+		{
+			name: "consistent",
+			cmd:  registeredCommand{Subprogram: "genre", Name: "list", OpID: "retrieveGenres"},
+		},
+		{
+			name: "wrong subprogram",
+			cmd:  registeredCommand{Subprogram: "series", Name: "genres", OpID: "retrieveGenres"},
+			want: []string{"operation retrieveGenres belongs to genre"},
+		},
+		{
+			name: "not an operation",
+			cmd:  registeredCommand{Subprogram: "cache", Name: "stats"},
+		},
+	}
+	for _, tt := range tests {
+		got := d.checkCommand(tt.cmd)
+		if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+			t.Errorf("%s: checkCommand = %q; want %q", tt.name, got, tt.want)
+		}
+	}
+}
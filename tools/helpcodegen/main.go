@@ -10,6 +10,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"text/template"
 
@@ -18,8 +19,15 @@ import (
 
 // --- Structs to model OpenAPI data ---
 type OpenAPISpec struct {
-	Paths    map[string]PathItem   `yaml:"paths"`
-	Security []map[string][]string `yaml:"security"`
+	Paths      map[string]PathItem   `yaml:"paths"`
+	Security   []map[string][]string `yaml:"security"`
+	Components Components            `yaml:"components"`
+	Tags       []Tag                 `yaml:"tags"`
+}
+
+type Tag struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
 }
 
 type PathItem map[string]OperationDetail
@@ -56,13 +64,14 @@ type RequestBody struct {
 }
 
 type MediaType struct {
-	Schema RefSchema `yaml:"schema"`
+	Schema Schema `yaml:"schema"`
 }
 
-type RefSchema struct {
-	Ref   string     `yaml:"$ref"`
-	Type  string     `yaml:"type"`
-	Items *RefSchema `yaml:"items"`
+// SpecOperation is an operation together with where the spec declares it.
+type SpecOperation struct {
+	Details OperationDetail
+	Path    string
+	Method  string
 }
 
 type Response struct {
@@ -77,8 +86,8 @@ type HelpGenData struct {
 	Usage        string
 	Description  string
 	Arguments    []ArgHelpGo
-	InputJSON    string
-	OutputJSON   string
+	InputJSON    string // Go expression: a quoted string or a utils.SchemaHelp
+	OutputJSON   string // Go expression: a quoted string or a utils.SchemaHelp
 	ErrorExample string
 	AuthRequired bool
 }
@@ -89,6 +98,8 @@ type ArgHelpGo struct {
 	Required    bool
 	Description string
 	Default     string
+	In          string
+	Enum        []string
 }
 
 // --- Helper Functions ---
@@ -108,7 +119,7 @@ func toCamelCase(snakeStr string, firstCap bool) string {
 	return result.String()
 }
 
-func getSchemaRefName(schema *RefSchema) string {
+func getSchemaRefName(schema *Schema) string {
 	if schema == nil {
 		return "interface{}"
 	}
@@ -116,13 +127,13 @@ func getSchemaRefName(schema *RefSchema) string {
 		parts := strings.Split(schema.Ref, "/")
 		return parts[len(parts)-1]
 	}
-	if schema.Type == "array" && schema.Items != nil {
+	if schema.Type.Name == "array" && schema.Items != nil {
 		return "[]" + getSchemaRefName(schema.Items)
 	}
-	if schema.Type == "" {
+	if schema.Type.Name == "" {
 		return "object"
 	}
-	return schema.Type
+	return schema.Type.Name
 }
 
 // Template for generating ONLY the var definition
@@ -141,14 +152,20 @@ var {{.HelpVarName}} = utils.HelpContent{
 			{{- if .Default }}
 			Default:     {{printf "%q" .Default}},
 			{{- end }}
+			{{- if .In }}
+			In:          {{printf "%q" .In}},
+			{{- end }}
+			{{- if .Enum }}
+			Enum:        {{printf "%#v" .Enum}},
+			{{- end }}
 		},
 		{{- end }}
 	},
 	{{- else}}
 	Arguments:   nil,
 	{{- end }}
-	InputJSON:     {{printf "%q" .InputJSON}},
-	OutputJSON:    {{printf "%q" .OutputJSON}},
+	InputJSON:     {{.InputJSON}},
+	OutputJSON:    {{.OutputJSON}},
 	ErrorExamples: {{.ErrorExample}},
 	AuthRequired:  {{.AuthRequired}},
 }
@@ -167,7 +184,7 @@ func cleanGeneratedFiles(outdirRoot string, subprogramDirs []string) {
 			continue
 		}
 		for _, f := range files {
-			if strings.HasSuffix(f.Name(), "_generated_help.go") || strings.HasSuffix(f.Name(), "_unformatted.go_error") {
+			if strings.Contains(f.Name(), "_generated_") || strings.HasSuffix(f.Name(), "_unformatted.go_error") {
 				filePath := filepath.Join(dirToClean, f.Name())
 				err := os.Remove(filePath)
 				if err != nil {
@@ -180,34 +197,53 @@ func cleanGeneratedFiles(outdirRoot string, subprogramDirs []string) {
 	}
 }
 
+// loadSpec reads the OpenAPI spec at path.
+func loadSpec(path string) *OpenAPISpec {
+	yamlFile, err := os.ReadFile(path)
+	if err != nil {
+		log.Fatalf("Error reading YAML spec file: %v", err)
+	}
+	var spec OpenAPISpec
+	if err := yaml.Unmarshal(yamlFile, &spec); err != nil {
+		log.Fatalf("Error unmarshalling YAML %s: %v", path, err)
+	}
+	return &spec
+}
+
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "diff" {
+		runDiff(os.Args[2:])
+		return
+	}
+
 	specFile := flag.String("spec", "", "Path to the OpenAPI YAML specification file.")
 	outdirRoot := flag.String("outdir_root", "", "Root directory for cmd subprogram packages (e.g., ./cmd).")
 	clean := flag.Bool("clean", false, "Clean previously generated files before generating new ones.")
+	modelsOut := flag.String("models_out", "", "Also write Go structs for components/schemas to this file (e.g., ./mangaupdates/models_generated.go).")
+	schemasOut := flag.String("schemas_out", "", "Also write the component schemas shown by command help to this file of the utils package (e.g., ./internal/utils/schemas_generated.go).")
+	subprogramsOut := flag.String("subprograms_out", "", "Also write command handlers for operations without a hand-written one, and register the subprograms that have no package yet in this file of package main (e.g., ./subprograms_generated.go).")
 	flag.Parse()
 
 	if *specFile == "" || *outdirRoot == "" {
 		log.Fatal("Both --spec and --outdir_root flags are required.")
 	}
 
-	yamlFile, err := os.ReadFile(*specFile)
-	if err != nil {
-		log.Fatalf("Error reading YAML spec file: %v", err)
+	spec := loadSpec(*specFile)
+	if collisions := globalFlagCollisions(spec); len(collisions) > 0 {
+		log.Fatalf("Error: these parameters and body fields are named like global flags, which would take their values:\n  %s\nRename the global flags in globals.go and in globalFlags of tools/helpcodegen.",
+			strings.Join(collisions, "\n  "))
 	}
 
-	var spec OpenAPISpec
-	err = yaml.Unmarshal(yamlFile, &spec)
-	if err != nil {
-		log.Fatalf("Error unmarshalling YAML: %v", err)
+	if *modelsOut != "" {
+		generateModels(spec, *modelsOut)
+	}
+	if *schemasOut != "" {
+		generateSchemas(spec, *schemasOut)
 	}
 
 	// Collect all potential subprogram directory names first for cleaning
 	potentialSubprogramDirs := make(map[string]bool)
-	subprogramOps := make(map[string]map[string]struct {
-		Details OperationDetail
-		Path    string
-		Method  string
-	})
+	subprogramOps := make(map[string]map[string]SpecOperation)
 
 	for pathStr, pathItem := range spec.Paths {
 		for methodStr, opDetail := range pathItem {
@@ -223,17 +259,9 @@ func main() {
 			potentialSubprogramDirs[subprogramName] = true // Store for cleaning pass
 
 			if _, ok := subprogramOps[subprogramName]; !ok {
-				subprogramOps[subprogramName] = make(map[string]struct {
-					Details OperationDetail
-					Path    string
-					Method  string
-				})
+				subprogramOps[subprogramName] = make(map[string]SpecOperation)
 			}
-			subprogramOps[subprogramName][opDetail.OperationID] = struct {
-				Details OperationDetail
-				Path    string
-				Method  string
-			}{Details: opDetail, Path: pathStr, Method: methodStr}
+			subprogramOps[subprogramName][opDetail.OperationID] = SpecOperation{Details: opDetail, Path: pathStr, Method: methodStr}
 		}
 	}
 
@@ -245,6 +273,7 @@ func main() {
 		cleanGeneratedFiles(*outdirRoot, dirNames)
 	}
 
+	models := &modelGen{schemas: spec.Components.Schemas, decls: make(map[string]string)}
 	tmpl, err := template.New("helpVar").Parse(helpVarTemplate)
 	if err != nil {
 		log.Fatalf("Error parsing template: %v", err)
@@ -254,7 +283,7 @@ func main() {
 		var allHelpContents bytes.Buffer
 		fmt.Fprintf(&allHelpContents, "// Code generated by tools/helpcodegen/main.go; DO NOT EDIT.\n")
 		fmt.Fprintf(&allHelpContents, "package %s\n\n", spName)
-		fmt.Fprintf(&allHelpContents, "import \"mangaupdatescli/internal/utils\"\n\n")
+		fmt.Fprintf(&allHelpContents, "import \"github.com/TheDucker1/mangaupdatescli/internal/utils\"\n\n")
 
 		var opIDs []string
 		for opID := range opsMap {
@@ -265,7 +294,7 @@ func main() {
 		for _, opID := range opIDs {
 			opData := opsMap[opID]
 			cliCommandName := opID
-			authRequired := len(opData.Details.Security) > 0 || (len(opData.Details.Security) == 0 && len(spec.Security) > 0)
+			authRequired := spec.authRequired(opData.Details)
 
 			data := HelpGenData{
 				PackageName:  spName,
@@ -297,20 +326,55 @@ func main() {
 						Required:    param.Required,
 						Description: param.Description,
 						Default:     argDefault,
+						In:          param.In,
+						Enum:        param.Schema.Enum,
 					})
 				}
 			}
+			// A JSON body is given with --body and the flags of its fields,
+			// see utils.AddBodyFlags.
+			if rb := opData.Details.RequestBody; rb != nil {
+				if rbJSON, ok := rb.Content["application/json"]; ok {
+					fields, dropRequired := withoutFlagCollisions(opID, bodyArguments(models, &rbJSON.Schema), opData.Details.Parameters)
+					describeBodyFields(opID, fields)
+					for _, arg := range fields {
+						usageParamStr := fmt.Sprintf("--%s <%s>", arg.Name, arg.Type)
+						if !arg.Required {
+							usageParamStr = "[" + usageParamStr + "]"
+						}
+						usageParts = append(usageParts, usageParamStr)
+						data.Arguments = append(data.Arguments, arg)
+					}
+					bodyArg := ArgHelpGo{
+						Name:        "body",
+						Type:        "JSON|YAML",
+						Required:    rb.Required && (len(fields) == 0 || dropRequired),
+						Description: "Request body as a JSON or YAML document, @file to read it from a file, or - for stdin. The flags of its fields override it.",
+					}
+					usageParamStr := "--body <JSON|YAML|@file|->"
+					if !bodyArg.Required {
+						usageParamStr = "[" + usageParamStr + "]"
+					}
+					usageParts = append(usageParts, usageParamStr)
+					data.Arguments = append(data.Arguments, bodyArg)
+				}
+			}
 			data.Usage = strings.Join(usageParts, " ")
 			if data.AuthRequired {
 				data.Usage += " [REQUIRES AUTH]"
 			}
 
+			// JSON schemas become field trees (utils.SchemaHelp); anything
+			// else is described by a quoted string.
+			inputSchema, outputSchema := false, false
 			if opData.Details.RequestBody != nil && opData.Details.RequestBody.Content != nil {
 				if rbJSON, ok := opData.Details.RequestBody.Content["application/json"]; ok {
-					data.InputJSON = fmt.Sprintf("Request Body Schema: <%s:L1>", getSchemaRefName(&rbJSON.Schema))
+					label := "Request Body Schema"
 					if opData.Details.RequestBody.Required {
-						data.InputJSON += " (required)"
+						label += " (required)"
 					}
+					data.InputJSON = schemaHelpLiteral(models, label, &rbJSON.Schema)
+					inputSchema = true
 				} else if _, okMulti := opData.Details.RequestBody.Content["multipart/form-data"]; okMulti {
 					data.InputJSON = "Request Body: Multipart Form Data"
 					if opData.Details.RequestBody.Required {
@@ -327,6 +391,9 @@ func main() {
 			} else {
 				data.InputJSON = "None"
 			}
+			if !inputSchema {
+				data.InputJSON = strconv.Quote(data.InputJSON)
+			}
 
 			var successStatusCode string
 			successStatusCodes := []string{"200", "201", "202", "204"}
@@ -340,7 +407,8 @@ func main() {
 				respSuccess := opData.Details.Responses[successStatusCode]
 				if respSuccess.Content != nil {
 					if contentJSON, ok := respSuccess.Content["application/json"]; ok {
-						data.OutputJSON = fmt.Sprintf("Schema (on %s): <%s:L1>", successStatusCode, getSchemaRefName(&contentJSON.Schema))
+						data.OutputJSON = schemaHelpLiteral(models, fmt.Sprintf("Schema (on %s)", successStatusCode), &contentJSON.Schema)
+						outputSchema = true
 					} else if _, okXml := respSuccess.Content["application/xml"]; okXml {
 						data.OutputJSON = fmt.Sprintf("XML Output (on %s)", successStatusCode)
 					} else if len(respSuccess.Content) > 0 {
@@ -359,6 +427,9 @@ func main() {
 			} else {
 				data.OutputJSON = "Schema: <ApiResponseV1:L1> (Default success, or specific success code)"
 			}
+			if !outputSchema {
+				data.OutputJSON = strconv.Quote(data.OutputJSON)
+			}
 
 			errorMap := make(map[string]string)
 			for code, respInfo := range opData.Details.Responses {
@@ -424,5 +495,8 @@ func main() {
 			fmt.Printf("No operations to generate help for in subprogram %s. Skipping file generation.\n", spName)
 		}
 	}
+	if *subprogramsOut != "" {
+		generateCommands(spec, subprogramOps, *outdirRoot, *subprogramsOut)
+	}
 	fmt.Println("Help code generation complete.")
 }
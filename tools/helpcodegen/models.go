@@ -0,0 +1,395 @@
+// tools/helpcodegen/models.go
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// --- Structs to model OpenAPI component schemas ---
+
+type Components struct {
+	Schemas map[string]*Schema `yaml:"schemas"`
+}
+
+type Schema struct {
+	Ref                  string                `yaml:"$ref"`
+	Type                 SchemaType            `yaml:"type"`
+	Format               string                `yaml:"format"`
+	Description          string                `yaml:"description"`
+	Nullable             bool                  `yaml:"nullable"`
+	Enum                 []interface{}         `yaml:"enum"`
+	Items                *Schema               `yaml:"items"`
+	Properties           Properties            `yaml:"properties"`
+	Required             []string              `yaml:"required"`
+	AllOf                []*Schema             `yaml:"allOf"`
+	OneOf                []*Schema             `yaml:"oneOf"`
+	AnyOf                []*Schema             `yaml:"anyOf"`
+	AdditionalProperties *AdditionalProperties `yaml:"additionalProperties"`
+}
+
+// SchemaType is the type of a schema. OpenAPI 3.1 allows a list such as
+// [string, "null"], which is read as a nullable string.
+type SchemaType struct {
+	Name     string
+	Nullable bool
+}
+
+func (t *SchemaType) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		return node.Decode(&t.Name)
+	}
+	var names []string
+	if err := node.Decode(&names); err != nil {
+		return err
+	}
+	for _, name := range names {
+		if name == "null" {
+			t.Nullable = true
+		} else if t.Name == "" {
+			t.Name = name
+		}
+	}
+	return nil
+}
+
+// Properties keeps the properties of a schema in spec order, so generated
+// structs list their fields the way the spec does.
+type Properties struct {
+	Names   []string
+	Schemas map[string]*Schema
+}
+
+func (p *Properties) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: properties must be a mapping", node.Line)
+	}
+	p.Schemas = make(map[string]*Schema)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		name := node.Content[i].Value
+		var s Schema
+		if err := node.Content[i+1].Decode(&s); err != nil {
+			return err
+		}
+		p.Names = append(p.Names, name)
+		p.Schemas[name] = &s
+	}
+	return nil
+}
+
+// AdditionalProperties is either a boolean or a schema.
+type AdditionalProperties struct {
+	Allowed bool
+	Schema  *Schema
+}
+
+func (a *AdditionalProperties) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		return node.Decode(&a.Allowed)
+	}
+	a.Allowed = true
+	return node.Decode(&a.Schema)
+}
+
+func (s *Schema) isNullable() bool {
+	return s.Nullable || s.Type.Nullable
+}
+
+// --- Go code generation ---
+
+// modelGen turns component schemas into Go declarations. Inline objects and
+// enums get named types derived from the schema and property names, so that
+// callers can construct them without anonymous struct literals.
+type modelGen struct {
+	schemas map[string]*Schema
+	decls   map[string]string // type name -> declaration
+	order   []string
+}
+
+// goInitialisms are the words written in all caps in Go identifiers.
+var goInitialisms = map[string]bool{"id": true, "uid": true, "url": true, "rss": true, "api": true, "http": true, "json": true, "html": true}
+
+// goName turns a JSON name such as "series_id" or "Drama CD" into an
+// exported Go identifier such as "SeriesID" or "DramaCD".
+func goName(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var b strings.Builder
+	for _, w := range words {
+		if goInitialisms[strings.ToLower(w)] {
+			b.WriteString(strings.ToUpper(w))
+			continue
+		}
+		b.WriteString(strings.ToUpper(w[:1]))
+		b.WriteString(w[1:])
+	}
+	if b.Len() == 0 || unicode.IsDigit(rune(b.String()[0])) {
+		return "X" + b.String()
+	}
+	return b.String()
+}
+
+func refName(ref string) string {
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
+
+// resolve follows a $ref, or a single-element allOf used to attach
+// nullable or a description to one, and returns the target schema.
+func (g *modelGen) resolve(s *Schema) *Schema {
+	for {
+		switch {
+		case s.Ref != "":
+			target, ok := g.schemas[refName(s.Ref)]
+			if !ok {
+				log.Fatalf("Unresolved schema reference %q", s.Ref)
+			}
+			s = target
+		case len(s.AllOf) == 1 && s.Type.Name == "" && s.Properties.Names == nil:
+			s = s.AllOf[0]
+		default:
+			return s
+		}
+	}
+}
+
+// isStruct reports whether s becomes a Go struct.
+func (g *modelGen) isStruct(s *Schema) bool {
+	s = g.resolve(s)
+	if len(s.AllOf) > 0 || s.Properties.Names != nil {
+		return true
+	}
+	return s.Type.Name == "object" && s.AdditionalProperties == nil
+}
+
+// goType returns the Go type of s, declaring a named type called name for
+// it if it is an inline object or enum. where says what s is, for the doc
+// comment of that type (e.g. "the anime property of SeriesModelV1").
+func (g *modelGen) goType(s *Schema, name, where string) string {
+	if s.Ref != "" {
+		refName := refName(s.Ref)
+		g.resolve(s) // fail early on a dangling reference
+		return refName
+	}
+	if len(s.AllOf) == 1 && s.Type.Name == "" && s.Properties.Names == nil {
+		return g.goType(s.AllOf[0], name, where)
+	}
+	if len(s.OneOf) > 0 || len(s.AnyOf) > 0 {
+		return "interface{}"
+	}
+	if len(s.Enum) > 0 && s.Type.Name == "string" {
+		g.declareEnum(name, s, where)
+		return name
+	}
+	switch s.Type.Name {
+	case "string":
+		return "string"
+	case "boolean":
+		return "bool"
+	case "integer":
+		switch s.Format {
+		case "int64":
+			return "int64"
+		case "int32":
+			return "int32"
+		}
+		return "int"
+	case "number":
+		return "float64"
+	case "array":
+		if s.Items == nil {
+			return "[]interface{}"
+		}
+		return "[]" + g.goType(s.Items, name+"Item", "an item of "+where)
+	}
+	if s.AdditionalProperties != nil && s.Properties.Names == nil && len(s.AllOf) == 0 {
+		if s.AdditionalProperties.Schema != nil {
+			return "map[string]" + g.goType(s.AdditionalProperties.Schema, name+"Value", "a value of "+where)
+		}
+		return "map[string]interface{}"
+	}
+	if g.isStruct(s) {
+		g.declareStruct(name, s, where)
+		return name
+	}
+	return "interface{}"
+}
+
+func (g *modelGen) declare(name, decl string) {
+	if _, ok := g.decls[name]; ok {
+		log.Fatalf("Generated type name %s is used twice; rename the schema or property", name)
+	}
+	g.decls[name] = decl
+	g.order = append(g.order, name)
+}
+
+// writeDocComment writes text as a comment, or fallback if text is empty.
+func writeDocComment(b *bytes.Buffer, indent, text, fallback string) {
+	if strings.TrimSpace(text) == "" {
+		text = fallback
+	}
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		fmt.Fprintf(b, "%s// %s\n", indent, strings.TrimSpace(line))
+	}
+}
+
+func (g *modelGen) declareEnum(name string, s *Schema, where string) {
+	var b bytes.Buffer
+	writeDocComment(&b, "", s.Description, fmt.Sprintf("%s enumerates the values of %s.", name, where))
+	fmt.Fprintf(&b, "type %s string\n\nconst (\n", name)
+	seen := make(map[string]bool)
+	for _, v := range s.Enum {
+		value := fmt.Sprint(v)
+		constName := name + goName(value)
+		for i := 2; seen[constName]; i++ {
+			constName = fmt.Sprintf("%s%s%d", name, goName(value), i)
+		}
+		seen[constName] = true
+		fmt.Fprintf(&b, "\t%s %s = %q\n", constName, name, value)
+	}
+	b.WriteString(")\n")
+	g.declare(name, b.String())
+}
+
+// fields collects the properties of s, including those of its allOf
+// members, in spec order.
+func (g *modelGen) fields(s *Schema) (names []string, schemas map[string]*Schema, required map[string]bool) {
+	schemas = make(map[string]*Schema)
+	required = make(map[string]bool)
+	var collect func(s *Schema)
+	collect = func(s *Schema) {
+		s = g.resolve(s)
+		for _, part := range s.AllOf {
+			collect(part)
+		}
+		for _, n := range s.Properties.Names {
+			if _, ok := schemas[n]; !ok {
+				names = append(names, n)
+			}
+			schemas[n] = s.Properties.Schemas[n]
+		}
+		for _, n := range s.Required {
+			required[n] = true
+		}
+	}
+	collect(s)
+	return names, schemas, required
+}
+
+func (g *modelGen) declareStruct(name string, s *Schema, where string) {
+	names, props, required := g.fields(s)
+
+	var b bytes.Buffer
+	writeDocComment(&b, "", s.Description, fmt.Sprintf("%s is %s.", name, where))
+	fmt.Fprintf(&b, "type %s struct {\n", name)
+	// Reserve the name before generating the fields, so a schema that
+	// refers to itself is not declared twice.
+	g.declare(name, "")
+	for _, jsonName := range names {
+		prop := props[jsonName]
+		fieldName := goName(jsonName)
+		fieldType := g.goType(prop, name+fieldName, fmt.Sprintf("the %s property of %s", jsonName, name))
+
+		// Nullable fields are pointers so that null and a zero value stay
+		// apart. Optional booleans and structs are pointers as well, since
+		// omitempty cannot otherwise tell "false"/"empty" from "unset".
+		target := g.resolve(prop)
+		pointer := prop.isNullable() || target.isNullable()
+		if !required[jsonName] && (target.Type.Name == "boolean" || g.isStruct(prop)) {
+			pointer = true
+		}
+		if fieldType == name && g.isStruct(prop) {
+			pointer = true // a struct cannot contain itself
+		}
+		if strings.HasPrefix(fieldType, "[]") || strings.HasPrefix(fieldType, "map[") || fieldType == "interface{}" {
+			pointer = false
+		}
+		if pointer {
+			fieldType = "*" + fieldType
+		}
+
+		tag := jsonName
+		if !required[jsonName] {
+			tag += ",omitempty"
+		}
+		if prop.Description != "" {
+			writeDocComment(&b, "\t", prop.Description, "")
+		}
+		fmt.Fprintf(&b, "\t%s %s `json:%q`\n", fieldName, fieldType, tag)
+	}
+	b.WriteString("}\n")
+	g.decls[name] = b.String()
+}
+
+// declareSchema declares the Go type of the component schema name.
+func (g *modelGen) declareSchema(name string, s *Schema) {
+	where := "the " + name + " schema"
+	if g.isStruct(s) && s.Ref == "" {
+		g.declareStruct(name, s, where)
+		return
+	}
+	if len(s.Enum) > 0 && s.Type.Name == "string" {
+		g.declareEnum(name, s, where)
+		return
+	}
+	var b bytes.Buffer
+	writeDocComment(&b, "", s.Description, fmt.Sprintf("%s is %s.", name, where))
+	// Build the underlying type first: it may declare item types.
+	underlying := g.goType(s, name+"Item", where)
+	fmt.Fprintf(&b, "type %s %s\n", name, underlying)
+	g.declare(name, b.String())
+}
+
+// generateModels writes a Go file to outFile declaring a type for every
+// component schema of spec.
+func generateModels(spec *OpenAPISpec, outFile string) {
+	g := &modelGen{schemas: spec.Components.Schemas, decls: make(map[string]string)}
+
+	var names []string
+	for name := range g.schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, ok := g.decls[name]; ok {
+			continue
+		}
+		g.declareSchema(name, g.schemas[name])
+	}
+
+	pkg := filepath.Base(filepath.Dir(outFile))
+	if abs, err := filepath.Abs(outFile); err == nil {
+		pkg = filepath.Base(filepath.Dir(abs))
+	}
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by tools/helpcodegen; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&out, "package %s\n", pkg)
+	sort.Strings(g.order)
+	for _, name := range g.order {
+		out.WriteString("\n")
+		out.WriteString(g.decls[name])
+	}
+
+	if err := os.MkdirAll(filepath.Dir(outFile), 0755); err != nil {
+		log.Fatalf("Error creating directory for %s: %v", outFile, err)
+	}
+	formatted, err := format.Source(out.Bytes())
+	if err != nil {
+		log.Printf("Error formatting Go source for models (writing unformatted to %s_unformatted.go_error): %v", outFile, err)
+		os.WriteFile(outFile+"_unformatted.go_error", out.Bytes(), 0644)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(outFile, formatted, 0644); err != nil {
+		log.Fatalf("Error writing Go file %s: %v", outFile, err)
+	}
+	fmt.Printf("Generated %d models at %s\n", len(g.order), outFile)
+}
@@ -0,0 +1,118 @@
+// tools/helpcodegen/schemas.go
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// schemaLiteral returns Go source for s as a *utils.Schema, for the schema
+// trees of the command help. References to component schemas stay
+// references. qual qualifies the type names ("utils." outside the package).
+func schemaLiteral(g *modelGen, s *Schema, qual string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "&%sSchema{", qual)
+	if s.Description != "" {
+		fmt.Fprintf(&b, "Description: %q, ", strings.TrimSpace(s.Description))
+	}
+	if s.isNullable() {
+		b.WriteString("Nullable: true, ")
+	}
+	// A single-element allOf only attaches the description or nullable above.
+	for s.Ref == "" && len(s.AllOf) == 1 && s.Type.Name == "" && s.Properties.Names == nil {
+		s = s.AllOf[0]
+	}
+	if s.Ref != "" {
+		g.resolve(s) // fail early on a dangling reference
+		fmt.Fprintf(&b, "Ref: %q}", refName(s.Ref))
+		return b.String()
+	}
+
+	if alts := slices.Concat(s.OneOf, s.AnyOf); len(alts) > 0 {
+		fmt.Fprintf(&b, "OneOf: []*%sSchema{", qual)
+		for _, alt := range alts {
+			b.WriteString(schemaLiteral(g, alt, qual) + ", ")
+		}
+		b.WriteString("}, ")
+	}
+	if s.Type.Name != "" {
+		typ := s.Type.Name
+		if s.Format != "" {
+			typ += "(" + s.Format + ")"
+		}
+		fmt.Fprintf(&b, "Type: %q, ", typ)
+	}
+	if len(s.Enum) > 0 {
+		enum := make([]string, len(s.Enum))
+		for i, v := range s.Enum {
+			enum[i] = fmt.Sprint(v)
+		}
+		fmt.Fprintf(&b, "Enum: %#v, ", enum)
+	}
+	if s.Items != nil {
+		fmt.Fprintf(&b, "Items: %s, ", schemaLiteral(g, s.Items, qual))
+	}
+	if ap := s.AdditionalProperties; ap != nil && ap.Schema != nil && s.Properties.Names == nil {
+		fmt.Fprintf(&b, "Values: %s, ", schemaLiteral(g, ap.Schema, qual))
+	}
+	if len(s.AllOf) > 0 || s.Properties.Names != nil {
+		names, props, required := g.fields(s)
+		fmt.Fprintf(&b, "Properties: []%sSchemaProperty{", qual)
+		for _, name := range names {
+			fmt.Fprintf(&b, "{Name: %q, Required: %t, Schema: %s}, ", name, required[name], schemaLiteral(g, props[name], qual))
+		}
+		b.WriteString("}, ")
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// schemaHelpLiteral returns Go source for a utils.SchemaHelp.
+func schemaHelpLiteral(g *modelGen, label string, s *Schema) string {
+	return fmt.Sprintf("utils.SchemaHelp{Label: %q, Schema: %s}", label, schemaLiteral(g, s, "utils."))
+}
+
+// generateSchemas writes the component schemas of spec, as the utils.Schemas
+// registry the command help resolves references in, and the JSON success
+// response of each operation, as utils.ResponseSchemas, to outFile.
+func generateSchemas(spec *OpenAPISpec, outFile string) {
+	g := &modelGen{schemas: spec.Components.Schemas, decls: make(map[string]string)}
+
+	var names []string
+	for name := range g.schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pkg := filepath.Base(filepath.Dir(outFile))
+	if abs, err := filepath.Abs(outFile); err == nil {
+		pkg = filepath.Base(filepath.Dir(abs))
+	}
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by tools/helpcodegen; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&out, "package %s\n\n", pkg)
+	out.WriteString("func init() {\n\tSchemas = map[string]*Schema{\n")
+	for _, name := range names {
+		fmt.Fprintf(&out, "%q: %s,\n", name, schemaLiteral(g, g.schemas[name], ""))
+	}
+	out.WriteString("}\n")
+
+	ops := indexOperations(spec)
+	out.WriteString("\tResponseSchemas = map[string]*Schema{\n")
+	responses := 0
+	for _, opID := range sortedKeys(ops) {
+		_, resp := successResponse(ops[opID].Details)
+		if media, ok := resp.Content["application/json"]; ok {
+			fmt.Fprintf(&out, "%q: %s,\n", opID, schemaLiteral(g, &media.Schema, ""))
+			responses++
+		}
+	}
+	out.WriteString("}\n}\n")
+
+	writeGoSource(outFile, out.Bytes())
+	fmt.Printf("Generated %d help schemas and %d response schemas at %s\n", len(names), responses, outFile)
+}